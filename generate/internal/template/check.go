@@ -0,0 +1,246 @@
+package template
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"text/template"
+	"text/template/parse"
+)
+
+// checkFields statically checks that every field and method a template
+// references exists on the data it is rendered against: the entry template
+// against *ServiceDesc and AggregateTemplate against *AggregateDesc, following
+// {{range}}, {{with}}, variables and {{template}} calls. A typo such as
+// .Commment is then reported when the template is parsed instead of failing,
+// or rendering nothing, for some services only. Values whose type is not known
+// statically, such as interfaces and function results of type any, are not
+// followed.
+func checkFields(tmpl *template.Template) error {
+	c := &fieldChecker{tmpl: tmpl, funcs: Funcs(), visited: make(map[string]bool)}
+	c.checkTemplate(tmpl, reflect.TypeOf(&ServiceDesc{}))
+	if aggregate := tmpl.Lookup(AggregateTemplate); aggregate != nil {
+		c.checkTemplate(aggregate, reflect.TypeOf(&AggregateDesc{}))
+	}
+	return errors.Join(c.errs...)
+}
+
+type fieldChecker struct {
+	tmpl    *template.Template
+	funcs   template.FuncMap
+	visited map[string]bool // template name and data type already checked
+	errs    []error
+}
+
+// checkTemplate checks t rendered with dot of type data, once per pair.
+func (c *fieldChecker) checkTemplate(t *template.Template, data reflect.Type) {
+	key := t.Name() + "\x00" + data.String()
+	if t.Tree == nil || c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+	c.walk(t.Tree, t.Tree.Root, data, map[string]reflect.Type{"$": data})
+}
+
+// walk checks node with dot of type dot; a nil type is unknown and not
+// checked. vars holds the variables in scope and is updated by declarations.
+func (c *fieldChecker) walk(tree *parse.Tree, node parse.Node, dot reflect.Type, vars map[string]reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(tree, child, dot, vars)
+		}
+	case *parse.ActionNode:
+		c.pipe(tree, n.Pipe, dot, vars)
+	case *parse.IfNode:
+		c.pipe(tree, n.Pipe, dot, vars)
+		c.walk(tree, n.List, dot, scope(vars))
+		c.walk(tree, n.ElseList, dot, scope(vars))
+	case *parse.WithNode:
+		inner := scope(vars)
+		t := c.pipe(tree, n.Pipe, dot, inner)
+		c.walk(tree, n.List, t, inner)
+		c.walk(tree, n.ElseList, dot, scope(vars))
+	case *parse.RangeNode:
+		inner := scope(vars)
+		t := c.pipe(tree, n.Pipe, dot, inner)
+		key, elem := rangeTypes(t)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			inner[n.Pipe.Decl[0].Ident[0]] = key
+			inner[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		c.walk(tree, n.List, elem, inner)
+		c.walk(tree, n.ElseList, dot, scope(vars))
+	case *parse.TemplateNode:
+		if n.Pipe == nil {
+			return
+		}
+		if t := c.pipe(tree, n.Pipe, dot, vars); t != nil {
+			if called := c.tmpl.Lookup(n.Name); called != nil {
+				c.checkTemplate(called, t)
+			}
+		}
+	}
+}
+
+// pipe checks a pipeline and returns the type of its result, declaring its
+// variables in vars.
+func (c *fieldChecker) pipe(tree *parse.Tree, pipe *parse.PipeNode, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	var t reflect.Type
+	for _, cmd := range pipe.Cmds {
+		t = c.command(tree, cmd, dot, vars)
+	}
+	if len(pipe.Decl) == 1 {
+		vars[pipe.Decl[0].Ident[0]] = t
+	}
+	return t
+}
+
+// command checks every argument of cmd and returns the type of its result.
+func (c *fieldChecker) command(tree *parse.Tree, cmd *parse.CommandNode, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	var t reflect.Type
+	for i, arg := range cmd.Args {
+		argType := c.arg(tree, arg, dot, vars)
+		if i == 0 {
+			t = argType
+		}
+	}
+	return t
+}
+
+func (c *fieldChecker) arg(tree *parse.Tree, node parse.Node, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return c.fields(tree, n, dot, n.Ident)
+	case *parse.VariableNode:
+		return c.fields(tree, n, vars[n.Ident[0]], n.Ident[1:])
+	case *parse.ChainNode:
+		return c.fields(tree, n, c.arg(tree, n.Node, dot, vars), n.Field)
+	case *parse.PipeNode:
+		return c.pipe(tree, n, dot, scope(vars))
+	case *parse.IdentifierNode:
+		return c.funcResult(n.Ident)
+	case *parse.StringNode:
+		return reflect.TypeOf("")
+	case *parse.BoolNode:
+		return reflect.TypeOf(false)
+	}
+	return nil
+}
+
+// fields resolves a chain of field or method names starting at type t,
+// reporting the first name t does not have.
+func (c *fieldChecker) fields(tree *parse.Tree, node parse.Node, t reflect.Type, names []string) reflect.Type {
+	for _, name := range names {
+		if t == nil {
+			return nil
+		}
+		if method, ok := lookupMethod(t, name); ok {
+			if method.Type.NumOut() == 0 {
+				return nil
+			}
+			t = method.Type.Out(0)
+			continue
+		}
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := t.FieldByName(name)
+			if !ok || !field.IsExported() {
+				c.unknown(tree, node, t, name)
+				return nil
+			}
+			t = field.Type
+		case reflect.Map:
+			t = t.Elem()
+		case reflect.Interface:
+			return nil
+		default:
+			c.unknown(tree, node, t, name)
+			return nil
+		}
+	}
+	return t
+}
+
+// unknown reports that type t, referenced at node, has no field name.
+func (c *fieldChecker) unknown(tree *parse.Tree, node parse.Node, t reflect.Type, name string) {
+	location, _ := tree.ErrorContext(node)
+	c.errs = append(c.errs, fmt.Errorf("template: %s: %s has no field or method %s", location, t, name))
+}
+
+// funcResult returns the result type of the template function name, or nil
+// when it is not known.
+func (c *fieldChecker) funcResult(name string) reflect.Type {
+	switch name {
+	case "len":
+		return reflect.TypeOf(0)
+	case "print", "printf", "println", "html", "js", "urlquery":
+		return reflect.TypeOf("")
+	case "not", "eq", "ne", "lt", "le", "gt", "ge":
+		return reflect.TypeOf(false)
+	}
+	fn, ok := c.funcs[name]
+	if !ok {
+		return nil
+	}
+	ft := reflect.TypeOf(fn)
+	if ft.Kind() != reflect.Func || ft.NumOut() == 0 {
+		return nil
+	}
+	return ft.Out(0)
+}
+
+// lookupMethod finds the method name of t or, like text/template for
+// addressable values, of *t.
+func lookupMethod(t reflect.Type, name string) (reflect.Method, bool) {
+	if method, ok := t.MethodByName(name); ok {
+		return method, true
+	}
+	if t.Kind() != reflect.Pointer && t.Kind() != reflect.Interface {
+		return reflect.PointerTo(t).MethodByName(name)
+	}
+	return reflect.Method{}, false
+}
+
+// rangeTypes returns the key and element types of ranging over t.
+func rangeTypes(t reflect.Type) (key, elem reflect.Type) {
+	if t == nil {
+		return nil, nil
+	}
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), t.Elem()
+	case reflect.Map:
+		return t.Key(), t.Elem()
+	case reflect.Int:
+		return t, t
+	}
+	return nil, nil
+}
+
+// scope returns a copy of vars for a nested control structure, whose
+// declarations end with it.
+func scope(vars map[string]reflect.Type) map[string]reflect.Type {
+	inner := make(map[string]reflect.Type, len(vars))
+	for name, t := range vars {
+		inner[name] = t
+	}
+	return inner
+}
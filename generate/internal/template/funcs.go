@@ -0,0 +1,192 @@
+package template
+
+import (
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Funcs returns the function map available to every template. String
+// arguments come last so the helpers compose in pipelines, e.g.
+// {{.Name | trimPrefix "Get" | snakeCase}}.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		"snakeCase":  snakeCase,
+		"kebabCase":  kebabCase,
+		"camelCase":  camelCase,
+		"pascalCase": pascalCase,
+		"upperFirst": upperFirst,
+		"lowerFirst": lowerFirst,
+		"lower":      strings.ToLower,
+		"upper":      strings.ToUpper,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"hasPrefix":  func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix":  func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"contains":   func(substr, s string) bool { return strings.Contains(s, substr) },
+		"replace":    func(old, new, s string) string { return strings.ReplaceAll(s, old, new) },
+		"split":      func(sep, s string) []string { return strings.Split(s, sep) },
+		"join":       func(sep string, elems []string) string { return strings.Join(elems, sep) },
+		"quote":      strconv.Quote,
+		"qualify":    qualify,
+
+		"goQuote":       strconv.Quote,
+		"goIdent":       goIdent,
+		"goStringMap":   goStringMap,
+		"goStringSlice": goStringSlice,
+	}
+}
+
+// qualify is the fallback for {{qualify "path" "Name"}} when no Qualifier is
+// bound (see Generator.WithQualifier): it uses the last import path element
+// as the package name and registers no import.
+func qualify(importPath, name string) string {
+	return path.Base(importPath) + "." + name
+}
+
+// goIdent makes s a valid Go identifier: runes other than letters, digits and
+// underscores become underscores, a leading digit or an empty s gets an
+// underscore prefix, and keywords an underscore suffix. The case of s is kept,
+// so {{.Key | pascalCase | goIdent}} yields an exported name.
+func goIdent(s string) string {
+	ident := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return '_'
+	}, s)
+	if r, _ := utf8.DecodeRuneInString(ident); ident == "" || unicode.IsDigit(r) {
+		ident = "_" + ident
+	}
+	if token.IsKeyword(ident) {
+		ident += "_"
+	}
+	return ident
+}
+
+// goStringMap returns the Go literal of m, with its keys sorted, e.g.
+// map[string]string{"command": "start"}. Keys and values are quoted, so any
+// extra renders as compilable code.
+func goStringMap(m map[string]string) string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		entries[i] = strconv.Quote(key) + ": " + strconv.Quote(m[key])
+	}
+	return "map[string]string{" + strings.Join(entries, ", ") + "}"
+}
+
+// goStringSlice returns the Go literal of values, e.g. []string{"start",
+// "begin"}.
+func goStringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
+
+// splitWords splits s into words on any non-letter, non-digit rune and on case
+// boundaries, keeping acronyms together: "HTTPServer_v2" -> [HTTP Server v2].
+func splitWords(s string) []string {
+	var words []string
+	var word []rune
+	runes := []rune(s)
+	flush := func() {
+		if len(word) > 0 {
+			words = append(words, string(word))
+			word = nil
+		}
+	}
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			flush()
+			continue
+		}
+		if unicode.IsUpper(r) && len(word) > 0 {
+			prev := word[len(word)-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		word = append(word, r)
+	}
+	flush()
+	return words
+}
+
+// snakeCase converts s to lower snake_case: "UpdateCount" -> "update_count".
+func snakeCase(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "_"))
+}
+
+// SnakeCase is the snakeCase template function, for Go callers such as the
+// {service} file name placeholder.
+func SnakeCase(s string) string {
+	return snakeCase(s)
+}
+
+// kebabCase converts s to lower kebab-case: "UpdateCount" -> "update-count".
+func kebabCase(s string) string {
+	return strings.ToLower(strings.Join(splitWords(s), "-"))
+}
+
+// pascalCase converts s to PascalCase. Every word is lower-cased first, so
+// acronyms are not preserved: "callback_query" -> "CallbackQuery",
+// "HTTPServer" -> "HttpServer".
+func pascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		b.WriteString(upperFirst(strings.ToLower(word)))
+	}
+	return b.String()
+}
+
+// PascalCase converts a space, underscore or dash separated options key into
+// the PascalCase fragment of the generated names, e.g. "callback_query" ->
+// "CallbackQuery". Unlike pascalCase it does not split on case boundaries, so
+// "myBot" -> "Mybot" and the names generated for existing keys do not change.
+func PascalCase(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return r == ' ' || r == '_' || r == '-'
+	})
+	var b strings.Builder
+	for _, word := range words {
+		b.WriteString(upperFirst(strings.ToLower(word)))
+	}
+	return b.String()
+}
+
+// camelCase converts s to camelCase: "callback_query" -> "callbackQuery".
+func camelCase(s string) string {
+	return lowerFirst(pascalCase(s))
+}
+
+// upperFirst upper-cases the first rune of s.
+func upperFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	if n == 0 {
+		return s
+	}
+	return string(unicode.ToUpper(r)) + s[n:]
+}
+
+// lowerFirst lower-cases the first rune of s.
+func lowerFirst(s string) string {
+	r, n := utf8.DecodeRuneInString(s)
+	if n == 0 {
+		return s
+	}
+	return string(unicode.ToLower(r)) + s[n:]
+}
@@ -0,0 +1,104 @@
+package template
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCaseConversion(t *testing.T) {
+	tests := []struct {
+		in                          string
+		snake, kebab, camel, pascal string
+	}{
+		{"UpdateCount", "update_count", "update-count", "updateCount", "UpdateCount"},
+		{"callback_query", "callback_query", "callback-query", "callbackQuery", "CallbackQuery"},
+		{"HTTPServer", "http_server", "http-server", "httpServer", "HttpServer"},
+		{"get menu-v2", "get_menu_v2", "get-menu-v2", "getMenuV2", "GetMenuV2"},
+		{"", "", "", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := snakeCase(tt.in); got != tt.snake {
+				t.Errorf("snakeCase(%q) = %q, want %q", tt.in, got, tt.snake)
+			}
+			if got := kebabCase(tt.in); got != tt.kebab {
+				t.Errorf("kebabCase(%q) = %q, want %q", tt.in, got, tt.kebab)
+			}
+			if got := camelCase(tt.in); got != tt.camel {
+				t.Errorf("camelCase(%q) = %q, want %q", tt.in, got, tt.camel)
+			}
+			if got := pascalCase(tt.in); got != tt.pascal {
+				t.Errorf("pascalCase(%q) = %q, want %q", tt.in, got, tt.pascal)
+			}
+		})
+	}
+	if got := upperFirst("édit"); got != "Édit" {
+		t.Errorf("upperFirst() = %q", got)
+	}
+	if got := lowerFirst("Menu"); got != "menu" {
+		t.Errorf("lowerFirst() = %q", got)
+	}
+}
+
+func TestPascalCase(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"", ""},
+		{"route", "Route"},
+		{"callback_query", "CallbackQuery"},
+		{"bot-command", "BotCommand"},
+		{"multi word key", "MultiWordKey"},
+		{"ALLCAPS", "Allcaps"},
+		{"__leading", "Leading"},
+		{"myBot", "Mybot"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := PascalCase(tt.in); got != tt.want {
+				t.Errorf("PascalCase(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGoLiterals(t *testing.T) {
+	idents := map[string]string{
+		"command":        "command",
+		"callback-query": "callback_query",
+		"2fa":            "_2fa",
+		"":               "_",
+		"type":           "type_",
+		"éa.b":           "éa_b",
+	}
+	for in, want := range idents {
+		if got := goIdent(in); got != want {
+			t.Errorf("goIdent(%q) = %q, want %q", in, got, want)
+		}
+	}
+	m := map[string]string{"command": "start", "text": "say \"hi\"\n"}
+	if got, want := goStringMap(m), `map[string]string{"command": "start", "text": "say \"hi\"\n"}`; got != want {
+		t.Errorf("goStringMap() = %s, want %s", got, want)
+	}
+	if got, want := goStringMap(nil), "map[string]string{}"; got != want {
+		t.Errorf("goStringMap(nil) = %s, want %s", got, want)
+	}
+	if got, want := goStringSlice([]string{"start", "begin"}), `[]string{"start", "begin"}`; got != want {
+		t.Errorf("goStringSlice() = %s, want %s", got, want)
+	}
+}
+
+func TestFuncsInTemplate(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate(DefaultTemplate) })
+	UseTemplate(`{{range .Methods}}{{.Name | trimPrefix "Get" | snakeCase}} {{join "," (.ExtraStrings "tags")}} {{quote .Name}}{{end}}`)
+
+	sd := &ServiceDesc{Methods: []*MethodDesc{{Name: "GetMenuItem", Extra: map[string]string{"tags": "a, b"}}}}
+	got, err := sd.Execute()
+	if err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	if want := `menu_item a,b "GetMenuItem"`; strings.TrimSpace(got) != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+}
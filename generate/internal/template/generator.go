@@ -0,0 +1,330 @@
+package template
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"text/template"
+)
+
+// DefaultTemplate is the name of the built-in template used when none is
+// selected.
+const DefaultTemplate = "bot"
+
+//go:embed templates/*.tmpl
+var builtinTemplates embed.FS
+
+// The selected template: the entry template source and the name errors refer
+// to it by, the partials parsed alongside it, the action delimiters they are
+// parsed with, and a counter bumped on every change so DefaultGenerator knows
+// when its cached parse is stale.
+var (
+	routeTemplate     = mustBuiltinTemplate(DefaultTemplate)
+	routeTemplateName = DefaultTemplate
+	routePartials     map[string]string
+	routeDelims       [2]string
+	selectionVersion  int
+)
+
+// Execute renders the service against the currently selected template. It
+// reuses the cached Generator from DefaultGenerator, so the template is only
+// parsed again after the selection changes.
+func (s *ServiceDesc) Execute() (string, error) {
+	gen, err := DefaultGenerator()
+	if err != nil {
+		return "", err
+	}
+	return gen.Execute(s)
+}
+
+// Generator renders ServiceDescs against a template that is parsed once.
+type Generator struct {
+	tmpl *template.Template
+}
+
+// NewGenerator parses source with the built-in function map.
+func NewGenerator(source string) (*Generator, error) {
+	return newGenerator("route", source, nil, [2]string{})
+}
+
+// newGenerator parses source as the entry template called name and every
+// partial as an associated template named after its key, so the entry can
+// {{template}} them. Empty delims select the default {{ and }}. References to
+// fields the data does not have are reported here (see checkFields), and a
+// missing map key fails execution instead of rendering "<no value>".
+func newGenerator(name, source string, partials map[string]string, delims [2]string) (*Generator, error) {
+	tmpl, err := template.New(name).Delims(delims[0], delims[1]).Option("missingkey=error").Funcs(Funcs()).Parse(source)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(partials))
+	for partial := range partials {
+		names = append(names, partial)
+	}
+	sort.Strings(names)
+	for _, partial := range names {
+		_, err = tmpl.New(partial).Parse(partials[partial])
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := checkFields(tmpl); err != nil {
+		return nil, err
+	}
+	return &Generator{tmpl: tmpl}, nil
+}
+
+// NewSelectionGenerator parses source as the entry template called name like
+// the selected template: with the partials of UseTemplateDir and the
+// delimiters of UseDelims, so several entry templates can share them.
+func NewSelectionGenerator(name, source string) (*Generator, error) {
+	return newGenerator(name, source, routePartials, routeDelims)
+}
+
+var (
+	cacheMu       sync.Mutex
+	cachedVersion int
+	cachedGen     *Generator
+)
+
+// DefaultGenerator returns a Generator for the currently selected template
+// (see UseBuiltinTemplate, UseTemplateDir and ReplaceTemplateIfNeed). The
+// parsed template is cached and reused until the selection changes.
+func DefaultGenerator() (*Generator, error) {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+	if cachedGen != nil && cachedVersion == selectionVersion {
+		return cachedGen, nil
+	}
+	gen, err := newGenerator(routeTemplateName, routeTemplate, routePartials, routeDelims)
+	if err != nil {
+		return nil, err
+	}
+	cachedVersion, cachedGen = selectionVersion, gen
+	return gen, nil
+}
+
+// SelectionHash returns a hex SHA-256 of the selected template: its name,
+// source, partials and delimiters, so caches of rendered output can tell when
+// the template changed.
+func SelectionHash() string {
+	h := sha256.New()
+	write := func(s string) {
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	write(routeTemplateName)
+	write(routeTemplate)
+	names := make([]string, 0, len(routePartials))
+	for name := range routePartials {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		write(name)
+		write(routePartials[name])
+	}
+	write(routeDelims[0])
+	write(routeDelims[1])
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Qualifier resolves a Go identifier in importPath to the name generated code
+// should use for it, registering the import as a side effect.
+type Qualifier func(importPath, name string) string
+
+// WithQualifier returns a copy of g whose {{qualify "path" "Name"}} calls are
+// resolved by q, so templates can reference any package and have its import
+// added to the generated file.
+func (g *Generator) WithQualifier(q Qualifier) (*Generator, error) {
+	tmpl, err := g.tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+	tmpl.Funcs(template.FuncMap{"qualify": q})
+	return &Generator{tmpl: tmpl}, nil
+}
+
+// Name returns the name of the entry template: the built-in template name, the
+// template file path, or "route" for template text.
+func (g *Generator) Name() string {
+	return g.tmpl.Name()
+}
+
+// Execute renders s. It sorts s.Methods by descending priority, then by name,
+// and fills s.MethodSets from them before rendering, so the output does not
+// depend on the method order and routes matched in method order, such as
+// callback query patterns, honor their priority. The sibling services of
+// s.File are prepared the same way.
+func (g *Generator) Execute(s *ServiceDesc) (string, error) {
+	prepareService(s)
+	if s.File != nil {
+		for _, sibling := range s.File.Services {
+			prepareService(sibling)
+		}
+	}
+	var buf strings.Builder
+	err := g.tmpl.Execute(&buf, s)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// prepareService sorts the methods of s and indexes them in MethodSets.
+func prepareService(s *ServiceDesc) {
+	sort.SliceStable(s.Methods, func(i, j int) bool {
+		if s.Methods[i].Priority != s.Methods[j].Priority {
+			return s.Methods[i].Priority > s.Methods[j].Priority
+		}
+		if s.Methods[i].Name != s.Methods[j].Name {
+			return s.Methods[i].Name < s.Methods[j].Name
+		}
+		return s.Methods[i].Num < s.Methods[j].Num
+	})
+	s.MethodSets = make(map[string]*MethodDesc)
+	for _, m := range s.Methods {
+		s.MethodSets[m.Name] = m
+	}
+}
+
+// AggregateTemplate names the optional template, defined with {{define}} by
+// the entry template or a partial, that is rendered once per Go package
+// against an AggregateDesc.
+const AggregateTemplate = "aggregate"
+
+// HasAggregate reports whether the template defines AggregateTemplate.
+func (g *Generator) HasAggregate() bool {
+	return g.tmpl.Lookup(AggregateTemplate) != nil
+}
+
+// ExecuteAggregate renders the aggregate template against a.
+func (g *Generator) ExecuteAggregate(a *AggregateDesc) (string, error) {
+	var buf strings.Builder
+	err := g.tmpl.ExecuteTemplate(&buf, AggregateTemplate, a)
+	if err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// BuiltinTemplates returns the sorted names of the embedded templates.
+func BuiltinTemplates() []string {
+	entries, _ := builtinTemplates.ReadDir("templates")
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".tmpl"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// BuiltinTemplate returns the source of the embedded template with the given
+// name. An empty name selects DefaultTemplate.
+func BuiltinTemplate(name string) (string, error) {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	raw, err := builtinTemplates.ReadFile("templates/" + name + ".tmpl")
+	if err != nil {
+		return "", fmt.Errorf("unknown built-in template %q, available: %s", name, strings.Join(BuiltinTemplates(), ", "))
+	}
+	return string(raw), nil
+}
+
+// UseBuiltinTemplate selects the embedded template with the given name. An
+// empty name selects DefaultTemplate. A later ReplaceTemplateIfNeed still
+// overrides the selection with a template file.
+func UseBuiltinTemplate(name string) error {
+	if name == "" {
+		name = DefaultTemplate
+	}
+	source, err := BuiltinTemplate(name)
+	if err != nil {
+		return err
+	}
+	routeTemplate, routeTemplateName = source, name
+	routePartials = nil
+	routeDelims = [2]string{}
+	selectionVersion++
+	return nil
+}
+
+// UseDelims sets the action delimiters the selected template and its partials
+// are parsed with, e.g. "<<" and ">>" for templates generating text that
+// contains {{ }} itself. Empty delimiters select the default {{ and }}. Since
+// the built-in templates use the default, UseBuiltinTemplate resets them.
+func UseDelims(left, right string) {
+	routeDelims = [2]string{left, right}
+	selectionVersion++
+}
+
+// UseTemplate selects source as the template text, e.g. a template built
+// programmatically by a plugin embedding the generator. Partials loaded by
+// UseTemplateDir stay available.
+func UseTemplate(source string) {
+	routeTemplate, routeTemplateName = source, "route"
+	selectionVersion++
+}
+
+// EntryTemplateFile is the file of a template directory used as the entry
+// template unless a template file is selected as well.
+const EntryTemplateFile = "route.tmpl"
+
+// UseTemplateDir parses every *.tmpl file in dir as a partial named after its
+// file name, e.g. {{template "header.tmpl" .}}; {{define}} blocks in those
+// files are available by their own names. When dir contains EntryTemplateFile
+// it also becomes the entry template. A later ReplaceTemplateIfNeed replaces
+// only the entry template, so a template file can use the directory's partials.
+func UseTemplateDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tmpl"))
+	if err != nil {
+		return err
+	}
+	if len(paths) == 0 {
+		return fmt.Errorf("template directory %q contains no *.tmpl files", dir)
+	}
+	partials := make(map[string]string, len(paths))
+	for _, path := range paths {
+		raw, rErr := os.ReadFile(path)
+		if rErr != nil {
+			return rErr
+		}
+		partials[filepath.Base(path)] = string(raw)
+	}
+	if entry, ok := partials[EntryTemplateFile]; ok {
+		routeTemplate, routeTemplateName = entry, filepath.Join(dir, EntryTemplateFile)
+		delete(partials, EntryTemplateFile)
+	}
+	routePartials = partials
+	selectionVersion++
+	return nil
+}
+
+func mustBuiltinTemplate(name string) string {
+	raw, err := builtinTemplates.ReadFile("templates/" + name + ".tmpl")
+	if err != nil {
+		panic(err)
+	}
+	return string(raw)
+}
+
+func ReplaceTemplateIfNeed(path string) error {
+	if path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		routeTemplate, routeTemplateName = string(raw), path
+		selectionVersion++
+	}
+	return nil
+}
@@ -0,0 +1,283 @@
+package template
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestUseBuiltinTemplate(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate(DefaultTemplate) })
+
+	want := []string{"bot", "data", "job", "minimal", "mq"}
+	if got := BuiltinTemplates(); !reflect.DeepEqual(got, want) {
+		t.Errorf("BuiltinTemplates() = %v, want %v", got, want)
+	}
+	for _, name := range want {
+		if err := UseBuiltinTemplate(name); err != nil {
+			t.Errorf("UseBuiltinTemplate(%q) failed: %v", name, err)
+		}
+	}
+	if err := UseBuiltinTemplate(""); err != nil {
+		t.Errorf("UseBuiltinTemplate(\"\") failed: %v", err)
+	}
+	if err := UseBuiltinTemplate("unknown"); err == nil {
+		t.Error("UseBuiltinTemplate(unknown) expected an error")
+	}
+}
+
+func TestGeneratorName(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate(DefaultTemplate) })
+
+	file := filepath.Join(t.TempDir(), "custom.tmpl")
+	if err := os.WriteFile(file, []byte("// custom"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	steps := []struct {
+		selectFn func() error
+		want     string
+	}{
+		{func() error { return UseBuiltinTemplate("") }, DefaultTemplate},
+		{func() error { return UseBuiltinTemplate("job") }, "job"},
+		{func() error { return ReplaceTemplateIfNeed(file) }, file},
+		{func() error { UseTemplate("// source"); return nil }, "route"},
+	}
+	for _, step := range steps {
+		if err := step.selectFn(); err != nil {
+			t.Fatal(err)
+		}
+		gen, err := DefaultGenerator()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := gen.Name(); got != step.want {
+			t.Errorf("Name() = %q, want %q", got, step.want)
+		}
+	}
+}
+
+func TestDefaultGeneratorCache(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate(DefaultTemplate) })
+
+	first, err := DefaultGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := DefaultGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first != second {
+		t.Error("DefaultGenerator() reparsed an unchanged template")
+	}
+	if err := UseBuiltinTemplate("minimal"); err != nil {
+		t.Fatal(err)
+	}
+	third, err := DefaultGenerator()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if third == first {
+		t.Error("DefaultGenerator() kept a stale template after the selection changed")
+	}
+}
+
+func TestSelectionHash(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate(DefaultTemplate) })
+
+	bot := SelectionHash()
+	if got := SelectionHash(); got != bot {
+		t.Errorf("SelectionHash() changed without a selection change: %s, then %s", bot, got)
+	}
+	if err := UseBuiltinTemplate("minimal"); err != nil {
+		t.Fatal(err)
+	}
+	minimal := SelectionHash()
+	if minimal == bot {
+		t.Error("SelectionHash() is the same for the bot and minimal templates")
+	}
+	UseDelims("<<", ">>")
+	if SelectionHash() == minimal {
+		t.Error("SelectionHash() ignores the delimiters")
+	}
+}
+
+func benchmarkService() *ServiceDesc {
+	sd := &ServiceDesc{
+		OptionsKey:  "Bot",
+		ServiceType: "MenuService",
+		ServiceName: "bot.v1.MenuService",
+		Package: &PackageDesc{
+			RequestType:      "telegram.Update",
+			ResponseType:     "telegram.Message",
+			ExtraDataType:    "telegram.MethodExtraData",
+			NewExtraDataFunc: "telegram.NewMethodExtraData",
+		},
+	}
+	for i := 0; i < 10; i++ {
+		name := "Method" + strconv.Itoa(i)
+		sd.Methods = append(sd.Methods, &MethodDesc{
+			Name:          name,
+			OriginalName:  name,
+			Operation:     "OperationBotMenuService" + name,
+			OperationPath: "/bot.v1.MenuService/" + name,
+			Request:       name + "Request",
+			Reply:         name + "Response",
+			Extra:         map[string]string{"command": name},
+			ExtraList:     []ExtraKV{{Key: "command", Value: name}},
+		})
+	}
+	return sd
+}
+
+// BenchmarkParseEachService measures the previous behavior of parsing the
+// template for every rendered service.
+func BenchmarkParseEachService(b *testing.B) {
+	sd := benchmarkService()
+	for i := 0; i < b.N; i++ {
+		gen, err := NewGenerator(routeTemplate)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := gen.Execute(sd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGeneratorExecute measures rendering against a template parsed once.
+func BenchmarkGeneratorExecute(b *testing.B) {
+	sd := benchmarkService()
+	gen, err := NewGenerator(routeTemplate)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := gen.Execute(sd); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestUseTemplateDir(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate(DefaultTemplate) })
+
+	dir := t.TempDir()
+	files := map[string]string{
+		"route.tmpl":  `{{template "header" .}}{{range .Methods}}{{template "method.tmpl" .}}{{end}}`,
+		"header.tmpl": `{{define "header"}}// {{.ServiceType}}{{"\n"}}{{end}}`,
+		"method.tmpl": `const {{.Operation}} = "{{.OperationPath}}"{{"\n"}}`,
+		"ignored.txt": `not a template`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := UseTemplateDir(dir); err != nil {
+		t.Fatalf("UseTemplateDir() failed: %v", err)
+	}
+
+	sd := &ServiceDesc{ServiceType: "MenuService", Methods: []*MethodDesc{{Name: "Start", Operation: "OperationBotMenuServiceStart", OperationPath: "/bot.v1.MenuService/Start"}}}
+	got, err := sd.Execute()
+	if err != nil {
+		t.Fatalf("Execute() failed: %v", err)
+	}
+	want := "// MenuService\nconst OperationBotMenuServiceStart = \"/bot.v1.MenuService/Start\"\n"
+	if got != want {
+		t.Errorf("Execute() = %q, want %q", got, want)
+	}
+
+	// A template file replaces only the entry template; partials stay.
+	UseTemplate(`{{template "header" .}}`)
+	got, err = sd.Execute()
+	if err != nil {
+		t.Fatalf("Execute() with replaced entry failed: %v", err)
+	}
+	if got != "// MenuService\n" {
+		t.Errorf("Execute() with replaced entry = %q", got)
+	}
+
+	if err := UseTemplateDir(t.TempDir()); err == nil || !strings.Contains(err.Error(), "no *.tmpl files") {
+		t.Errorf("UseTemplateDir(empty) error = %v", err)
+	}
+}
+
+func TestGeneratorWithQualifier(t *testing.T) {
+	gen, err := NewGenerator(`{{qualify "example.com/pkg/errs" "New"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd := &ServiceDesc{}
+	got, err := gen.Execute(sd)
+	if err != nil || got != "errs.New" {
+		t.Errorf("Execute() without qualifier = %q, %v", got, err)
+	}
+
+	var imported []string
+	bound, err := gen.WithQualifier(func(importPath, name string) string {
+		imported = append(imported, importPath)
+		return "alias." + name
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err = bound.Execute(sd)
+	if err != nil || got != "alias.New" {
+		t.Errorf("Execute() with qualifier = %q, %v", got, err)
+	}
+	if !reflect.DeepEqual(imported, []string{"example.com/pkg/errs"}) {
+		t.Errorf("qualifier saw imports %v", imported)
+	}
+	// The original generator is unaffected.
+	if got, _ := gen.Execute(sd); got != "errs.New" {
+		t.Errorf("WithQualifier modified the original generator: %q", got)
+	}
+}
+
+func TestNewGeneratorUnknownFields(t *testing.T) {
+	valid := []string{
+		`{{.ServiceType}}{{range .Methods}}{{.Name}}{{.ExtraValue "command"}}{{range .RequestFields}}{{.GoName}}{{end}}{{end}}`,
+		`{{range $i, $m := .Methods}}{{$i}}{{$m.Operation}}{{$.ServerName}}{{end}}`,
+		`{{with .Package}}{{.RequestType}}{{end}}{{.Package.DispatchSwitch}}{{.Extra.anything}}`,
+		`{{range .MethodSets}}{{.Name | snakeCase}}{{end}}{{define "aggregate"}}{{range .Services}}{{.ServerName}}{{end}}{{.GoPackageName}}{{end}}`,
+		`{{define "m"}}{{.Name}}{{end}}{{range .Methods}}{{template "m" .}}{{end}}`,
+	}
+	for _, source := range valid {
+		if _, err := NewGenerator(source); err != nil {
+			t.Errorf("NewGenerator(%q) failed: %v", source, err)
+		}
+	}
+
+	invalid := map[string]string{
+		`{{.Commment}}`:                                                            "route:1:2: template.ServiceDesc has no field or method Commment",
+		`{{range .Methods}}{{.Commment}}{{end}}`:                                   "template.MethodDesc has no field or method Commment",
+		`{{range $m := .Methods}}{{$m.Request.Name}}{{end}}`:                       "string has no field or method Name",
+		`{{with .Package}}{{.Rquest}}{{end}}`:                                      "template.PackageDesc has no field or method Rquest",
+		`{{define "m"}}{{.Nam}}{{end}}{{range .Methods}}{{template "m" .}}{{end}}`: "route:1:16: template.MethodDesc has no field or method Nam",
+		`{{define "aggregate"}}{{.ServiceType}}{{end}}`:                            "template.AggregateDesc has no field or method ServiceType",
+	}
+	for source, want := range invalid {
+		_, err := NewGenerator(source)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("NewGenerator(%q) error = %v, want %q", source, err, want)
+		}
+	}
+}
+
+func TestGeneratorMissingKey(t *testing.T) {
+	gen, err := NewGenerator(`{{.Extra.command}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := gen.Execute(&ServiceDesc{Extra: map[string]string{}}); err == nil || !strings.Contains(err.Error(), "map has no entry") {
+		t.Errorf("Execute error = %v, want a missing key error", err)
+	}
+	if got, err := gen.Execute(&ServiceDesc{Extra: map[string]string{"command": "start"}}); err != nil || got != "start" {
+		t.Errorf("Execute = %q, %v", got, err)
+	}
+}
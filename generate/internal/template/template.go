@@ -1,14 +1,16 @@
 package template
 
 import (
-	_ "embed"
-	"os"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
-	"text/template"
-)
+	"time"
 
-//go:embed template.tmpl
-var routeTemplate string
+	"google.golang.org/protobuf/compiler/protogen"
+)
 
 /*
 service MenuService {
@@ -32,27 +34,645 @@ service MenuService {
 */
 
 type ServiceDesc struct {
-	OptionsKey string // bot
+	OptionsKey    string // Bot
+	RawOptionsKey string // bot, the options key as configured
 
 	ServiceType string // MenuService
 	ServiceName string // bot.v1.MenuService
 
+	ServerName              string // MenuServiceBotServer
+	UnimplementedServerName string // UnimplementedMenuServiceBotServer
+
+	// Extra holds the service-level default extras for the options key. They
+	// are already merged into every MethodDesc.Extra, with method values winning.
+	Extra map[string]string
+
+	// Methods is sorted by descending Priority, then Name, then Num, when the
+	// service is rendered.
+	// MethodSets holds one method per Name; ranging over it in a template
+	// visits the names in sorted order.
 	Methods    []*MethodDesc
 	MethodSets map[string]*MethodDesc
 
 	Package *PackageDesc
+	// File describes the proto file of the service and its sibling services
+	// rendered into the same generated file.
+	File *FileDesc
+
+	// Descriptor is the protogen service, giving templates access to any
+	// descriptor-level data such as options and full names. It is only set
+	// when the descriptors parameter is enabled, and nil otherwise.
+	Descriptor *protogen.Service
+}
+
+// AggregateDesc is the data of the optional aggregate template, rendered once
+// per Go package and options key with every service generated into that
+// package, sorted by ServiceName.
+type AggregateDesc struct {
+	OptionsKey    string // Bot
+	GoPackageName string // botv1
+
+	Services []*ServiceDesc
+	Package  *PackageDesc
+}
+
+// FileDesc is the data of a proto file shared by every ServiceDesc rendered
+// into the same generated file, so a template can render artifacts spanning
+// its services, such as a file-wide dispatcher, once with FirstInFile.
+// Services holds the services with routed methods, sorted by ServiceName;
+// with the output split per service it holds that service only.
+type FileDesc struct {
+	OptionsKey    string // Bot
+	Path          string // bot/v1/menu.proto
+	ProtoPackage  string // bot.v1
+	GoPackageName string // botv1
+	GoImportPath  string // example.com/gen/bot/v1
+
+	Services []*ServiceDesc
+	Package  *PackageDesc
+}
+
+// Methods returns the methods of every service of the file, service by
+// service.
+func (f *FileDesc) Methods() []*MethodDesc {
+	var methods []*MethodDesc
+	for _, s := range f.Services {
+		methods = append(methods, s.Methods...)
+	}
+	return methods
 }
 
 type MethodDesc struct {
 	Name         string // rpc method name: UpdateCount
-	OriginalName string // service and method name: MenuServiceUpdateCount
-	Num          int    // duplicate method number, used for generating unique method names
+	OriginalName string // proto method name, or its name extra: UpdateCount
+	ProtoName    string // proto method name: UpdateCount
+	// Num numbers the methods of the proto file sharing this Go name, from 0,
+	// in service full name and then Go name order; unrouted methods count too,
+	// so it is stable. UniqueName is unique in the proto file, for naming
+	// generated identifiers: Name, followed by Num when the Go name is shared,
+	// e.g. UpdateCount or Ping1.
+	Num        int
+	UniqueName string
+
+	Operation     string // operation constant name: OperationBotMenuServiceUpdateCount
+	OperationPath string // operation constant value: /bot.v1.MenuService/UpdateCount
+
+	Request string // rpc request type: UpdateCountRequest, or v1.UpdateCountRequest from another package
+	Reply   string // rpc reply type: UpdateCountResponse, or v1.UpdateCountResponse from another package
+
+	RequestImportPath string // Go import path of the request message
+	ReplyImportPath   string // Go import path of the reply message
+
+	RequestMessage string // full proto name of the request message: bot.v1.UpdateCountRequest
+	ReplyMessage   string // full proto name of the reply message: bot.v1.UpdateCountResponse
+
+	// RequestFields describes the request message's fields in declaration
+	// order, so templates can generate argument binding code.
+	RequestFields []*FieldDesc
 
-	Request string // rpc request type: UpdateCountRequest
-	Reply   string // rpc reply type: UpdateCountResponse
 	Comment string
+	// LeadingComment is the method's leading proto comment as plain text, with
+	// surrounding whitespace trimmed from every line.
+	LeadingComment string
+	// TrailingComment is the comment following the method declaration, and
+	// DetachedComments the comments separated from it by a blank line, as
+	// plain text.
+	TrailingComment  string
+	DetachedComments []string
+	// Summary is the first line of the leading comment and Description the
+	// rest of it, with paragraph breaks kept as blank lines.
+	Summary     string
+	Description string
 
 	Extra map[string]string
+	// ExtraList holds the same entries as Extra sorted by key. Extra is a proto
+	// map, whose declaration order is lost before the generator runs: protoc
+	// and buf may encode the entries of an options block in any order, and the
+	// service defaults and overrides are merged in. Sorting keeps ranging
+	// templates deterministic across compilers and builds.
+	ExtraList []ExtraKV
+	// ExtraTyped holds the extras with inferred types: "true"/"false" become
+	// bool, integers int64, other numbers float64, and anything else stays a
+	// string.
+	ExtraTyped map[string]any
+
+	// Validate is set when the decoded request is validated before the
+	// server is called: the validate parameter is enabled and the method does
+	// not opt out with a false validate extra.
+	Validate bool
+
+	// Middleware holds the middleware extra split on commas: the names of the
+	// middleware wrapping the method's handler, outermost first.
+	Middleware []string
+
+	// Priority is the priority extra: methods with a higher priority come
+	// first in Methods, and so are registered and matched first. It is 0 when
+	// the method has none.
+	Priority int
+
+	// Group is the group extra, the route group the method is registered
+	// with; empty when the method has none.
+	Group string
+	// ClientStreaming and ServerStreaming report whether the method streams
+	// its requests or replies. Streaming methods only reach templates with the
+	// streaming=include parameter.
+	ClientStreaming bool
+	ServerStreaming bool
+	// ErrorDomain is the error_domain extra, the domain of the sphere error
+	// model the method's errors are mapped into; empty when it has none.
+	ErrorDomain string
+	// Roles holds the roles extra split on commas and Permission the
+	// permission extra, what a caller needs to be authorized for the method;
+	// both are empty when the method has none.
+	Roles      []string
+	Permission string
+	// Deprecated is set when the method option deprecated or the deprecated
+	// extra marks the method deprecated, and DeprecationNotice holds the
+	// migration notice of the extra; it is empty for a plain "true".
+	Deprecated        bool
+	DeprecationNotice string
+	// FeatureFlag is the feature_flag extra, the flag a feature gate must
+	// enable for the method to be routed; empty when the method is not gated.
+	FeatureFlag string
+	// ChatType is the chat_type extra, private, group or channel, the chats
+	// the method's commands are routed in; empty when it handles all chats.
+	ChatType string
+
+	// Tags holds the tags extra split on commas, e.g. [experimental admin].
+	Tags []string
+
+	// Commands holds the command extra split on commas: the command followed
+	// by its aliases, e.g. "start,begin" yields [start begin].
+	Commands []string
+
+	// CallbackQueryPattern is the callback_query_pattern extra, a regular
+	// expression matched against callback data such as "item:123". It is
+	// validated at generation time; empty when the method has none.
+	CallbackQueryPattern string
+	// CallbackData is the callback_data extra, a format such as "item:{id}"
+	// whose placeholders name request fields. CallbackDataExpr is the Go
+	// expression building that string from a request variable named req, e.g.
+	// "item:" + strconv.FormatInt(req.GetId(), 10). Both are empty when the
+	// method has no callback_data extra.
+	CallbackData     string
+	CallbackDataExpr string
+	// CallbackFields holds the callback_fields extra split on commas, the
+	// proto names of the request fields packed into compact callback data.
+	// CallbackEncoder is the body of a function formatting a request variable
+	// named req as that data, returning it and an error, and CallbackDecoder
+	// the body of a function parsing a string variable named data into req,
+	// returning an error. All are empty when the method has no
+	// callback_fields extra.
+	CallbackFields  []string
+	CallbackEncoder string
+	CallbackDecoder string
+	// Args holds the args extra split on commas, the proto names of the
+	// request fields a command's arguments bind to in positional order, and
+	// ArgsBinder the body of a function binding a []string variable named args
+	// to a request variable named req, returning an error. Both are empty when
+	// the method has no args extra.
+	Args       []string
+	ArgsBinder string
+	// Keyboard holds the rows of the inline keyboard described by the buttons
+	// extra; nil when the method has no buttons extra.
+	Keyboard [][]*ButtonDesc
+	// DispatchOneof describes the request oneof named by the dispatch_oneof
+	// extra, whose set field a generated dispatcher routes on; nil when the
+	// method has no dispatch_oneof extra.
+	DispatchOneof *OneofDesc
+	// Cron is the validated cron extra, the schedule of a job; empty when the
+	// method has none.
+	Cron string
+	// Timeout and MaxRetries are the parsed timeout and retry extras; zero
+	// when the method has none.
+	Timeout    time.Duration
+	MaxRetries int
+	// RateLimit is the parsed rate_limit extra; nil when the method has none.
+	RateLimit *RateLimitDesc
+
+	// Descriptor is the protogen method, including its Input and Output
+	// messages. Like ServiceDesc.Descriptor it is nil unless the descriptors
+	// parameter is enabled.
+	Descriptor *protogen.Method
+}
+
+// FirstInFile reports whether s is the first service of its file, where a
+// template renders the artifacts of the whole file. A service without a File
+// is the only one.
+func (s *ServiceDesc) FirstInFile() bool {
+	return s.File == nil || len(s.File.Services) == 0 || s.File.Services[0] == s
+}
+
+// HasCallbackQueryPatterns reports whether any method declares a
+// callback_query_pattern extra.
+func (s *ServiceDesc) HasCallbackQueryPatterns() bool {
+	for _, m := range s.Methods {
+		if m.CallbackQueryPattern != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// CommandDescription is a command with the description of the method handling
+// it.
+type CommandDescription struct {
+	Command     string
+	Description string
+}
+
+// CommandDescriptions returns the first command of every method with a command
+// extra, aliases excluded, with the method's CommandDescription, sorted by
+// command. A command routed to several methods, e.g. one per chat type, is
+// described by the first of them.
+func (s *ServiceDesc) CommandDescriptions() []CommandDescription {
+	var descriptions []CommandDescription
+	for _, m := range s.Methods {
+		if len(m.Commands) == 0 || slices.ContainsFunc(descriptions, func(d CommandDescription) bool { return d.Command == m.Commands[0] }) {
+			continue
+		}
+		descriptions = append(descriptions, CommandDescription{Command: m.Commands[0], Description: m.CommandDescription()})
+	}
+	slices.SortStableFunc(descriptions, func(a, b CommandDescription) int {
+		return strings.Compare(a.Command, b.Command)
+	})
+	return descriptions
+}
+
+// LogField is one key/value pair of structured logging context.
+type LogField struct {
+	Key   string
+	Value string
+}
+
+// LogFields returns the structured logging fields of m, in a fixed order:
+// service (the full service name), method (the proto method name), operation
+// (the operation path) and, when m has a command extra, command (its first
+// command). Handler wrappers attaching these get the same keys for every route.
+func (s *ServiceDesc) LogFields(m *MethodDesc) []LogField {
+	fields := []LogField{
+		{Key: "service", Value: s.ServiceName},
+		{Key: "method", Value: m.OriginalName},
+		{Key: "operation", Value: m.OperationPath},
+	}
+	if len(m.Commands) > 0 {
+		fields = append(fields, LogField{Key: "command", Value: m.Commands[0]})
+	}
+	return fields
+}
+
+// OptionType returns the name of the functional option type of the service's
+// registration function, e.g. "MenuServiceBotOption".
+func (s *ServiceDesc) OptionType() string {
+	return s.ServiceType + s.OptionsKey + "Option"
+}
+
+// OptionFunc returns the name of the functional option setting name, e.g.
+// "WithMenuServiceBotCodec" for "Codec".
+func (s *ServiceDesc) OptionFunc(name string) string {
+	return "With" + s.ServiceType + s.OptionsKey + name
+}
+
+// HasCommands reports whether any method declares a command extra.
+func (s *ServiceDesc) HasCommands() bool {
+	for _, m := range s.Methods {
+		if len(m.Commands) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasValidation reports whether any method validates its decoded request.
+func (s *ServiceDesc) HasValidation() bool {
+	for _, m := range s.Methods {
+		if m.Validate {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMiddleware reports whether any method declares a middleware extra.
+func (s *ServiceDesc) HasMiddleware() bool {
+	for _, m := range s.Methods {
+		if len(m.Middleware) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Groups returns the distinct group extras of the methods, sorted.
+func (s *ServiceDesc) Groups() []string {
+	var groups []string
+	for _, m := range s.Methods {
+		if m.Group != "" && !slices.Contains(groups, m.Group) {
+			groups = append(groups, m.Group)
+		}
+	}
+	slices.Sort(groups)
+	return groups
+}
+
+// HasGroups reports whether any method declares a group extra.
+func (s *ServiceDesc) HasGroups() bool {
+	return len(s.Groups()) > 0
+}
+
+// ChatTypes returns the distinct chat_type extras of the methods, sorted.
+func (s *ServiceDesc) ChatTypes() []string {
+	var chatTypes []string
+	for _, m := range s.Methods {
+		if m.ChatType != "" && !slices.Contains(chatTypes, m.ChatType) {
+			chatTypes = append(chatTypes, m.ChatType)
+		}
+	}
+	slices.Sort(chatTypes)
+	return chatTypes
+}
+
+// HasChatTypes reports whether any method declares a chat_type extra.
+func (s *ServiceDesc) HasChatTypes() bool {
+	return len(s.ChatTypes()) > 0
+}
+
+// HasErrorDomains reports whether any method declares an error_domain extra.
+func (s *ServiceDesc) HasErrorDomains() bool {
+	for _, m := range s.Methods {
+		if m.ErrorDomain != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasKeyboards reports whether any method declares a buttons extra.
+func (s *ServiceDesc) HasKeyboards() bool {
+	for _, m := range s.Methods {
+		if len(m.Keyboard) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// HasRateLimits reports whether any method declares a rate_limit extra.
+func (s *ServiceDesc) HasRateLimits() bool {
+	for _, m := range s.Methods {
+		if m.RateLimit != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAuthorization reports whether any method declares a roles or permission
+// extra.
+func (s *ServiceDesc) HasAuthorization() bool {
+	for _, m := range s.Methods {
+		if len(m.Roles) > 0 || m.Permission != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasDeprecations reports whether any method is deprecated.
+func (s *ServiceDesc) HasDeprecations() bool {
+	for _, m := range s.Methods {
+		if m.Deprecated {
+			return true
+		}
+	}
+	return false
+}
+
+// HasFeatureFlags reports whether any method declares a feature_flag extra.
+func (s *ServiceDesc) HasFeatureFlags() bool {
+	for _, m := range s.Methods {
+		if m.FeatureFlag != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCronJobs reports whether any method declares a cron extra.
+func (s *ServiceDesc) HasCronJobs() bool {
+	for _, m := range s.Methods {
+		if m.Cron != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldDesc describes a single message field.
+type FieldDesc struct {
+	Name     string // proto field name: user_id
+	GoName   string // Go struct field name: UserId
+	JSONName string // JSON name: userId
+	Number   int    // field number
+
+	Kind string // proto kind: string, int64, message, enum, ...
+	// GoTypeFunc returns the type GoType reports. It qualifies the message
+	// and enum types of other Go packages when called, so their imports are
+	// only added to a generated file that renders them.
+	GoTypeFunc func() string
+
+	Repeated bool   // repeated (list) field; false for maps
+	Map      bool   // map field
+	Optional bool   // scalar with explicit presence, e.g. proto3 optional or an edition default
+	Oneof    string // name of the containing oneof, empty if none
+
+	Comment string // leading comment as plain text
+}
+
+// GoType returns the Go type of the struct field: string, *int64, []string,
+// *v1.Item, ...
+func (f *FieldDesc) GoType() string {
+	if f.GoTypeFunc == nil {
+		return ""
+	}
+	return f.GoTypeFunc()
+}
+
+// ButtonDesc describes a button of an inline keyboard: its label and either
+// static callback data or the method whose callback data it carries.
+type ButtonDesc struct {
+	Text string // label: Next
+	Data string // static callback data; empty when Target is set
+
+	// Target is the method of the service the button routes to, whose
+	// callback_data or callback_fields extra builds the data from a request,
+	// and Param the name of the parameter of the keyboard builder taking that
+	// request, e.g. showItemReq. Both are unset for static data.
+	Target *MethodDesc
+	Param  string
+}
+
+// RateLimitDesc is a rate_limit extra: at most Count requests per Period.
+type RateLimitDesc struct {
+	Count  int
+	Period time.Duration
+}
+
+// PeriodUnit returns the name of the largest time unit dividing Period, e.g.
+// Minute, for rendering it as PeriodUnits() * time.<unit>.
+func (r *RateLimitDesc) PeriodUnit() string {
+	unit, _ := r.periodUnit()
+	return unit
+}
+
+// PeriodUnits returns Period in units of PeriodUnit.
+func (r *RateLimitDesc) PeriodUnits() int64 {
+	_, size := r.periodUnit()
+	return int64(r.Period / size)
+}
+
+// periodUnit returns the name and size of the largest time unit dividing
+// Period.
+func (r *RateLimitDesc) periodUnit() (string, time.Duration) {
+	return durationUnit(r.Period)
+}
+
+// durationUnit returns the name and size of the largest time unit dividing d.
+func durationUnit(d time.Duration) (string, time.Duration) {
+	for _, unit := range []struct {
+		name string
+		size time.Duration
+	}{{"Hour", time.Hour}, {"Minute", time.Minute}, {"Second", time.Second}, {"Millisecond", time.Millisecond}, {"Microsecond", time.Microsecond}} {
+		if d%unit.size == 0 {
+			return unit.name, unit.size
+		}
+	}
+	return "Nanosecond", time.Nanosecond
+}
+
+// OneofDesc describes a oneof of a request message.
+type OneofDesc struct {
+	Name   string // proto oneof name: payload
+	GoName string // Go interface field name: Payload
+
+	Cases []*OneofCaseDesc // fields of the oneof in declaration order
+}
+
+// OneofCaseDesc describes a field of a oneof.
+type OneofCaseDesc struct {
+	Name    string // proto field name: text
+	GoName  string // Go field name in the wrapper: Text
+	Wrapper string // Go wrapper type of the case: SubmitRequest_Text, or v1.SubmitRequest_Text from another package
+	GoType  string // Go type of the value: string, *v1.Photo, ...
+}
+
+// ExtraKV is a single extra entry of a method rule.
+type ExtraKV struct {
+	Key   string
+	Value string
+}
+
+// CommandDescription returns the description of the method's command for a
+// command menu or help text: its Summary, or its proto name when it has no
+// comment.
+func (m *MethodDesc) CommandDescription() string {
+	if m.Summary == "" {
+		return m.OriginalName
+	}
+	return m.Summary
+}
+
+// KeyboardParams returns the buttons of the method's keyboard that target a
+// method, in row order: one parameter of the keyboard builder each.
+func (m *MethodDesc) KeyboardParams() []*ButtonDesc {
+	var params []*ButtonDesc
+	for _, row := range m.Keyboard {
+		for _, button := range row {
+			if button.Target != nil {
+				params = append(params, button)
+			}
+		}
+	}
+	return params
+}
+
+// HasExtra reports whether the method carries an extra with the given key.
+func (m *MethodDesc) HasExtra(key string) bool {
+	_, ok := m.Extra[key]
+	return ok
+}
+
+// ExtraValue returns the extra value for key, or "" when it is not set.
+func (m *MethodDesc) ExtraValue(key string) string {
+	return m.Extra[key]
+}
+
+// ExtraInt parses the extra value for key as an integer. A missing key yields 0;
+// a value that is not an integer fails template execution.
+func (m *MethodDesc) ExtraInt(key string) (int64, error) {
+	raw, ok := m.Extra[key]
+	if !ok {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(raw, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("extra %q of method %s is not an integer: %q", key, m.Name, raw)
+	}
+	return v, nil
+}
+
+// ExtraFloat parses the extra value for key as a float. A missing key yields 0;
+// a value that is not a number fails template execution.
+func (m *MethodDesc) ExtraFloat(key string) (float64, error) {
+	raw, ok := m.Extra[key]
+	if !ok {
+		return 0, nil
+	}
+	v, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0, fmt.Errorf("extra %q of method %s is not a number: %q", key, m.Name, raw)
+	}
+	return v, nil
+}
+
+// ExtraBool parses the extra value for key as a bool (strconv.ParseBool
+// syntax). A missing key yields false; an invalid value fails template
+// execution.
+func (m *MethodDesc) ExtraBool(key string) (bool, error) {
+	raw, ok := m.Extra[key]
+	if !ok {
+		return false, nil
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, fmt.Errorf("extra %q of method %s is not a bool: %q", key, m.Name, raw)
+	}
+	return v, nil
+}
+
+// ExtraStrings splits the extra value for key on commas, trimming whitespace
+// and dropping empty elements. A missing key yields nil.
+func (m *MethodDesc) ExtraStrings(key string) []string {
+	var list []string
+	for _, item := range strings.Split(m.Extra[key], ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// TimeoutUnit returns the name of the largest time unit dividing Timeout, e.g.
+// Second, for rendering it as TimeoutUnits() * time.<unit>.
+func (m *MethodDesc) TimeoutUnit() string {
+	unit, _ := durationUnit(m.Timeout)
+	return unit
+}
+
+// TimeoutUnits returns Timeout in units of TimeoutUnit.
+func (m *MethodDesc) TimeoutUnits() int64 {
+	_, size := durationUnit(m.Timeout)
+	return int64(m.Timeout / size)
 }
 
 type PackageDesc struct {
@@ -60,32 +680,109 @@ type PackageDesc struct {
 	ResponseType     string
 	ExtraDataType    string
 	NewExtraDataFunc string
+
+	// Dispatch selects how the bot template routes requests: "map" (or empty)
+	// registers a map of handler closures, "switch" generates a dispatch
+	// function switching on the operation.
+	Dispatch string
+
+	// Tracing makes the bot template accept an OpenTelemetry tracer through a
+	// registration option and start a span around every handler.
+	Tracing bool
+	// Metrics makes the bot template accept a Prometheus registerer through a
+	// registration option and record request metrics for every handler.
+	Metrics bool
+
+	// VersionGuard is the qualified identifier of the runtime package the
+	// generated file asserts its compatibility with, e.g.
+	// telegram.SupportPackageIsVersion1, or empty when the guard is disabled.
+	// The plugin emits the guard itself, once per file.
+	VersionGuard string
+
+	// Generic makes the bot template generate its codec, handlers and
+	// registration functions generic in the transport types: RequestType and
+	// ResponseType are then the type parameters TReq and TResp, and
+	// DefaultRequestType and DefaultResponseType the qualified request and
+	// response models, empty when not configured.
+	Generic             bool
+	DefaultRequestType  string
+	DefaultResponseType string
+
+	// GenericHandlers makes the bot template build every handler from one
+	// generic adapter typed by the request and reply messages of its method,
+	// and the invoker emit a typed route of every method.
+	GenericHandlers bool
 }
 
-func (s *ServiceDesc) Execute() (string, error) {
-	s.MethodSets = make(map[string]*MethodDesc)
-	for _, m := range s.Methods {
-		s.MethodSets[m.Name] = m
+// TypeParams returns the type parameter list the declarations of a generic
+// package take, "[TReq, TResp any]", or "" when Generic is not set.
+func (p *PackageDesc) TypeParams() string {
+	if !p.Generic {
+		return ""
 	}
-	var buf strings.Builder
-	tmpl, err := template.New("route").Parse(routeTemplate)
-	if err != nil {
-		return "", err
+	return "[TReq, TResp any]"
+}
+
+// TypeArgs returns the type arguments instantiating a declaration of a
+// generic package with its own type parameters, "[TReq, TResp]", or "" when
+// Generic is not set.
+func (p *PackageDesc) TypeArgs() string {
+	if !p.Generic {
+		return ""
 	}
-	err = tmpl.Execute(&buf, s)
-	if err != nil {
-		return "", err
+	return "[TReq, TResp]"
+}
+
+// DispatchSwitch reports whether the switch dispatch code path is selected.
+func (p *PackageDesc) DispatchSwitch() bool {
+	return p.Dispatch == "switch"
+}
+
+// SortedExtraList converts a rule's extra map into a slice sorted by key. A nil
+// or empty map yields nil.
+func SortedExtraList(extra map[string]string) []ExtraKV {
+	if len(extra) == 0 {
+		return nil
+	}
+	list := make([]ExtraKV, 0, len(extra))
+	for key, value := range extra {
+		list = append(list, ExtraKV{Key: key, Value: value})
 	}
-	return buf.String(), nil
+	sort.Slice(list, func(i, j int) bool { return list[i].Key < list[j].Key })
+	return list
 }
 
-func ReplaceTemplateIfNeed(path string) error {
-	if path != "" {
-		raw, err := os.ReadFile(path)
-		if err != nil {
-			return err
-		}
-		routeTemplate = string(raw)
+// TypedExtras converts a rule's extra map into a map of inferred values (see
+// InferExtraValue). A nil or empty map yields nil.
+func TypedExtras(extra map[string]string) map[string]any {
+	if len(extra) == 0 {
+		return nil
+	}
+	typed := make(map[string]any, len(extra))
+	for key, value := range extra {
+		typed[key] = InferExtraValue(value)
+	}
+	return typed
+}
+
+// InferExtraValue infers the type of a single extra value: "true"/"false" map
+// to bool, base-10 integers to int64, other finite numbers to float64, and
+// anything else is returned unchanged as a string. Only the lowercase bool
+// spellings are recognized so values such as "T" or "1" are not accidentally
+// turned into bools, and "NaN", "Inf" and "Infinity", which ParseFloat
+// accepts, stay strings.
+func InferExtraValue(raw string) any {
+	switch raw {
+	case "true":
+		return true
+	case "false":
+		return false
+	}
+	if v, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return v
+	}
+	if v, err := strconv.ParseFloat(raw, 64); err == nil && !math.IsNaN(v) && !math.IsInf(v, 0) {
+		return v
 	}
-	return nil
+	return raw
 }
@@ -0,0 +1,161 @@
+package template
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestMethodDescTypedExtras(t *testing.T) {
+	m := &MethodDesc{
+		Name: "UpdateCount",
+		Extra: map[string]string{
+			"timeout":  "30",
+			"ratio":    "0.5",
+			"enabled":  "true",
+			"commands": " start, begin ,,",
+			"command":  "start",
+		},
+	}
+
+	if v, err := m.ExtraInt("timeout"); err != nil || v != 30 {
+		t.Errorf("ExtraInt(timeout) = %v, %v", v, err)
+	}
+	if v, err := m.ExtraInt("missing"); err != nil || v != 0 {
+		t.Errorf("ExtraInt(missing) = %v, %v", v, err)
+	}
+	if _, err := m.ExtraInt("command"); err == nil {
+		t.Error("ExtraInt(command) expected an error")
+	}
+	if v, err := m.ExtraFloat("ratio"); err != nil || v != 0.5 {
+		t.Errorf("ExtraFloat(ratio) = %v, %v", v, err)
+	}
+	if _, err := m.ExtraFloat("command"); err == nil {
+		t.Error("ExtraFloat(command) expected an error")
+	}
+	if v, err := m.ExtraBool("enabled"); err != nil || !v {
+		t.Errorf("ExtraBool(enabled) = %v, %v", v, err)
+	}
+	if _, err := m.ExtraBool("command"); err == nil {
+		t.Error("ExtraBool(command) expected an error")
+	}
+	if got := m.ExtraStrings("commands"); !reflect.DeepEqual(got, []string{"start", "begin"}) {
+		t.Errorf("ExtraStrings(commands) = %v", got)
+	}
+	if got := m.ExtraStrings("missing"); got != nil {
+		t.Errorf("ExtraStrings(missing) = %v, want nil", got)
+	}
+	if !m.HasExtra("command") || m.HasExtra("missing") {
+		t.Error("HasExtra returned an unexpected result")
+	}
+	if got := m.ExtraValue("command"); got != "start" {
+		t.Errorf("ExtraValue(command) = %q", got)
+	}
+}
+
+func TestSortedExtraList(t *testing.T) {
+	if got := SortedExtraList(nil); got != nil {
+		t.Errorf("SortedExtraList(nil) = %v, want nil", got)
+	}
+	got := SortedExtraList(map[string]string{"command": "start", "callback_query": "menu", "scope": "user"})
+	want := []ExtraKV{
+		{Key: "callback_query", Value: "menu"},
+		{Key: "command", Value: "start"},
+		{Key: "scope", Value: "user"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("SortedExtraList() = %v, want %v", got, want)
+	}
+}
+
+func TestInferExtraValue(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want any
+	}{
+		{"true", true},
+		{"false", false},
+		{"True", "True"},
+		{"30", int64(30)},
+		{"-5", int64(-5)},
+		{"1.5", 1.5},
+		{"start", "start"},
+		{"", ""},
+		{"a,b", "a,b"},
+		{"NaN", "NaN"},
+		{"nan", "nan"},
+		{"Inf", "Inf"},
+		{"-Inf", "-Inf"},
+		{"+Infinity", "+Infinity"},
+		{"infinity", "infinity"},
+		{"1e400", "1e400"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			if got := InferExtraValue(tt.raw); got != tt.want {
+				t.Errorf("InferExtraValue(%q) = %#v, want %#v", tt.raw, got, tt.want)
+			}
+		})
+	}
+	if got := TypedExtras(nil); got != nil {
+		t.Errorf("TypedExtras(nil) = %v, want nil", got)
+	}
+}
+
+func TestServiceDescLogFields(t *testing.T) {
+	s := &ServiceDesc{ServiceName: "bot.v1.MenuService"}
+	got := s.LogFields(&MethodDesc{OriginalName: "Start", OperationPath: "/bot.v1.MenuService/Start", Commands: []string{"start", "begin"}})
+	want := []LogField{
+		{Key: "service", Value: "bot.v1.MenuService"},
+		{Key: "method", Value: "Start"},
+		{Key: "operation", Value: "/bot.v1.MenuService/Start"},
+		{Key: "command", Value: "start"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("LogFields() = %v, want %v", got, want)
+	}
+	if got := s.LogFields(&MethodDesc{OriginalName: "Notify"}); len(got) != 3 {
+		t.Errorf("LogFields() without a command = %v, want 3 fields", got)
+	}
+}
+
+func TestRateLimitDescPeriodUnit(t *testing.T) {
+	tests := []struct {
+		period time.Duration
+		units  int64
+		unit   string
+	}{
+		{time.Minute, 1, "Minute"},
+		{90 * time.Second, 90, "Second"},
+		{24 * time.Hour, 24, "Hour"},
+		{1500 * time.Millisecond, 1500, "Millisecond"},
+		{time.Microsecond + 1, 1001, "Nanosecond"},
+	}
+	for _, tt := range tests {
+		r := &RateLimitDesc{Count: 1, Period: tt.period}
+		if units, unit := r.PeriodUnits(), r.PeriodUnit(); units != tt.units || unit != tt.unit {
+			t.Errorf("period %v = %d * %s, want %d * %s", tt.period, units, unit, tt.units, tt.unit)
+		}
+	}
+}
+
+func TestFileDescMethods(t *testing.T) {
+	menu := &ServiceDesc{ServiceType: "MenuService", Methods: []*MethodDesc{{Name: "Start"}, {Name: "Help"}}}
+	admin := &ServiceDesc{ServiceType: "AdminService", Methods: []*MethodDesc{{Name: "Ban"}}}
+	file := &FileDesc{Services: []*ServiceDesc{menu, admin}}
+	menu.File, admin.File = file, file
+
+	var names []string
+	for _, m := range file.Methods() {
+		names = append(names, m.Name)
+	}
+	if want := []string{"Start", "Help", "Ban"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("Methods() = %v, want %v", names, want)
+	}
+	if !menu.FirstInFile() || admin.FirstInFile() {
+		t.Errorf("FirstInFile() = %v, %v, want true, false", menu.FirstInFile(), admin.FirstInFile())
+	}
+	if !(&ServiceDesc{}).FirstInFile() {
+		t.Error("FirstInFile() of a service without a file = false, want true")
+	}
+}
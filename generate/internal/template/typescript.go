@@ -0,0 +1,149 @@
+package template
+
+import (
+	_ "embed"
+	"encoding/json"
+	"slices"
+	"strings"
+	"text/template"
+)
+
+//go:embed typescript/routes.ts.tmpl
+var typeScriptTemplate string
+
+// TypeScriptDesc is the data of the TypeScript routes template, rendered once
+// per invocation with every service generated, in generation order, so a web
+// client can build the callback data and look up the commands of the Go side.
+type TypeScriptDesc struct {
+	Sources  []string // proto files of the services: bot/v1/menu.proto
+	Services []*ServiceDesc
+}
+
+// HasCallbackData reports whether any method of any service declares a
+// callback_data extra.
+func (d *TypeScriptDesc) HasCallbackData() bool {
+	for _, s := range d.Services {
+		for _, m := range s.Methods {
+			if m.CallbackData != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// TypeScriptCommand is a command or command alias of a service and the
+// operation constant handling it.
+type TypeScriptCommand struct {
+	Command   string
+	Operation string
+}
+
+var typeScriptGenerator = template.Must(template.New("routes.ts").Option("missingkey=error").Funcs(Funcs()).Funcs(template.FuncMap{
+	"tsQuote":          tsQuote,
+	"tsCommands":       tsCommands,
+	"tsCallbackFields": tsCallbackFields,
+	"tsCallbackData":   tsCallbackData,
+}).Parse(typeScriptTemplate))
+
+// ExecuteTypeScript renders d against the TypeScript routes template. The
+// services must have been rendered by a Generator, which sorts their methods.
+func ExecuteTypeScript(d *TypeScriptDesc) (string, error) {
+	var buf strings.Builder
+	if err := typeScriptGenerator.Execute(&buf, d); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// tsQuote renders s as a TypeScript string literal.
+func tsQuote(s string) string {
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// tsCommands returns the commands and command aliases of s in method order.
+// A command routed by several methods, one per chat type, maps to the first,
+// since an object literal cannot repeat a key.
+func tsCommands(s *ServiceDesc) []TypeScriptCommand {
+	var commands []TypeScriptCommand
+	for _, m := range s.Methods {
+		for _, command := range m.Commands {
+			if !slices.ContainsFunc(commands, func(c TypeScriptCommand) bool { return c.Command == command }) {
+				commands = append(commands, TypeScriptCommand{Command: command, Operation: m.Operation})
+			}
+		}
+	}
+	return commands
+}
+
+// callbackDataParts splits a callback_data format such as "item:{id}" into
+// its literals and placeholders, the latter reported with placeholder set.
+// The format has been validated when the Go code was generated.
+func callbackDataParts(format string) (parts []string, placeholder []bool) {
+	for format != "" {
+		open := strings.IndexByte(format, '{')
+		end := strings.IndexByte(format, '}')
+		if open < 0 || end < open {
+			parts, placeholder = append(parts, format), append(placeholder, false)
+			break
+		}
+		if open > 0 {
+			parts, placeholder = append(parts, format[:open]), append(placeholder, false)
+		}
+		parts, placeholder = append(parts, format[open+1:end]), append(placeholder, true)
+		format = format[end+1:]
+	}
+	return parts, placeholder
+}
+
+// tsFieldName returns the JSON name protoc derives from the proto name of a
+// field, item_id becoming itemId, which TypeScript protobuf runtimes name
+// message properties after.
+func tsFieldName(name string) string {
+	var b strings.Builder
+	upper := false
+	for _, r := range name {
+		switch {
+		case r == '_':
+			upper = true
+		case upper:
+			b.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// tsCallbackFields returns the properties of the request a callback_data
+// format formats, each once, in the order of the format.
+func tsCallbackFields(format string) []string {
+	var fields []string
+	parts, placeholder := callbackDataParts(format)
+	for i, part := range parts {
+		if name := tsFieldName(part); placeholder[i] && !slices.Contains(fields, name) {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// tsCallbackData compiles a callback_data format into the TypeScript
+// expression building it from a request named req: "item:" + String(req.id).
+func tsCallbackData(format string) string {
+	parts, placeholder := callbackDataParts(format)
+	exprs := make([]string, len(parts))
+	for i, part := range parts {
+		if placeholder[i] {
+			exprs[i] = "String(req." + tsFieldName(part) + ")"
+		} else {
+			exprs[i] = tsQuote(part)
+		}
+	}
+	return strings.Join(exprs, " + ")
+}
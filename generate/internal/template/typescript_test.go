@@ -0,0 +1,44 @@
+package template
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTSCallbackData(t *testing.T) {
+	tests := []struct {
+		format string
+		fields []string
+		expr   string
+	}{
+		{"close", nil, `"close"`},
+		{"item:{item_id}", []string{"itemId"}, `"item:" + String(req.itemId)`},
+		{"{id}:{id}/{page_token}", []string{"id", "pageToken"}, `String(req.id) + ":" + String(req.id) + "/" + String(req.pageToken)`},
+	}
+	for _, tt := range tests {
+		if got := tsCallbackFields(tt.format); !slices.Equal(got, tt.fields) {
+			t.Errorf("tsCallbackFields(%q) = %q, want %q", tt.format, got, tt.fields)
+		}
+		if got := tsCallbackData(tt.format); got != tt.expr {
+			t.Errorf("tsCallbackData(%q) = %s, want %s", tt.format, got, tt.expr)
+		}
+	}
+}
+
+func TestTSQuote(t *testing.T) {
+	if got, want := tsQuote(`a "<b>" \d`+"\n"), `"a \"<b>\" \\d\n"`; got != want {
+		t.Errorf("tsQuote = %s, want %s", got, want)
+	}
+}
+
+func TestTSCommands(t *testing.T) {
+	s := &ServiceDesc{Methods: []*MethodDesc{
+		{Operation: "OpStart", Commands: []string{"start", "begin"}},
+		{Operation: "OpGroupStart", Commands: []string{"start"}},
+		{Operation: "OpHelp", Commands: []string{"help"}},
+	}}
+	want := []TypeScriptCommand{{"start", "OpStart"}, {"begin", "OpStart"}, {"help", "OpHelp"}}
+	if got := tsCommands(s); !slices.Equal(got, want) {
+		t.Errorf("tsCommands = %v, want %v", got, want)
+	}
+}
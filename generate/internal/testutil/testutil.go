@@ -42,9 +42,16 @@ func LoadDescriptorSet(t *testing.T, path string) *descriptorpb.FileDescriptorSe
 // depend on the host toolchain version (an unset version renders as "(unknown)").
 func MustCreatePlugin(t *testing.T, set *descriptorpb.FileDescriptorSet, fileToGenerate string) *protogen.Plugin {
 	t.Helper()
+	return MustCreatePluginForFiles(t, set, fileToGenerate)
+}
+
+// MustCreatePluginForFiles is MustCreatePlugin for several files to generate,
+// e.g. a fixture together with a fixture it imports.
+func MustCreatePluginForFiles(t *testing.T, set *descriptorpb.FileDescriptorSet, filesToGenerate ...string) *protogen.Plugin {
+	t.Helper()
 
 	req := &pluginpb.CodeGeneratorRequest{
-		FileToGenerate: []string{fileToGenerate},
+		FileToGenerate: filesToGenerate,
 		ProtoFile:      set.File, // all files, dependencies first
 		CompilerVersion: &pluginpb.Version{
 			Major: proto.Int32(5),
@@ -55,7 +62,7 @@ func MustCreatePlugin(t *testing.T, set *descriptorpb.FileDescriptorSet, fileToG
 
 	plugin, err := protogen.Options{}.New(req)
 	if err != nil {
-		t.Fatalf("failed to create plugin for %q: %v", fileToGenerate, err)
+		t.Fatalf("failed to create plugin for %q: %v", filesToGenerate, err)
 	}
 	return plugin
 }
@@ -0,0 +1,104 @@
+package route
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// packageGroup collects the generated services of the proto files sharing one
+// output directory, and so one Go package.
+type packageGroup struct {
+	dir      string
+	pkg      GoPackage
+	files    []*protogen.File
+	services []*template.ServiceDesc
+}
+
+// packageGroups keeps the groups in the order their first file was added.
+type packageGroups []*packageGroup
+
+// add records the services generated for file; files without any are ignored.
+func (p *packageGroups) add(conf *Config, file *protogen.File, services []*template.ServiceDesc) {
+	if len(services) == 0 {
+		return
+	}
+	pkg, prefix := conf.goPackage(file)
+	dir := path.Dir(prefix)
+	for _, group := range *p {
+		if group.dir == dir {
+			group.files = append(group.files, file)
+			group.services = append(group.services, services...)
+			return
+		}
+	}
+	*p = append(*p, &packageGroup{dir: dir, pkg: pkg, files: []*protogen.File{file}, services: services})
+}
+
+// generateAggregates renders the template's aggregate template once per Go
+// package into <package>.<key>.all.pb.go, next to the package's other
+// generated files.
+func generateAggregates(gen *protogen.Plugin, conf *Config, packages packageGroups) error {
+	for _, group := range packages {
+		err := generateAggregate(gen, conf, group)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func generateAggregate(gen *protogen.Plugin, conf *Config, group *packageGroup) error {
+	filename := path.Join(group.dir, fmt.Sprintf("%s.%s.all.pb.go", group.pkg.Name, strings.ToLower(conf.OptionsKey)))
+	g := gen.NewGeneratedFile(filename, group.pkg.ImportPath)
+	if len(conf.Renders) > 0 {
+		// The aggregate template is the one of the first render.
+		conf = conf.renderConfigs()[0]
+	}
+	generator, err := fileGenerator(g, conf)
+	if err != nil {
+		return err
+	}
+	if !generator.HasAggregate() {
+		g.Skip()
+		return fmt.Errorf("register_all: the selected template does not define a %q template", template.AggregateTemplate)
+	}
+
+	sources := make([]string, 0, len(group.files))
+	for _, file := range group.files {
+		sources = append(sources, file.Desc.Path())
+	}
+	lines := formatFileHeader(conf.fileHeader(
+		formatProtocVersion(gen.Request.GetCompilerVersion()),
+		strings.Join(sources, ", "),
+		string(group.pkg.Name),
+	))
+	for _, line := range lines {
+		g.P(line)
+	}
+	generateGoImport(g, conf)
+
+	services := slices.Clone(group.services)
+	slices.SortStableFunc(services, func(a, b *template.ServiceDesc) int {
+		return strings.Compare(a.ServiceName, b.ServiceName)
+	})
+	content, err := generator.ExecuteAggregate(&template.AggregateDesc{
+		OptionsKey:    template.PascalCase(conf.OptionsKey),
+		GoPackageName: string(group.pkg.Name),
+		Services:      services,
+		Package:       buildPackageDesc(g, conf),
+	})
+	if err != nil {
+		return err
+	}
+	content, err = formatRendered(generator.Name(), "package "+string(group.pkg.Name), content)
+	if err != nil {
+		return err
+	}
+	g.P(content)
+	return nil
+}
@@ -0,0 +1,122 @@
+package route
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// argsBinder compiles the args extra of method, the proto names of request
+// fields such as "id,count", into the body of a function binding the arguments
+// of a command, a []string variable named args, to the request variable req.
+// An argument is bound to the field at its position, unless it has the form
+// name=value for one of the names. Values are converted to the field's type;
+// repeated, map, message and oneof fields cannot be bound. An empty extra
+// yields an empty body.
+func argsBinder(g *protogen.GeneratedFile, method *protogen.Method, names []string) (string, error) {
+	if len(names) == 0 {
+		return "", nil
+	}
+	fail := func(reason string, args ...any) error {
+		return descriptorErrorf(method.Desc, "invalid %s %q: %s", ExtraArgs, strings.Join(names, ","), fmt.Sprintf(reason, args...))
+	}
+	ident := func(importPath protogen.GoImportPath, name string) string {
+		return g.QualifiedGoIdent(importPath.Ident(name))
+	}
+	quoted := make([]string, len(names))
+	var cases []string
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			return "", fail("field %q is listed twice", name)
+		}
+		field := findField(method.Input, name)
+		if field == nil {
+			return "", fail("request %s has no field %q", method.Input.Desc.FullName(), name)
+		}
+		if oneof := field.Desc.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			return "", fail("field %q is in oneof %s", name, oneof.Name())
+		}
+		parse, value, ok := parseArgExpr(g, field)
+		if !ok {
+			return "", fail("field %q is not a scalar", name)
+		}
+		quoted[i] = strconv.Quote(name)
+		lines := []string{"case " + quoted[i] + ":"}
+		lines = append(lines, parse...)
+		if field.Desc.HasPresence() {
+			lines = append(lines, "x := "+value, "req."+field.GoName+" = &x")
+		} else {
+			lines = append(lines, "req."+field.GoName+" = "+value)
+		}
+		cases = append(cases, strings.Join(lines, "\n"))
+	}
+
+	errorf := ident("fmt", "Errorf")
+	var b strings.Builder
+	fmt.Fprintf(&b, "names := [...]string{%s}\n", strings.Join(quoted, ", "))
+	fmt.Fprintf(&b, "if len(args) > len(names) {\nreturn %s(\"too many arguments: got %%d, want at most %%d\", len(args), len(names))\n}\n", errorf)
+	b.WriteString("for i, arg := range args {\n")
+	b.WriteString("name, value := names[i], arg\n")
+	fmt.Fprintf(&b, "if n, v, ok := %s(arg, \"=\"); ok {\nswitch n {\ncase %s:\nname, value = n, v\n}\n}\n", ident("strings", "Cut"), strings.Join(quoted, ", "))
+	b.WriteString("switch name {\n")
+	b.WriteString(strings.Join(cases, "\n"))
+	b.WriteString("\n}\n}\nreturn nil")
+	return b.String(), nil
+}
+
+// parseArgExpr returns the statements parsing the string variable value for
+// field, which leave the parsed value in v and return an error naming the
+// argument on failure, and the expression of the field's type holding it. It
+// reports false for list, map and message fields.
+func parseArgExpr(g *protogen.GeneratedFile, field *protogen.Field) ([]string, string, bool) {
+	if field.Desc.IsList() || field.Desc.IsMap() {
+		return nil, "", false
+	}
+	errorf := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Errorf", GoImportPath: "fmt"})
+	parse := func(call string) []string {
+		return []string{
+			"v, err := " + g.QualifiedGoIdent(strconvPackage.Ident(call)),
+			"if err != nil {",
+			"return " + errorf + "(\"argument %s: %w\", name, err)",
+			"}",
+		}
+	}
+	withArgs := func(lines []string, args string) []string {
+		lines[0] += "(value" + args + ")"
+		return lines
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return nil, "value", true
+	case protoreflect.BytesKind:
+		return nil, "[]byte(value)", true
+	case protoreflect.BoolKind:
+		return withArgs(parse("ParseBool"), ""), "v", true
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return withArgs(parse("ParseInt"), ", 10, 64"), "v", true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return withArgs(parse("ParseInt"), ", 10, 32"), "int32(v)", true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return withArgs(parse("ParseUint"), ", 10, 64"), "v", true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return withArgs(parse("ParseUint"), ", 10, 32"), "uint32(v)", true
+	case protoreflect.FloatKind:
+		return withArgs(parse("ParseFloat"), ", 32"), "float32(v)", true
+	case protoreflect.DoubleKind:
+		return withArgs(parse("ParseFloat"), ", 64"), "v", true
+	case protoreflect.EnumKind:
+		enum := g.QualifiedGoIdent(field.Enum.GoIdent)
+		values := g.QualifiedGoIdent(protogen.GoIdent{GoName: field.Enum.GoIdent.GoName + "_value", GoImportPath: field.Enum.GoIdent.GoImportPath})
+		return []string{
+			"v, ok := " + values + "[value]",
+			"if !ok {",
+			"return " + errorf + "(\"argument %s: unknown " + string(field.Enum.Desc.Name()) + " %q\", name, value)",
+			"}",
+		}, enum + "(v)", true
+	}
+	return nil, "", false
+}
@@ -0,0 +1,53 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestArgsBinder(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/args.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "args.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	g := plugin.NewGeneratedFile("args.route.pb.go", file.GoImportPath)
+	buy := file.Services[0].Methods[0]
+
+	tests := []struct {
+		names   string
+		want    []string
+		wantErr string
+	}{
+		{"", nil, ""},
+		{"note", []string{`names := [...]string{"note"}`, `case "note":` + "\n" + `req.Note = value`}, ""},
+		{"discount", []string{`strconv.ParseFloat(value, 64)`, "x := v\nreq.Discount = &x"}, ""},
+		{"kind", []string{`v, ok := Kind_value[value]`, `req.Kind = Kind(v)`}, ""},
+		{"id,missing", nil, `request testdata.args.v1.BuyRequest has no field "missing"`},
+		{"tags", nil, `field "tags" is not a scalar`},
+		{"user", nil, `field "user" is in oneof target`},
+		{"id,count,id", nil, `field "id" is listed twice`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.names, func(t *testing.T) {
+			got, err := argsBinder(g, buy, splitExtraList(tt.names))
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasSuffix(err.Error(), tt.wantErr) {
+					t.Fatalf("argsBinder() error = %v, want suffix %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("argsBinder() failed: %v", err)
+			}
+			if tt.want == nil && got != "" {
+				t.Errorf("argsBinder() = %q, want empty", got)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(got, want) {
+					t.Errorf("argsBinder() = %s, want it to contain %s", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,185 @@
+package route
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"sync"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// cacheFormat versions the layout of cache entries and what their keys cover;
+// bumping it invalidates every entry written before.
+const cacheFormat = "protoc-gen-route cache 1"
+
+// generationCache is the cache of Config.CacheDir: the renderedFile of every
+// proto file and options key, stored as <key>.json. It is safe for concurrent
+// use.
+type generationCache struct {
+	dir string
+	// base hashes the inputs shared by every file of the run: the plugin
+	// build, the selected template and the request parameters.
+	base []byte
+
+	mu sync.Mutex
+	// descriptors memoizes the hash of every proto file descriptor by path.
+	descriptors map[string][]byte
+}
+
+// newGenerationCache returns the cache of conf.CacheDir for the selected
+// template, or nil when conf has no CacheDir.
+func newGenerationCache(gen *protogen.Plugin, conf *Config) (*generationCache, error) {
+	if conf.CacheDir == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(conf.CacheDir, 0o755); err != nil {
+		return nil, fmt.Errorf("cache_dir: %w", err)
+	}
+	h := sha256.New()
+	writeHashed(h, cacheFormat)
+	if info, ok := debug.ReadBuildInfo(); ok {
+		// The settings hold the VCS revision of development builds, whose
+		// module version is (devel).
+		writeHashed(h, info.Main.Version)
+		for _, setting := range info.Settings {
+			writeHashed(h, setting.Key+"="+setting.Value)
+		}
+	}
+	writeHashed(h, template.SelectionHash())
+	for _, r := range conf.Renders {
+		writeHashed(h, r.source)
+	}
+	writeHashed(h, gen.Request.GetParameter())
+	writeHashed(h, formatProtocVersion(gen.Request.GetCompilerVersion()))
+	return &generationCache{dir: conf.CacheDir, base: h.Sum(nil), descriptors: make(map[string][]byte)}, nil
+}
+
+// lookup returns the cache key of file generated with conf and the stored
+// output of that key, if any.
+func (c *generationCache) lookup(file *protogen.File, conf *Config) (string, *renderedFile, error) {
+	key, err := c.key(file, conf)
+	if err != nil {
+		return "", nil, err
+	}
+	return key, readCacheEntry(c.entryPath(key)), nil
+}
+
+// store stores out under key.
+func (c *generationCache) store(key string, out *renderedFile) error {
+	return writeCacheEntry(c.entryPath(key), out)
+}
+
+// entryPath returns the path of the entry of key.
+func (c *generationCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// key returns the hex cache key of file generated with conf: a hash of the
+// run's inputs, conf, where file is generated to, and the descriptors of file
+// and every file it imports, directly or not.
+func (c *generationCache) key(file *protogen.File, conf *Config) (string, error) {
+	keyConf := *conf
+	keyConf.CacheDir, keyConf.Stats, keyConf.StatsOut = "", false, ""
+	rawConf, err := json.Marshal(&keyConf)
+	if err != nil {
+		return "", err
+	}
+	h := sha256.New()
+	h.Write(c.base)
+	writeHashed(h, string(rawConf))
+	writeHashed(h, file.GeneratedFilenamePrefix)
+	writeHashed(h, string(file.GoImportPath))
+	writeHashed(h, string(file.GoPackageName))
+
+	files := map[string]protoreflect.FileDescriptor{}
+	var collect func(fd protoreflect.FileDescriptor)
+	collect = func(fd protoreflect.FileDescriptor) {
+		if _, ok := files[fd.Path()]; ok {
+			return
+		}
+		files[fd.Path()] = fd
+		imports := fd.Imports()
+		for i := range imports.Len() {
+			collect(imports.Get(i).FileDescriptor)
+		}
+	}
+	collect(file.Desc)
+	for _, path := range sortedKeys(files) {
+		sum, err := c.descriptorHash(files[path])
+		if err != nil {
+			return "", err
+		}
+		writeHashed(h, path)
+		h.Write(sum)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// descriptorHash returns the hash of the deterministically marshaled
+// descriptor of fd.
+func (c *generationCache) descriptorHash(fd protoreflect.FileDescriptor) ([]byte, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if sum, ok := c.descriptors[fd.Path()]; ok {
+		return sum, nil
+	}
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(protodesc.ToFileDescriptorProto(fd))
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(raw)
+	c.descriptors[fd.Path()] = sum[:]
+	return sum[:], nil
+}
+
+// writeHashed writes s to h prefixed with its length, so consecutive strings
+// cannot run into each other.
+func writeHashed(h io.Writer, s string) {
+	fmt.Fprintf(h, "%d:%s", len(s), s)
+}
+
+// readCacheEntry reads the entry at path. An entry missing or unreadable is a
+// miss, returned as nil.
+func readCacheEntry(path string) *renderedFile {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var entry renderedFile
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil
+	}
+	return &entry
+}
+
+// writeCacheEntry writes entry to path through a temporary file, so that
+// concurrent runs sharing the directory never read a partial entry.
+func writeCacheEntry(path string, entry *renderedFile) error {
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".entry-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(raw); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
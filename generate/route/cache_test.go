@@ -0,0 +1,85 @@
+package route
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestRun_CacheDir(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	dir := t.TempDir()
+
+	generate := func(conf *Config) *pluginpb.CodeGeneratorResponse {
+		t.Helper()
+		plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+		if err := Run(plugin, conf); err != nil {
+			t.Fatalf("Run() failed: %v", err)
+		}
+		resp := plugin.Response()
+		if resp.Error != nil {
+			t.Fatalf("Response() failed: %s", resp.GetError())
+		}
+		return resp
+	}
+	entries := func() []string {
+		t.Helper()
+		names, err := filepath.Glob(filepath.Join(dir, "*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return names
+	}
+
+	conf := DefaultConfig()
+	conf.Manifest = ManifestJSON
+	uncached := generate(conf)
+
+	conf.CacheDir = dir
+	cached := generate(conf)
+	if len(cached.GetFile()) != len(uncached.GetFile()) {
+		t.Fatalf("cached run generated %d files, want %d", len(cached.GetFile()), len(uncached.GetFile()))
+	}
+	for i, f := range cached.GetFile() {
+		want := uncached.GetFile()[i]
+		if f.GetName() != want.GetName() || f.GetContent() != want.GetContent() {
+			t.Errorf("cached run generated %s differently from an uncached run", f.GetName())
+		}
+	}
+	if got := entries(); len(got) != 1 {
+		t.Fatalf("cache entries = %v, want one", got)
+	}
+
+	// A hit emits the stored files without rendering.
+	entry := entries()[0]
+	raw, err := os.ReadFile(entry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(entry, bytes.ReplaceAll(raw, []byte("GetMenu"), []byte("FromCache")), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if resp := generate(conf); !strings.Contains(resp.GetFile()[0].GetContent(), "FromCache") {
+		t.Error("unchanged inputs were rendered again instead of served from the cache")
+	}
+
+	// Changing the template or the configuration misses.
+	conf.Template = "minimal"
+	if resp := generate(conf); strings.Contains(resp.GetFile()[0].GetContent(), "FromCache") {
+		t.Error("a template change was served from the cache")
+	}
+	conf.Template = ""
+	conf.Manifest = ""
+	if resp := generate(conf); len(resp.GetFile()) != 1 {
+		t.Errorf("a configuration change was served from the cache: %d files", len(resp.GetFile()))
+	}
+	if got := entries(); len(got) != 3 {
+		t.Errorf("cache entries = %v, want three", got)
+	}
+}
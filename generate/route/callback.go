@@ -0,0 +1,98 @@
+package route
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+const strconvPackage = protogen.GoImportPath("strconv")
+
+// callbackDataExpr compiles the callback_data format of method, such as
+// "item:{id}", into a Go string expression over the request variable req:
+// "item:" + strconv.FormatInt(req.GetId(), 10). Placeholders name scalar
+// fields of the request message by their proto name. An empty format yields
+// an empty expression.
+func callbackDataExpr(g *protogen.GeneratedFile, method *protogen.Method, format string) (string, error) {
+	if format == "" {
+		return "", nil
+	}
+	fail := func(reason string, args ...any) error {
+		return descriptorErrorf(method.Desc, "invalid %s %q: %s", ExtraCallbackData, format, fmt.Sprintf(reason, args...))
+	}
+	var parts []string
+	rest := format
+	for rest != "" {
+		open := strings.IndexAny(rest, "{}")
+		if open < 0 {
+			parts = append(parts, strconv.Quote(rest))
+			break
+		}
+		if rest[open] == '}' {
+			return "", fail("unexpected '}'")
+		}
+		if open > 0 {
+			parts = append(parts, strconv.Quote(rest[:open]))
+		}
+		end := strings.IndexByte(rest[open:], '}')
+		if end < 0 {
+			return "", fail("unclosed '{'")
+		}
+		name := rest[open+1 : open+end]
+		field := findField(method.Input, name)
+		if field == nil {
+			return "", fail("request %s has no field %q", method.Input.Desc.FullName(), name)
+		}
+		expr, ok := formatFieldExpr(g, field, "req.Get"+field.GoName+"()")
+		if !ok {
+			return "", fail("field %q is not a scalar", name)
+		}
+		parts = append(parts, expr)
+		rest = rest[open+end+1:]
+	}
+	return strings.Join(parts, " + "), nil
+}
+
+// findField returns the field of message with the given proto name, or nil.
+func findField(message *protogen.Message, name string) *protogen.Field {
+	for _, field := range message.Fields {
+		if string(field.Desc.Name()) == name {
+			return field
+		}
+	}
+	return nil
+}
+
+// formatFieldExpr returns the Go expression formatting value, a getter call
+// for field, as a string. It reports false for bytes, message, list and map
+// fields, which have no canonical single-token form.
+func formatFieldExpr(g *protogen.GeneratedFile, field *protogen.Field, value string) (string, bool) {
+	if field.Desc.IsList() || field.Desc.IsMap() {
+		return "", false
+	}
+	ident := func(name string) string {
+		return g.QualifiedGoIdent(strconvPackage.Ident(name))
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return value, true
+	case protoreflect.BoolKind:
+		return ident("FormatBool") + "(" + value + ")", true
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return ident("FormatInt") + "(" + value + ", 10)", true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind, protoreflect.EnumKind:
+		return ident("FormatInt") + "(int64(" + value + "), 10)", true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return ident("FormatUint") + "(" + value + ", 10)", true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return ident("FormatUint") + "(uint64(" + value + "), 10)", true
+	case protoreflect.FloatKind:
+		return ident("FormatFloat") + "(float64(" + value + "), 'g', -1, 32)", true
+	case protoreflect.DoubleKind:
+		return ident("FormatFloat") + "(" + value + ", 'g', -1, 64)", true
+	}
+	return "", false
+}
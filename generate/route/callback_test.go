@@ -0,0 +1,48 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestCallbackDataExpr(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/callback_pattern.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "callback_pattern.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	g := plugin.NewGeneratedFile("callback_pattern.route.pb.go", file.GoImportPath)
+	buy := file.Services[0].Methods[1]
+
+	tests := []struct {
+		format  string
+		want    string
+		wantErr string
+	}{
+		{"", "", ""},
+		{"static", `"static"`, ""},
+		{"{note}", "req.GetNote()", ""},
+		{"buy:{id}:{count}", `"buy:" + strconv.FormatInt(req.GetId(), 10) + ":" + strconv.FormatInt(int64(req.GetCount()), 10)`, ""},
+		{"buy:{missing}", "", `request testdata.callbackpattern.v1.BuyRequest has no field "missing"`},
+		{"buy:{tags}", "", `field "tags" is not a scalar`},
+		{"buy:{id", "", "unclosed '{'"},
+		{"buy:id}", "", "unexpected '}'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			got, err := callbackDataExpr(g, buy, tt.format)
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasSuffix(err.Error(), tt.wantErr) {
+					t.Fatalf("callbackDataExpr() error = %v, want suffix %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("callbackDataExpr() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("callbackDataExpr() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
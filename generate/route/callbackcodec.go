@@ -0,0 +1,119 @@
+package route
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// maxCallbackDataLen is Telegram's limit on the callback data of a button, in
+// bytes.
+const maxCallbackDataLen = 64
+
+// callbackCodec compiles the callback_fields extra of method, the proto names
+// of request fields such as "id,page", into the bodies of an encoder and a
+// decoder of compact callback data: the snake_case method name followed by
+// the fields, separated by colons, e.g. "show_item:2s:3" for {id: 100, page: 3}.
+// Integers and enums are written in base 36, bools as t or f, strings query
+// escaped and bytes in unpadded URL-safe base64. The encoder formats the
+// request variable req, failing when the data exceeds 64 bytes; the decoder
+// parses the string variable data into req. An empty extra yields empty
+// bodies.
+func callbackCodec(g *protogen.GeneratedFile, method *protogen.Method, names []string) (encoder, decoder string, err error) {
+	if len(names) == 0 {
+		return "", "", nil
+	}
+	fail := func(reason string, args ...any) error {
+		return descriptorErrorf(method.Desc, "invalid %s %q: %s", ExtraCallbackFields, strings.Join(names, ","), fmt.Sprintf(reason, args...))
+	}
+	errorf := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Errorf", GoImportPath: "fmt"})
+	prefix := template.SnakeCase(string(method.Desc.Name()))
+	var parts, decode []string
+	sep := prefix + ":"
+	for i, name := range names {
+		if slices.Contains(names[:i], name) {
+			return "", "", fail("field %q is listed twice", name)
+		}
+		field := findField(method.Input, name)
+		if field == nil {
+			return "", "", fail("request %s has no field %q", method.Input.Desc.FullName(), name)
+		}
+		if oneof := field.Desc.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			return "", "", fail("field %q is in oneof %s", name, oneof.Name())
+		}
+		v := fmt.Sprintf("v%d", i+1)
+		encode, parse, value, ok := compactFieldExprs(g, field, "req.Get"+field.GoName+"()", fmt.Sprintf("parts[%d]", i+1), v)
+		if !ok {
+			return "", "", fail("field %q is not a scalar", name)
+		}
+		parts = append(parts, strconv.Quote(sep), encode)
+		sep = ":"
+		decode = append(decode,
+			v+", err := "+parse,
+			"if err != nil {",
+			"return "+errorf+"(\"callback data field "+name+": %w\", err)",
+			"}",
+		)
+		switch {
+		case field.Desc.HasPresence() && value == v:
+			decode = append(decode, "req."+field.GoName+" = &"+v)
+		case field.Desc.HasPresence():
+			decode = append(decode, "x"+v+" := "+value, "req."+field.GoName+" = &x"+v)
+		default:
+			decode = append(decode, "req."+field.GoName+" = "+value)
+		}
+	}
+
+	encoder = fmt.Sprintf("data := %s\nif len(data) > %d {\nreturn \"\", %s(\"callback data %%q is longer than %d bytes\", data)\n}\nreturn data, nil",
+		strings.Join(parts, " + "), maxCallbackDataLen, errorf, maxCallbackDataLen)
+	decoder = fmt.Sprintf("parts := %s(data, \":\")\nif len(parts) != %d || parts[0] != %s {\nreturn %s(\"callback data %%q is not %s data\", data)\n}\n%s\nreturn nil",
+		g.QualifiedGoIdent(protogen.GoIdent{GoName: "Split", GoImportPath: "strings"}), len(names)+1, strconv.Quote(prefix), errorf, prefix, strings.Join(decode, "\n"))
+	return encoder, decoder, nil
+}
+
+// compactFieldExprs returns, for field, the expression encoding value, a
+// getter call, compactly as a string; the call parsing the string expression
+// part, returning a value and an error; and the expression of the field's type
+// holding that value once stored in the variable v. It reports false for list,
+// map and message fields.
+func compactFieldExprs(g *protogen.GeneratedFile, field *protogen.Field, value, part, v string) (encode, parse, decoded string, ok bool) {
+	if field.Desc.IsList() || field.Desc.IsMap() {
+		return "", "", "", false
+	}
+	strconvIdent := func(name string) string {
+		return g.QualifiedGoIdent(strconvPackage.Ident(name))
+	}
+	urlIdent := func(name string) string {
+		return g.QualifiedGoIdent(protogen.GoIdent{GoName: name, GoImportPath: "net/url"})
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return urlIdent("QueryEscape") + "(" + value + ")", urlIdent("QueryUnescape") + "(" + part + ")", v, true
+	case protoreflect.BytesKind:
+		base64 := g.QualifiedGoIdent(protogen.GoIdent{GoName: "RawURLEncoding", GoImportPath: "encoding/base64"})
+		return base64 + ".EncodeToString(" + value + ")", base64 + ".DecodeString(" + part + ")", v, true
+	case protoreflect.BoolKind:
+		return strconvIdent("FormatBool") + "(" + value + ")[:1]", strconvIdent("ParseBool") + "(" + part + ")", v, true
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return strconvIdent("FormatInt") + "(" + value + ", 36)", strconvIdent("ParseInt") + "(" + part + ", 36, 64)", v, true
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return strconvIdent("FormatInt") + "(int64(" + value + "), 36)", strconvIdent("ParseInt") + "(" + part + ", 36, 32)", "int32(" + v + ")", true
+	case protoreflect.EnumKind:
+		enum := g.QualifiedGoIdent(field.Enum.GoIdent)
+		return strconvIdent("FormatInt") + "(int64(" + value + "), 36)", strconvIdent("ParseInt") + "(" + part + ", 36, 32)", enum + "(" + v + ")", true
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return strconvIdent("FormatUint") + "(" + value + ", 36)", strconvIdent("ParseUint") + "(" + part + ", 36, 64)", v, true
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return strconvIdent("FormatUint") + "(uint64(" + value + "), 36)", strconvIdent("ParseUint") + "(" + part + ", 36, 32)", "uint32(" + v + ")", true
+	case protoreflect.FloatKind:
+		return strconvIdent("FormatFloat") + "(float64(" + value + "), 'g', -1, 32)", strconvIdent("ParseFloat") + "(" + part + ", 32)", "float32(" + v + ")", true
+	case protoreflect.DoubleKind:
+		return strconvIdent("FormatFloat") + "(" + value + ", 'g', -1, 64)", strconvIdent("ParseFloat") + "(" + part + ", 64)", v, true
+	}
+	return "", "", "", false
+}
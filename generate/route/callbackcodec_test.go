@@ -0,0 +1,59 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestCallbackCodec(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/args.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "args.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	g := plugin.NewGeneratedFile("args.route.pb.go", file.GoImportPath)
+	buy := file.Services[0].Methods[0]
+
+	tests := []struct {
+		names       string
+		wantEncoder []string
+		wantDecoder []string
+		wantErr     string
+	}{
+		{"", nil, nil, ""},
+		{"id,gift", []string{`data := "buy:" + strconv.FormatInt(req.GetId(), 36) + ":" + strconv.FormatBool(req.GetGift())[:1]`}, []string{`len(parts) != 3 || parts[0] != "buy"`, `strconv.ParseBool(parts[2])`}, ""},
+		{"kind,discount", []string{`strconv.FormatInt(int64(req.GetKind()), 36)`}, []string{"req.Kind = Kind(v1)", "req.Discount = &v2"}, ""},
+		{"payload", []string{"base64.RawURLEncoding.EncodeToString(req.GetPayload())"}, []string{"base64.RawURLEncoding.DecodeString(parts[1])"}, ""},
+		{"id,missing", nil, nil, `request testdata.args.v1.BuyRequest has no field "missing"`},
+		{"tags", nil, nil, `field "tags" is not a scalar`},
+		{"user", nil, nil, `field "user" is in oneof target`},
+		{"id,id", nil, nil, `field "id" is listed twice`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.names, func(t *testing.T) {
+			encoder, decoder, err := callbackCodec(g, buy, splitExtraList(tt.names))
+			if tt.wantErr != "" {
+				if err == nil || !strings.HasSuffix(err.Error(), tt.wantErr) {
+					t.Fatalf("callbackCodec() error = %v, want suffix %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("callbackCodec() failed: %v", err)
+			}
+			if tt.names == "" && (encoder != "" || decoder != "") {
+				t.Errorf("callbackCodec() = %q, %q, want empty", encoder, decoder)
+			}
+			for _, want := range tt.wantEncoder {
+				if !strings.Contains(encoder, want) {
+					t.Errorf("encoder = %s, want it to contain %s", encoder, want)
+				}
+			}
+			for _, want := range tt.wantDecoder {
+				if !strings.Contains(decoder, want) {
+					t.Errorf("decoder = %s, want it to contain %s", decoder, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,227 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// jsonSchemaDialect is the JSON Schema draft the callback schema follows.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// callbackPlaceholderPattern matches a {field} placeholder of a callback_data
+// extra.
+var callbackPlaceholderPattern = regexp.MustCompile(`\{([^{}]*)\}`)
+
+// jsonSchema is the subset of JSON Schema the callback schema uses. Type is a
+// string or, for 64-bit integers protojson writes as strings, a list of them;
+// AdditionalProperties is false or the schema of a map value.
+type jsonSchema struct {
+	Schema               string                 `json:"$schema,omitempty"`
+	Ref                  string                 `json:"$ref,omitempty"`
+	Title                string                 `json:"title,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Type                 any                    `json:"type,omitempty"`
+	Format               string                 `json:"format,omitempty"`
+	ContentEncoding      string                 `json:"contentEncoding,omitempty"`
+	Enum                 []string               `json:"enum,omitempty"`
+	Items                *jsonSchema            `json:"items,omitempty"`
+	Properties           map[string]*jsonSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties any                    `json:"additionalProperties,omitempty"`
+	Defs                 map[string]*jsonSchema `json:"$defs,omitempty"`
+}
+
+// generateCallbackSchema emits the .<key>.callbacks.schema.json sidecar, the
+// JSON Schema of the request messages of the methods routed by callbacks,
+// for tools crafting callbacks outside the bot. Nothing is emitted when no
+// method takes callbacks.
+func generateCallbackSchema(gen *protogen.Plugin, file *protogen.File, conf *Config, services []*template.ServiceDesc) error {
+	schema := buildCallbackSchema(file, services)
+	if schema == nil {
+		return nil
+	}
+	content, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := file.GeneratedFilenamePrefix + fmt.Sprintf(".%s.callbacks.schema.json", strings.ToLower(conf.OptionsKey))
+	g := gen.NewGeneratedFile(filename, "")
+	_, err = g.Write(append(content, '\n'))
+	return err
+}
+
+// buildCallbackSchema describes every method with a callback_query,
+// callback_query_pattern, callback_data or callback_fields extra as a property
+// named after its operation path, referencing the schema of its request
+// message in $defs and requiring the fields its callback data carries. The
+// messages follow the protojson mapping: lowerCamelCase names, 64-bit
+// integers as numbers or strings, enums by value name, bytes in base64 and
+// the well-known types in their JSON forms. It returns nil when no method
+// takes callbacks.
+func buildCallbackSchema(file *protogen.File, services []*template.ServiceDesc) *jsonSchema {
+	schema := &jsonSchema{
+		Schema:     jsonSchemaDialect,
+		Title:      file.Desc.Path() + " callbacks",
+		Type:       "object",
+		Properties: make(map[string]*jsonSchema),
+		Defs:       make(map[string]*jsonSchema),
+	}
+	for _, sd := range services {
+		for _, md := range sd.Methods {
+			if md.ExtraValue("callback_query") == "" && md.CallbackQueryPattern == "" && md.CallbackData == "" && len(md.CallbackFields) == 0 {
+				continue
+			}
+			method := findMethod(file, md.OperationPath)
+			summary, _ := splitComment(string(method.Comments.Leading))
+			op := &jsonSchema{
+				Ref:         messageSchemaRef(method.Input.Desc),
+				Title:       string(method.Parent.Desc.Name()) + "." + md.OriginalName,
+				Description: summary,
+				Required:    callbackFieldNames(method.Input, md),
+			}
+			schema.Properties[md.OperationPath] = op
+			addMessageSchema(schema.Defs, method.Input)
+		}
+	}
+	if len(schema.Properties) == 0 {
+		return nil
+	}
+	return schema
+}
+
+// callbackFieldNames returns the JSON names of the request fields the
+// callback data of md carries: the placeholders of its callback_data extra,
+// then its callback_fields, without repeats.
+func callbackFieldNames(message *protogen.Message, md *template.MethodDesc) []string {
+	var names []string
+	for _, match := range callbackPlaceholderPattern.FindAllStringSubmatch(md.CallbackData, -1) {
+		names = append(names, match[1])
+	}
+	names = append(names, md.CallbackFields...)
+	var required []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		field := findField(message, strings.TrimSpace(name))
+		if field == nil || seen[field.Desc.JSONName()] {
+			continue
+		}
+		seen[field.Desc.JSONName()] = true
+		required = append(required, field.Desc.JSONName())
+	}
+	return required
+}
+
+func messageSchemaRef(desc protoreflect.MessageDescriptor) string {
+	return "#/$defs/" + string(desc.FullName())
+}
+
+// addMessageSchema adds the schema of message, and of the messages its
+// fields refer to, to defs. Well-known types are inlined where used instead.
+func addMessageSchema(defs map[string]*jsonSchema, message *protogen.Message) {
+	name := string(message.Desc.FullName())
+	if _, ok := defs[name]; ok {
+		return
+	}
+	summary, _ := splitComment(string(message.Comments.Leading))
+	schema := &jsonSchema{
+		Title:                string(message.Desc.Name()),
+		Description:          summary,
+		Type:                 "object",
+		Properties:           make(map[string]*jsonSchema),
+		AdditionalProperties: false,
+	}
+	defs[name] = schema
+	for _, field := range message.Fields {
+		property := fieldSchema(defs, field)
+		if summary, _ := splitComment(string(field.Comments.Leading)); summary != "" {
+			property.Description = summary
+		}
+		schema.Properties[field.Desc.JSONName()] = property
+		if field.Desc.Cardinality() == protoreflect.Required {
+			schema.Required = append(schema.Required, field.Desc.JSONName())
+		}
+	}
+	if len(schema.Properties) == 0 {
+		schema.Properties = nil
+	}
+}
+
+// fieldSchema returns the schema of the values of field: an array of them for
+// a list, and an object of them for a map.
+func fieldSchema(defs map[string]*jsonSchema, field *protogen.Field) *jsonSchema {
+	switch {
+	case field.Desc.IsMap():
+		return &jsonSchema{Type: "object", AdditionalProperties: fieldSchema(defs, field.Message.Fields[1])}
+	case field.Desc.IsList():
+		return &jsonSchema{Type: "array", Items: singularFieldSchema(defs, field)}
+	default:
+		return singularFieldSchema(defs, field)
+	}
+}
+
+func singularFieldSchema(defs map[string]*jsonSchema, field *protogen.Field) *jsonSchema {
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return &jsonSchema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &jsonSchema{Type: "string", ContentEncoding: "base64"}
+	case protoreflect.BoolKind:
+		return &jsonSchema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &jsonSchema{Type: "integer"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &jsonSchema{Type: []string{"integer", "string"}}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return &jsonSchema{Type: "number"}
+	case protoreflect.EnumKind:
+		schema := &jsonSchema{Type: "string"}
+		for _, value := range field.Enum.Values {
+			schema.Enum = append(schema.Enum, string(value.Desc.Name()))
+		}
+		return schema
+	default:
+		if schema := wellKnownSchema(field.Message); schema != nil {
+			return schema
+		}
+		addMessageSchema(defs, field.Message)
+		return &jsonSchema{Ref: messageSchemaRef(field.Message.Desc)}
+	}
+}
+
+// wellKnownSchema returns the schema of the JSON form protojson gives a
+// well-known type, or nil for other messages.
+func wellKnownSchema(message *protogen.Message) *jsonSchema {
+	switch message.Desc.FullName() {
+	case "google.protobuf.Timestamp":
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case "google.protobuf.Duration":
+		return &jsonSchema{Type: "string", Description: "seconds with an s suffix, e.g. \"1.5s\""}
+	case "google.protobuf.FieldMask":
+		return &jsonSchema{Type: "string", Description: "comma-separated lowerCamelCase field paths"}
+	case "google.protobuf.Struct":
+		return &jsonSchema{Type: "object"}
+	case "google.protobuf.ListValue":
+		return &jsonSchema{Type: "array"}
+	case "google.protobuf.Value":
+		return &jsonSchema{}
+	case "google.protobuf.Empty":
+		return &jsonSchema{Type: "object", AdditionalProperties: false}
+	case "google.protobuf.Any":
+		return &jsonSchema{Type: "object", Required: []string{"@type"}}
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue",
+		"google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		return singularFieldSchema(nil, message.Fields[0])
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,44 @@
+package route
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestGoldenCallbackSchema(t *testing.T) {
+	conf := DefaultConfig()
+	conf.CallbackSchema = true
+	content := generateSidecar(t, "testdata/pb/callback_schema.pb", "callback_schema.proto", conf, ".route.callbacks.schema.json")
+	compareGolden(t, "testdata/golden/callback_schema.route.callbacks.schema.json", content)
+
+	var schema jsonSchema
+	if err := json.Unmarshal(content, &schema); err != nil {
+		t.Fatalf("schema is not valid JSON: %v", err)
+	}
+	if _, ok := schema.Properties["/testdata.callbackschema.v1.OrderService/Start"]; ok {
+		t.Error("Start takes no callbacks but has a schema")
+	}
+	reschedule := schema.Properties["/testdata.callbackschema.v1.OrderService/Reschedule"]
+	if reschedule == nil || strings.Join(reschedule.Required, ",") != "id,page" {
+		t.Errorf("Reschedule = %+v, want id and page required", reschedule)
+	}
+}
+
+func TestCallbackSchema_NoCallbacks(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	conf := DefaultConfig()
+	conf.CallbackSchema = true
+	if _, err := GenerateFile(plugin, file, conf); err != nil {
+		t.Fatalf("GenerateFile failed: %v", err)
+	}
+	for _, f := range plugin.Response().GetFile() {
+		if strings.HasSuffix(f.GetName(), ".callbacks.schema.json") {
+			t.Errorf("unexpected %s without callback routes", f.GetName())
+		}
+	}
+}
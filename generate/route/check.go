@@ -0,0 +1,87 @@
+package route
+
+import (
+	"errors"
+	"regexp"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Check reports the route conflicts of the files to generate without writing
+// them, as a gate across every proto module of a workspace: the errors Run
+// reports for conf, such as invalid extras, commands routed twice in a
+// service and missing required extras, and for every options key the
+// commands routed to methods of two services and the callback_query_pattern
+// extras a callback value could match for two methods. The services are built
+// but not rendered, so the request and response models may be unset. The
+// files Run adds to the response of gen are meant to be discarded.
+func Check(gen *protogen.Plugin, conf *Config) error {
+	runConf := *conf
+	runConf.ErrorFormat, runConf.CacheDir = "", ""
+	runConf.checkOnly = true
+	errs := []error{Run(gen, &runConf)}
+	keys, err := ParseOptionsKeys(conf.OptionsKey)
+	if err != nil {
+		return formatError(conf.ErrorFormat, joinErrors(errs))
+	}
+	checkConf, err := withRuleExtension(gen, conf)
+	if err != nil {
+		return formatError(conf.ErrorFormat, joinErrors(errs))
+	}
+	for _, key := range expandOptionsKeys(gen, keys, checkConf.ruleExtension()) {
+		errs = append(errs, checkRouteConflicts(gen, checkConf.ForKey(key)))
+	}
+	return formatError(conf.ErrorFormat, joinErrors(errs))
+}
+
+// checkRouteConflicts reports, across every file to generate, a command alias
+// routed for conf.OptionsKey by methods of two services, and two
+// callback_query_pattern extras that a callback value matches both of, with
+// the proto positions of both methods and such a value. Conflicts within a
+// service, and extras that do not parse, are left to generation.
+func checkRouteConflicts(gen *protogen.Plugin, conf *Config) error {
+	type pattern struct {
+		method *protogen.Method
+		value  string
+	}
+	commands := make(map[string]*protogen.Method)
+	var patterns []pattern
+	var errs []error
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				extra, ok, err := extractMethodExtras(service, method, conf.OptionsKey, conf.ruleExtension(), conf.Streaming == StreamingInclude, conf.ExtrasOverrides, conf.Vars, conf.tagFilter())
+				if err != nil || !ok {
+					continue
+				}
+				for _, command := range splitExtraList(extra[ExtraCommand]) {
+					key := commandKey(command, extra[ExtraChatType])
+					other, routed := commands[key]
+					if !routed {
+						commands[key] = method
+						continue
+					}
+					if other.Parent != service {
+						errs = append(errs, descriptorErrorf(method.Desc, "%s alias %q under options key %q is already routed to %s (%s)",
+							ExtraCommand, command, conf.OptionsKey, other.Desc.FullName(), descriptorPosition(other.Desc)))
+					}
+				}
+				value, ok := extra[ExtraCallbackQueryPattern]
+				if _, err := regexp.Compile(value); !ok || err != nil {
+					continue
+				}
+				for _, other := range patterns {
+					if example, overlap, _ := patternsOverlap(other.value, value); overlap {
+						errs = append(errs, descriptorErrorf(method.Desc, "%s %q under options key %q overlaps %q of %s (%s): both match %q",
+							ExtraCallbackQueryPattern, value, conf.OptionsKey, other.value, other.method.Desc.FullName(), descriptorPosition(other.method.Desc), example))
+					}
+				}
+				patterns = append(patterns, pattern{method: method, value: value})
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,60 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestCheck(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/check.pb")
+
+	t.Run("no conflicts", func(t *testing.T) {
+		plugin := testutil.MustCreatePluginForFiles(t, set, "basic.proto", "callback_pattern.proto")
+		if err := Check(plugin, DefaultConfig()); err != nil {
+			t.Errorf("Check() = %v, want nil", err)
+		}
+	})
+
+	t.Run("conflicts across files", func(t *testing.T) {
+		plugin := testutil.MustCreatePluginForFiles(t, set, "basic.proto", "callback_pattern.proto", "check.proto")
+		err := Check(plugin, DefaultConfig())
+		if err == nil {
+			t.Fatal("Check() = nil, want conflicts")
+		}
+		msg := err.Error()
+		for _, want := range []string{
+			`testdata.check.v1.StoreService.Restart: command alias "start" under options key "route" is already routed to testdata.basic.v1.MenuService.UpdateCount (basic.proto:`,
+			`testdata.check.v1.StoreService.Peek: callback_query_pattern "^item:\\d" under options key "route" overlaps "^item:(\\d+)$" of testdata.callbackpattern.v1.ShopService.ShowItem (callback_pattern.proto:`,
+			`both match "item:0"`,
+		} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("error %q does not contain %q", msg, want)
+			}
+		}
+		if n := strings.Count(msg, "\n") + 1; n != 2 {
+			t.Errorf("got %d conflicts, want 2:\n%s", n, msg)
+		}
+	})
+
+	t.Run("without models", func(t *testing.T) {
+		plugin := testutil.MustCreatePluginForFiles(t, set, "basic.proto", "callback_pattern.proto", "check.proto")
+		conf := DefaultConfig()
+		conf.RequestType, conf.ResponseType = protogen.GoIdent{}, protogen.GoIdent{}
+		err := Check(plugin, conf)
+		if err == nil || strings.Count(err.Error(), "\n") != 1 {
+			t.Errorf("Check() = %v, want the 2 conflicts", err)
+		}
+	})
+
+	t.Run("generation errors", func(t *testing.T) {
+		set := testutil.LoadDescriptorSet(t, "testdata/pb/invalid_pattern.pb")
+		plugin := testutil.MustCreatePlugin(t, set, "invalid_pattern.proto")
+		if err := Check(plugin, DefaultConfig()); err == nil || !strings.Contains(err.Error(), "invalid callback_query_pattern") {
+			t.Errorf("Check() = %v, want the invalid pattern", err)
+		}
+	})
+}
@@ -2,7 +2,15 @@ package route
 
 import (
 	"errors"
+	"fmt"
+	"go/build/constraint"
+	"go/token"
+	"io"
+	"path"
+	"slices"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
 	"google.golang.org/protobuf/compiler/protogen"
@@ -14,6 +22,160 @@ const (
 	// key are turned into route code.
 	DefaultOptionsKey = "route"
 
+	// DefaultFilePattern names the generated Go file when Config.FilePattern
+	// is empty; see formatFilename for the placeholders.
+	DefaultFilePattern = "{proto}.{key}.pb.go"
+	// DefaultServiceFilePattern names the generated Go files with
+	// Config.Split set to SplitService when Config.FilePattern is empty.
+	DefaultServiceFilePattern = "{service}.{key}.pb.go"
+
+	// SplitFile and SplitService are the Config.Split values: one generated Go
+	// file per proto file, or one per service.
+	SplitFile    = "file"
+	SplitService = "service"
+
+	// ExtraCommand is the extra holding a bot command, optionally followed by
+	// comma-separated aliases such as "start,begin". Every alias routes to the
+	// same method; it is exposed as MethodDesc.Commands.
+	ExtraCommand = "command"
+
+	// ExtraCallbackQueryPattern is the extra holding a regular expression for
+	// dynamic callback data. It is compiled at generation time and exposed as
+	// MethodDesc.CallbackQueryPattern.
+	ExtraCallbackQueryPattern = "callback_query_pattern"
+
+	// ExtraCallbackData is the extra holding the callback data format of a
+	// method, e.g. "item:{id}", whose placeholders name request fields. It is
+	// compiled into MethodDesc.CallbackDataExpr.
+	ExtraCallbackData = "callback_data"
+
+	// ExtraCallbackFields is the extra naming the request fields packed into
+	// compact callback data, e.g. "id,page". It is compiled into
+	// MethodDesc.CallbackEncoder and MethodDesc.CallbackDecoder.
+	ExtraCallbackFields = "callback_fields"
+
+	// ExtraButtons describes the inline keyboard sent with a method's reply,
+	// a JSON array of rows of buttons, each with a text and either the method
+	// of the service it routes to or static data. It is compiled into
+	// MethodDesc.Keyboard.
+	ExtraButtons = "buttons"
+
+	// ExtraName overrides the name a method's operation constant and
+	// MethodDesc.OriginalName are built from, e.g. "StartMenu" for a proto
+	// method named Start. It must be a Go identifier, unique in the service.
+	ExtraName = "name"
+
+	// ExtraErrorDomain is the extra naming the domain of the sphere error
+	// model a method's errors are mapped into, e.g. "payment". It is exposed as
+	// MethodDesc.ErrorDomain.
+	ExtraErrorDomain = "error_domain"
+
+	// ExtraArgs is the extra naming the request fields a bot command's
+	// arguments bind to, in positional order, e.g. "id,count". It is compiled
+	// into MethodDesc.ArgsBinder.
+	ExtraArgs = "args"
+
+	// ExtraDispatchOneof is the extra naming a oneof of the request, e.g.
+	// "payload", whose set field a generated dispatcher routes on. It is
+	// compiled into MethodDesc.DispatchOneof.
+	ExtraDispatchOneof = "dispatch_oneof"
+
+	// ExtraCron is the extra holding a job's cron schedule. It is validated at
+	// generation time and exposed as MethodDesc.Cron.
+	ExtraCron = "cron"
+
+	// ExtraTopic and ExtraConsumerGroup are the message-queue extras of the mq
+	// template: the topic a consumer subscribes to, required for every method,
+	// and its optional consumer group.
+	ExtraTopic         = "topic"
+	ExtraConsumerGroup = "consumer_group"
+
+	// DispatchMap and DispatchSwitch are the Config.Dispatch values: handlers
+	// registered in a map keyed by operation, or a generated switch.
+	DispatchMap    = "map"
+	DispatchSwitch = "switch"
+
+	// StreamingSkip, StreamingError and StreamingInclude are the
+	// Config.Streaming values: streaming methods with a rule are skipped with
+	// a warning, fail generation, or are passed to a custom template.
+	StreamingSkip    = "skip"
+	StreamingError   = "error"
+	StreamingInclude = "include"
+
+	// ExtraMiddleware names the middleware wrapping a method's handler,
+	// separated by commas and outermost first, e.g. "auth,ratelimit". It is
+	// exposed as MethodDesc.Middleware.
+	ExtraMiddleware = "middleware"
+
+	// ExtraValidate opts a method out of request validation when set to
+	// "false"; see Config.ValidateRequests.
+	ExtraValidate = "validate"
+
+	// ExtraScope is the Telegram BotCommandScope type, e.g. "all_group_chats",
+	// a command is listed under by Config.TelegramCommands; without it the
+	// command is in the default scope.
+	ExtraScope = "scope"
+
+	// ExtraTimeout and ExtraRetry are a route's deadline, a duration such as
+	// "30s", and how many times a failed call is retried. Both are validated at
+	// generation time and exposed as MethodDesc.Timeout and MethodDesc.MaxRetries.
+	ExtraTimeout = "timeout"
+	ExtraRetry   = "retry"
+
+	// ExtraRateLimit is a route's rate limit, a count of requests per period
+	// such as "10/min" or "3/30s". It is validated at generation time and
+	// exposed as MethodDesc.RateLimit.
+	ExtraRateLimit = "rate_limit"
+
+	// ExtraPriority is an integer ordering the methods of a service, highest
+	// first, for routers matching in registration order. It is exposed as
+	// MethodDesc.Priority.
+	ExtraPriority = "priority"
+
+	// ExtraGroup names the route group of a method, e.g. "admin"; the bot
+	// template generates a registration function per group. It is exposed as
+	// MethodDesc.Group.
+	ExtraGroup = "group"
+
+	// ExtraChatType restricts a method to one chat type, private, group or
+	// channel, so a command can be routed to different methods in private
+	// chats, groups and channels. It is exposed as MethodDesc.ChatType.
+	ExtraChatType = "chat_type"
+
+	// ExtraRoles lists the roles authorized to call a method separated by
+	// commas, e.g. "admin,support", and ExtraPermission names the permission
+	// it requires, e.g. "orders.refund". The bot template passes them to an
+	// authorizer; they are exposed as MethodDesc.Roles and
+	// MethodDesc.Permission.
+	ExtraRoles      = "roles"
+	ExtraPermission = "permission"
+
+	// ExtraFeatureFlag names the feature flag gating a method, e.g.
+	// "new_checkout": the bot template routes the method only when a feature
+	// gate enables the flag. It is exposed as MethodDesc.FeatureFlag.
+	ExtraFeatureFlag = "feature_flag"
+
+	// ExtraTags lists the tags of a method separated by commas, e.g.
+	// "experimental,admin", which Config.IncludeTags and Config.ExcludeTags
+	// select methods by. It is exposed as MethodDesc.Tags.
+	ExtraTags = "tags"
+
+	// ExtraEnv lists the environments a method exists in separated by
+	// commas, e.g. "dev,staging", which Config.Env selects methods by: a
+	// debug command tagged "dev" is left out of prod builds. Methods without
+	// it exist in every environment.
+	ExtraEnv = "env"
+
+	// ExtraDeprecated marks a method deprecated like the deprecated method
+	// option: "true" marks it, and any other text but "false" marks it with
+	// that migration notice, e.g. "use /orders instead". It is exposed as
+	// MethodDesc.Deprecated and MethodDesc.DeprecationNotice.
+	ExtraDeprecated = "deprecated"
+
+	// ExtraSkip excludes a method carrying a rule for the options key from
+	// generation when set to "true".
+	ExtraSkip = "skip"
+
 	// Example GoIdent flag values, in "import/path;Ident" format, mirroring the
 	// telegram bot setup documented in the README. They are not real defaults for
 	// main.go (request/response models are required there) but give DefaultConfig
@@ -27,9 +189,254 @@ const (
 // Config holds the user-facing options for the route generator. It is populated
 // from command-line flags in main.go and passed to GenerateFile.
 type Config struct {
-	OptionsKey   string
+	OptionsKey string
+	// OptionsExtension is the full name of the MethodOptions extension the
+	// rules are read from instead of (sphere.options.options), for
+	// organizations declaring options in their own proto package. It must be a
+	// repeated message shaped like sphere.options.KeyValuePair: a string key in
+	// field 1 and a map<string, string> extra in field 5. Its field number is
+	// looked up in the files of the request unless OptionsExtensionNumber is
+	// set, and service rules reuse it on ServiceOptions.
+	OptionsExtension       string
+	OptionsExtensionNumber int32
+	// Template names the built-in template to render; TemplateFile, when set,
+	// overrides it.
+	Template     string
 	TemplateFile string
+	// TemplateDir is a directory of *.tmpl partials parsed with the template;
+	// its route.tmpl, if present, is the entry template unless TemplateFile is
+	// set.
+	TemplateDir string
+	// TemplateSource is template text supplied programmatically by plugins
+	// embedding the generator. It takes precedence over Template and
+	// TemplateFile and has no flag.
+	TemplateSource string
+	// TemplateDelims are the left and right action delimiters of the custom
+	// template, e.g. "<<" and ">>"; empty values select {{ and }}. The built-in
+	// templates use the default and cannot be combined with other delimiters.
+	TemplateDelims [2]string
+	// Manifest selects the route manifest sidecar format ("json" or "yaml"); an
+	// empty value disables the manifest.
+	Manifest string
+	// Docs selects the command reference format ("markdown"); an empty value
+	// disables it.
+	Docs string
+	// I18n selects the i18n resource skeleton format, I18nJSON or I18nTOML,
+	// emitted with Go constants of its keys; an empty value disables it.
+	I18n string
+	// TelegramCommands also emits the setMyCommands payloads of the commands
+	// of every file, one per ExtraScope value.
+	TelegramCommands bool
+	// DiscordCommands also emits the Discord application commands of every
+	// file, with options derived from the request fields.
+	DiscordCommands bool
+	// CallbackSchema also emits the JSON Schema of the request messages of the
+	// methods routed by callbacks, keyed by operation path.
+	CallbackSchema bool
+	// TSOutFile, when set, also emits this TypeScript file, relative to the
+	// output directory, exporting the operation constants, commands, callback
+	// query patterns and callback data builders of every service generated,
+	// so a web client routes like the Go side.
+	TSOutFile string
+	// ExtrasSchema declares the extras allowed per options key; methods of the
+	// keys it lists are checked against it.
+	ExtrasSchema ExtrasSchema
+	// ExtrasOverrides adds or replaces the extras of methods by full name;
+	// see ExtrasOverrides.
+	ExtrasOverrides ExtrasOverrides
+	// UniqueExtras lists the extra keys whose values must be unique across all
+	// methods of an options key; see CheckUniqueExtras.
+	UniqueExtras []string
+	// IncludeTags, when set, generates only the methods whose tags extra
+	// lists one of these tags, and ExcludeTags leaves out the methods whose
+	// tags extra lists one of those, e.g. experimental routes in production
+	// builds. ExcludeTags wins over IncludeTags.
+	IncludeTags []string
+	ExcludeTags []string
+	// Vars holds the values of the ${name} placeholders of extra values, e.g.
+	// a per-tenant command prefix "tenant_" for "${bot_prefix}start". A
+	// placeholder without a variable fails generation.
+	Vars map[string]string
+	// Env, when set, is the environment generated for, e.g. "prod": methods
+	// whose env extra does not list it are left out like excluded tags.
+	Env string
+	// FilePattern names the generated Go file, relative to the proto file's
+	// output directory. It may use the {proto}, {package} and {key}
+	// placeholders; an empty value selects DefaultFilePattern. Without {key},
+	// several options keys are generated into the one file, a region per key
+	// in key order.
+	FilePattern string
+	// Renders, when set, replace the template and FilePattern: every proto
+	// file is rendered with each template file into the files its output
+	// pattern names, e.g. the Go code and a command reference, from one
+	// invocation and so with the same parameters.
+	Renders []Render
+	// Split selects SplitFile or SplitService; an empty value selects
+	// SplitFile. With SplitService every service is generated into a file of
+	// its own, named by FilePattern's {service} placeholder.
+	Split string
+	// RegisterAll also renders the template's aggregate template once per Go
+	// package, e.g. a RegisterAll<Key>Routes helper wiring every service.
+	RegisterAll bool
+	// Dispatch selects the bot template's dispatch code path, DispatchMap or
+	// DispatchSwitch; an empty value selects DispatchMap.
+	Dispatch string
+	// Streaming selects how client or server streaming methods carrying a
+	// rule are handled, StreamingSkip, StreamingError or StreamingInclude; an
+	// empty value selects StreamingSkip. The built-in templates only handle
+	// unary methods, so StreamingInclude requires a custom template.
+	Streaming string
+	// Tracing makes the bot template generate a With<Service><Key>Tracer
+	// registration option taking an OpenTelemetry trace.Tracer. The generated
+	// code only imports OpenTelemetry when it is set.
+	Tracing bool
+	// Metrics makes the bot template generate a With<Service><Key>Metrics
+	// registration option taking a Prometheus registerer, counting requests and
+	// errors and observing durations per operation. The generated code only
+	// imports the Prometheus client when it is set.
+	Metrics bool
+	// GenericCodec makes the bot template generate its codec, handlers and
+	// registration functions generic in the transport request and response
+	// types, TReq and TResp, instead of naming the request and response
+	// models, so that one generated router serves several transports. The
+	// models are then optional; when set, a <Service><Key>DefaultCodec alias
+	// instantiates the codec with them.
+	GenericCodec bool
+	// Generics makes the bot template build its handlers from a generic
+	// adapter typed by the request and reply messages of each method, and the
+	// invoker emit a typed <Service><Key><Method>Route of every method, called
+	// without a type assertion. The generated code then needs Go 1.21.
+	Generics bool
+	// ErrorFormat selects how Run reports errors, ErrorFormatText or
+	// ErrorFormatJSON; an empty value selects ErrorFormatText.
+	ErrorFormat string
+	// StrictKeys turns the warnings about methods of the files to generate
+	// whose rules are all for keys options_key does not select, such as a
+	// misspelt key, into errors.
+	StrictKeys bool
+	// GoPackageOverride, when its ImportPath is set, generates the Go files
+	// into that package instead of the package of the message code, e.g. an
+	// internal/route package of a layered architecture. The files are placed
+	// in the directory of the import path, which the protoc module= parameter
+	// strips like for protoc-gen-go, and import the messages they reference.
+	GoPackageOverride GoPackage
+	// SelfTest also emits <proto>.<key>.routes_test.go, a test checking that
+	// the routes of every service are unique and their extras valid.
+	SelfTest bool
+	// License is text written as line comments at the top of every generated
+	// Go file, e.g. a license banner required by compliance tooling.
+	License string
+	// BuildTags is a build constraint expression, e.g. "linux && !race",
+	// written as a //go:build line into every generated Go file.
+	BuildTags string
+	// HeaderVersion lists Version, the plugin version, in the header of every
+	// generated Go file. Version has no flag; main sets it.
+	HeaderVersion bool
+	Version       string
+	// Descriptors exposes the protogen service and method to templates as
+	// ServiceDesc.Descriptor and MethodDesc.Descriptor.
+	Descriptors bool
+	// RouteInfo also emits, after every service, a Get<Service><Key>RouteInfo
+	// lookup returning the command, callback query, comment and extras of an
+	// operation.
+	RouteInfo bool
+	// ValidateRequests makes the bot and mq templates validate every decoded
+	// request through the codec before calling the server, unless the method
+	// sets the validate extra to false.
+	ValidateRequests bool
+	// Mocks also emits, after every service, a Mock<Server> stub of its server
+	// interface for unit tests.
+	Mocks bool
+	// TypeRegistry also emits, after every service, a map from its operation
+	// paths to the protoreflect types of their request and reply messages.
+	TypeRegistry bool
+	// Invoker also emits, after every service, a <Service><Key>Invoker calling
+	// the method of an operation path with a request message.
+	Invoker bool
+	// CacheDir, when set, caches the files generated for every proto file in
+	// this directory, keyed by a hash of the file's descriptors and their
+	// imports, the template and the configuration, and reuses them instead of
+	// rendering while those inputs are unchanged. Files are generated without
+	// the cache when RegisterAll is set, since the aggregates need every
+	// rendered service.
+	CacheDir string
+	// VersionGuard makes every generated Go file assert at compile time that
+	// the runtime package it is compiled against supports it, by referencing
+	// VersionGuardIdent. An empty VersionGuardIdent names
+	// SupportPackageIsVersion<RuntimeVersion> in the package of RequestType.
+	VersionGuard      bool
+	VersionGuardIdent protogen.GoIdent
+	// Parallel is the number of proto files generated concurrently; 0 selects
+	// GOMAXPROCS. The output does not depend on it: the files and warnings of
+	// every proto file are emitted in the order of the request.
+	Parallel int
+	// Stats, when set, reports a summary of the run with the warnings: the
+	// files processed, the services and methods routed per options key, the
+	// methods with a rule left out and why, and the render time of every
+	// proto file. StatsOut writes it to this file instead, and implies Stats.
+	Stats    bool
+	StatsOut string
+
+	RequestType      protogen.GoIdent
+	ResponseType     protogen.GoIdent
+	ExtraType        protogen.GoIdent
+	ExtraConstructor protogen.GoIdent
+
+	// KeyModels overrides the models above for individual options keys, so
+	// the keys of one invocation can target different runtime packages. It is
+	// applied by ForKey.
+	KeyModels map[string]Models
+
+	// rules is the rule extension Run resolved from OptionsExtension.
+	rules ruleExtension
+	// render is the render of Renders a copy of Config from renderConfigs
+	// generates.
+	render *Render
+	// paths holds the paths and module parameters of the request Run
+	// generates for.
+	paths outputPaths
+	// regions, when set, holds the Go files Run generates several options
+	// keys into.
+	regions *keyRegions
+	// warnings, when set, receives the warnings of generation instead of the
+	// package's warnings, so that files generated concurrently do not
+	// interleave theirs.
+	warnings io.Writer
+	// checkOnly, set by Check and Flags.CheckConfig, builds the services
+	// without rendering them, so the models of the template are not required.
+	checkOnly bool
+}
+
+// RuntimeVersion is the version of the runtime package API the built-in
+// templates generate code against: with Config.VersionGuard the generated
+// files reference SupportPackageIsVersion<RuntimeVersion> of the runtime
+// package, which fails to compile against a runtime package too old for them.
+// It is bumped when the generated code starts depending on a newer runtime API.
+const RuntimeVersion = 1
 
+// versionGuard returns the identifier the version guard of c references, or
+// a zero GoIdent when VersionGuard is not set.
+func (c *Config) versionGuard() protogen.GoIdent {
+	switch {
+	case !c.VersionGuard:
+		return protogen.GoIdent{}
+	case c.VersionGuardIdent.GoName != "":
+		return c.VersionGuardIdent
+	}
+	return c.RequestType.GoImportPath.Ident(fmt.Sprintf("SupportPackageIsVersion%d", RuntimeVersion))
+}
+
+// warningsWriter returns where the warnings of generating with c go.
+func (c *Config) warningsWriter() io.Writer {
+	if c.warnings != nil {
+		return c.warnings
+	}
+	return warnings
+}
+
+// Models are the runtime types the generated code is written against. Empty
+// idents in a KeyModels entry keep the Config-wide value.
+type Models struct {
 	RequestType      protogen.GoIdent
 	ResponseType     protogen.GoIdent
 	ExtraType        protogen.GoIdent
@@ -37,15 +444,366 @@ type Config struct {
 }
 
 // genConfig holds the per-file generation state derived from Config. It is
-// internal to the package and scoped to a single generated file.
+// internal to the package and scoped to a single generated file. The fields
+// after services are copies of the Config fields of the same name.
 type genConfig struct {
 	optionsKey  string
+	rules       ruleExtension
 	packageDesc *template.PackageDesc
-	// methodSets tracks the per-file duplicate count for each method GoName so
-	// MethodDesc.Num stays deterministic. It is scoped to a single generated file
-	// (created in generateFileContent) instead of a package global, which keeps
-	// output stable across files in a single protoc invocation and across tests.
-	methodSets map[string]int
+	generator   *template.Generator
+	// methodNums holds MethodDesc.Num and MethodDesc.UniqueName of every
+	// method of the proto file, see methodNums.
+	methodNums map[*protogen.Method]methodNum
+	// requiredExtras lists the extras every generated method must carry, as
+	// demanded by the selected template.
+	requiredExtras []string
+	// unsupportedExtras holds the extras the selected template cannot honor,
+	// see Config.unsupportedExtras.
+	unsupportedExtras map[string]string
+	// text is set when the file is not a Go file: the rendered services are
+	// written as is, without formatting and the Go additions.
+	text bool
+	// warnings receives the warnings of the file, see Config.warningsWriter.
+	warnings io.Writer
+	// extrasSchema holds the rules of Config.ExtrasSchema for optionsKey; nil
+	// when the schema does not list the key.
+	extrasSchema map[string]*ExtraRule
+	// tags holds Config.IncludeTags, Config.ExcludeTags and Config.Env.
+	tags tagFilter
+	// services collects the rendered services, in file order, for the sidecar
+	// artifacts produced after the Go file.
+	services []*template.ServiceDesc
+
+	descriptors      bool
+	validateRequests bool
+	typeRegistry     bool
+	invoker          bool
+	routeInfo        bool
+	mocks            bool
+	streaming        string
+	extrasOverrides  ExtrasOverrides
+	vars             map[string]string
+	checkOnly        bool
+}
+
+// DataTemplate is the built-in template selected by the data_only parameter:
+// a route table per service, without handler wiring.
+const DataTemplate = "data"
+
+// modelFreeTemplates lists the built-in templates that do not reference the
+// request and response models.
+var modelFreeTemplates = map[string]bool{
+	DataTemplate: true,
+	"job":        true,
+	"minimal":    true,
+}
+
+// templateRequiredExtras lists, per built-in template, the extras every method
+// routed through that template must carry.
+var templateRequiredExtras = map[string][]string{
+	"mq": {ExtraTopic},
+}
+
+// requiredExtras returns the extras the selected template requires. Custom
+// templates require none.
+func (c *Config) requiredExtras() []string {
+	if c.customTemplate() {
+		return nil
+	}
+	return templateRequiredExtras[c.Template]
+}
+
+// switchUnsupportedExtras lists the extras the bot template cannot honor with
+// dispatch=switch, with the registration it would need.
+var switchUnsupportedExtras = map[string]string{
+	ExtraGroup:      "group registration functions",
+	ExtraMiddleware: "Register<Server>WithMiddleware",
+}
+
+// unsupportedExtras returns the extras the selected template cannot honor,
+// mapped to the registration it does not generate. Custom templates honor
+// every extra.
+func (c *Config) unsupportedExtras() map[string]string {
+	if c.customTemplate() || c.Template != "" && c.Template != "bot" || c.Dispatch != DispatchSwitch {
+		return nil
+	}
+	return switchUnsupportedExtras
+}
+
+// Validate reports options that the selected template cannot do without. The
+// request and response models are required unless a template file is used or
+// the built-in template does not reference them.
+func (c *Config) Validate() error {
+	switch c.Manifest {
+	case "", ManifestJSON, ManifestYAML:
+	default:
+		return fmt.Errorf("invalid manifest format %q, expected %q or %q", c.Manifest, ManifestJSON, ManifestYAML)
+	}
+	if name := path.Clean(c.TSOutFile); c.TSOutFile != "" && (path.Ext(name) != ".ts" || path.IsAbs(name) || strings.HasPrefix(name, "../")) {
+		return fmt.Errorf("invalid ts_out_file %q, expected a .ts file below the output directory", c.TSOutFile)
+	}
+	if c.Docs != "" && c.Docs != DocsMarkdown {
+		return fmt.Errorf("invalid docs format %q, expected %q", c.Docs, DocsMarkdown)
+	}
+	switch c.I18n {
+	case "", I18nJSON, I18nTOML:
+	default:
+		return fmt.Errorf("invalid i18n format %q, expected %q or %q", c.I18n, I18nJSON, I18nTOML)
+	}
+	switch c.ErrorFormat {
+	case "", ErrorFormatText, ErrorFormatJSON:
+	default:
+		return fmt.Errorf("invalid error_format %q, expected %q or %q", c.ErrorFormat, ErrorFormatText, ErrorFormatJSON)
+	}
+	for _, tag := range c.IncludeTags {
+		if slices.Contains(c.ExcludeTags, tag) {
+			return fmt.Errorf("tag %q is both included and excluded", tag)
+		}
+	}
+	for name := range c.Vars {
+		if !varNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid var %q: a name holds only letters, digits and '_' and does not start with a digit", name)
+		}
+	}
+	if c.Env != "" && !groupPattern.MatchString(c.Env) {
+		return fmt.Errorf("invalid env %q: must start with a letter and hold only letters, digits, '_' or '-'", c.Env)
+	}
+	switch c.Dispatch {
+	case "", DispatchMap, DispatchSwitch:
+	default:
+		return fmt.Errorf("invalid dispatch %q, expected %q or %q", c.Dispatch, DispatchMap, DispatchSwitch)
+	}
+	switch c.Streaming {
+	case "", StreamingSkip, StreamingError:
+	case StreamingInclude:
+		if !c.customTemplate() {
+			return fmt.Errorf("streaming=%s requires a custom template, the built-in templates only handle unary methods", StreamingInclude)
+		}
+		if c.Mocks {
+			return fmt.Errorf("mocks cannot stub streaming methods included by streaming=%s", StreamingInclude)
+		}
+	default:
+		return fmt.Errorf("invalid streaming %q, expected %q, %q or %q", c.Streaming, StreamingSkip, StreamingError, StreamingInclude)
+	}
+	if !c.customTemplate() && (c.Template != "" && c.Template != "bot" || c.Dispatch == DispatchSwitch) {
+		if c.Tracing {
+			return fmt.Errorf("tracing requires the bot template with dispatch=%s, whose registration options take the tracer", DispatchMap)
+		}
+		if c.Metrics {
+			return fmt.Errorf("metrics requires the bot template with dispatch=%s, whose registration options take the registerer", DispatchMap)
+		}
+	}
+	if c.GenericCodec && !c.customTemplate() && c.Template != "" && c.Template != "bot" {
+		return fmt.Errorf("generic_codec requires the bot template, not %s", c.Template)
+	}
+	if c.Generics && !c.customTemplate() && c.Template != "" && c.Template != "bot" && !c.Invoker {
+		return fmt.Errorf("generics requires the bot template or invoker, the %s template has no handler adapters", c.Template)
+	}
+	if c.TemplateDelims != [2]string{} && !c.customTemplate() {
+		return fmt.Errorf("template_delims requires a custom template, the built-in templates use {{ and }}")
+	}
+	if c.Mocks && c.Template == DataTemplate && !c.customTemplate() {
+		return fmt.Errorf("mocks cannot stub the server interfaces, which the %s template does not generate", DataTemplate)
+	}
+	if err := validateFilePattern(c.FilePattern); err != nil {
+		return err
+	}
+	if c.BuildTags != "" {
+		if _, err := constraint.Parse("//go:build " + c.BuildTags); err != nil {
+			return fmt.Errorf("invalid build_tags %q: %v", c.BuildTags, err)
+		}
+	}
+	switch c.Split {
+	case "", SplitFile:
+		if strings.Contains(c.FilePattern, "{service}") {
+			return fmt.Errorf("file_pattern %q may only contain {service} with split=%s", c.FilePattern, SplitService)
+		}
+	case SplitService:
+		if c.FilePattern != "" && !strings.Contains(c.FilePattern, "{service}") {
+			return fmt.Errorf("file_pattern %q must contain {service} with split=%s", c.FilePattern, SplitService)
+		}
+	default:
+		return fmt.Errorf("invalid split %q, expected %q or %q", c.Split, SplitFile, SplitService)
+	}
+	if len(c.Renders) > 0 && (c.TemplateFile != "" || c.TemplateSource != "" || c.FilePattern != "") {
+		return errors.New("render replaces template_file and file_pattern, which cannot be set with it")
+	}
+	outputs := make(map[string]bool, len(c.Renders))
+	for _, r := range c.Renders {
+		if err := r.validate(c.Split); err != nil {
+			return err
+		}
+		if outputs[r.filePattern()] {
+			return fmt.Errorf("render %s: output %q is rendered twice", r, r.Output)
+		}
+		outputs[r.filePattern()] = true
+	}
+	if c.VersionGuard && c.VersionGuardIdent.GoName == "" && c.RequestType.GoImportPath == "" {
+		keys, _ := ParseOptionsKeys(c.OptionsKey)
+		if len(c.KeyModels) == 0 || slices.ContainsFunc(keys, func(key string) bool { return c.ForKey(key).RequestType.GoImportPath == "" }) {
+			return errors.New("version_guard needs a request_model naming the runtime package, or version_guard_ident")
+		}
+	}
+	if c.ExtraType.GoName != "" && c.ExtraConstructor.GoName == "" {
+		return errors.New("extra_data_constructor is required with extra_data_model")
+	}
+	if len(c.KeyModels) > 0 {
+		return c.validateKeyModels()
+	}
+	if c.modelFree() || c.checkOnly {
+		return nil
+	}
+	if c.RequestType.GoName == "" {
+		return errors.New("request_model is required")
+	}
+	if c.ResponseType.GoName == "" {
+		return errors.New("response_model is required")
+	}
+	return nil
+}
+
+// fileHeader returns the header of a generated Go file with the header options
+// of c applied.
+func (c *Config) fileHeader(protocVersion, source, pkgName string) fileHeader {
+	header := fileHeader{
+		ProtocVersion: protocVersion,
+		Source:        source,
+		Package:       pkgName,
+		License:       c.License,
+		BuildTags:     c.BuildTags,
+	}
+	if c.HeaderVersion {
+		header.PluginVersion = c.Version
+		if header.PluginVersion == "" {
+			header.PluginVersion = "(unknown)"
+		}
+	}
+	return header
+}
+
+// modelFree reports whether the selected template can do without the request
+// and response models.
+func (c *Config) modelFree() bool {
+	return c.customTemplate() || modelFreeTemplates[c.Template] || c.GenericCodec
+}
+
+// customTemplate reports whether a template file, directory or text replaces
+// the built-in template.
+func (c *Config) customTemplate() bool {
+	return c.TemplateFile != "" || c.TemplateDir != "" || c.TemplateSource != "" || len(c.Renders) > 0
+}
+
+// validateKeyModels checks that every KeyModels entry names a key of
+// OptionsKey and that every key ends up with the models the template needs.
+func (c *Config) validateKeyModels() error {
+	keys, err := ParseOptionsKeys(c.OptionsKey)
+	if err != nil {
+		return err
+	}
+	for key, models := range c.KeyModels {
+		if !matchOptionsKey(keys, key) {
+			return fmt.Errorf("models set for options key %q, which is not in options_key %q", key, c.OptionsKey)
+		}
+		if models.ExtraType.GoName != "" && models.ExtraConstructor.GoName == "" {
+			return fmt.Errorf("%s.extra_data_constructor is required with %s.extra_data_model", key, key)
+		}
+	}
+	if c.modelFree() || c.checkOnly {
+		return nil
+	}
+	for _, key := range keys {
+		keyConf := c.ForKey(key)
+		if keyConf.RequestType.GoName == "" {
+			return fmt.Errorf("request_model or %s.request_model is required", key)
+		}
+		if keyConf.ResponseType.GoName == "" {
+			return fmt.Errorf("response_model or %s.response_model is required", key)
+		}
+	}
+	return nil
+}
+
+// ForKey returns a shallow copy of c targeting the given options key, with the
+// key's KeyModels entry applied. main.go uses it to derive one Config per key
+// when options_key lists several keys.
+func (c *Config) ForKey(key string) *Config {
+	keyConf := *c
+	keyConf.OptionsKey = key
+	if models, ok := c.KeyModels[key]; ok {
+		override := func(dst *protogen.GoIdent, id protogen.GoIdent) {
+			if id.GoName != "" {
+				*dst = id
+			}
+		}
+		override(&keyConf.RequestType, models.RequestType)
+		override(&keyConf.ResponseType, models.ResponseType)
+		override(&keyConf.ExtraType, models.ExtraType)
+		override(&keyConf.ExtraConstructor, models.ExtraConstructor)
+	}
+	return &keyConf
+}
+
+// ParseList splits a ';'-separated parameter value such as
+// "command;callback_query" into its trimmed, non-empty elements.
+func ParseList(raw string) []string {
+	var list []string
+	for _, item := range strings.Split(raw, ";") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// ParseOptionsKeys splits an options_key value such as "bot;job" into its keys,
+// preserving their order. Surrounding whitespace is trimmed; empty and
+// duplicate keys are rejected since each key maps to one generated file. A
+// key may be a path.Match pattern such as "*" or "bot_*", see
+// IsOptionsKeyPattern; Run expands it to the keys found in the files.
+func ParseOptionsKeys(raw string) ([]string, error) {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, key := range strings.Split(raw, ";") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return nil, fmt.Errorf("invalid options key list %q: empty key", raw)
+		}
+		if seen[key] {
+			return nil, fmt.Errorf("invalid options key list %q: duplicate key %q", raw, key)
+		}
+		if _, err := path.Match(key, ""); err != nil {
+			return nil, fmt.Errorf("invalid options key pattern %q: %v", key, err)
+		}
+		seen[key] = true
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// IsOptionsKeyPattern reports whether an options_key element is a pattern
+// matching keys rather than a key.
+func IsOptionsKeyPattern(key string) bool {
+	return strings.ContainsAny(key, `*?[\`)
+}
+
+// matchOptionsKey reports whether key is one of keys or matched by one of
+// their patterns.
+func matchOptionsKey(keys []string, key string) bool {
+	return slices.ContainsFunc(keys, func(k string) bool {
+		matched, _ := path.Match(k, key)
+		return matched
+	})
+}
+
+// ParseTemplateDelims parses a template_delims value such as "<<,>>" into the
+// left and right delimiters.
+func ParseTemplateDelims(raw string) ([2]string, error) {
+	left, right, ok := strings.Cut(raw, ",")
+	left, right = strings.TrimSpace(left), strings.TrimSpace(right)
+	if !ok || left == "" || right == "" || strings.Contains(right, ",") {
+		return [2]string{}, fmt.Errorf("invalid template_delims %q, expected 'left,right' such as '<<,>>'", raw)
+	}
+	return [2]string{left, right}, nil
 }
 
 // ParseGoIdent parses a "import/path;Ident" string into a protogen.GoIdent.
@@ -60,6 +818,54 @@ func ParseGoIdent(raw string) (protogen.GoIdent, error) {
 	}, nil
 }
 
+// GoPackage is a Go package: its import path and package name.
+type GoPackage struct {
+	ImportPath protogen.GoImportPath
+	Name       protogen.GoPackageName
+}
+
+// ParseGoPackage parses a package in go_package option format,
+// "import/path;name" or "import/path". Without a name the last element of the
+// import path is used, with characters not allowed in identifiers replaced by
+// underscores.
+func ParseGoPackage(raw string) (GoPackage, error) {
+	importPath, name, hasName := strings.Cut(raw, ";")
+	if importPath == "" {
+		return GoPackage{}, fmt.Errorf("invalid Go package %q, expected 'import/path;name' or 'import/path'", raw)
+	}
+	if !hasName {
+		name = sanitizePackageName(path.Base(importPath))
+	}
+	if !token.IsIdentifier(name) {
+		return GoPackage{}, fmt.Errorf("invalid Go package %q: %q is not a valid package name", raw, name)
+	}
+	return GoPackage{ImportPath: protogen.GoImportPath(importPath), Name: protogen.GoPackageName(name)}, nil
+}
+
+// sanitizePackageName turns a path element such as "route-v2" into a package
+// name, "route_v2".
+func sanitizePackageName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, name)
+	if r, _ := utf8.DecodeRuneInString(name); unicode.IsDigit(r) {
+		name = "_" + name
+	}
+	return name
+}
+
+// goPackage returns the package the Go files generated for file belong to,
+// and the prefix of their filenames, honoring GoPackageOverride.
+func (c *Config) goPackage(file *protogen.File) (GoPackage, string) {
+	if c.GoPackageOverride.ImportPath == "" {
+		return GoPackage{ImportPath: file.GoImportPath, Name: file.GoPackageName}, file.GeneratedFilenamePrefix
+	}
+	return c.GoPackageOverride, c.overridePrefix(file)
+}
+
 // DefaultConfig returns a Config populated with representative example values
 // (the telegram bot setup from the README). main.go builds its Config from
 // required flags instead; DefaultConfig exists so tests produce golden output
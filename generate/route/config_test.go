@@ -0,0 +1,216 @@
+package route
+
+import (
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestParseOptionsKeys(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    []string
+		wantErr bool
+	}{
+		{"single", "bot", []string{"bot"}, false},
+		{"multiple", "bot;job", []string{"bot", "job"}, false},
+		{"whitespace trimmed", " bot ; job ", []string{"bot", "job"}, false},
+		{"empty", "", nil, true},
+		{"empty element", "bot;;job", nil, true},
+		{"duplicate", "bot;job;bot", nil, true},
+		{"pattern", "bot;job_*", []string{"bot", "job_*"}, false},
+		{"invalid pattern", "bot_[", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOptionsKeys(tt.raw)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOptionsKeys(%q) error = %v, wantErr %v", tt.raw, err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOptionsKeys(%q) = %v, want %v", tt.raw, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigForKey(t *testing.T) {
+	conf := DefaultConfig()
+	keyConf := conf.ForKey("bot")
+	if keyConf.OptionsKey != "bot" {
+		t.Errorf("ForKey().OptionsKey = %q, want %q", keyConf.OptionsKey, "bot")
+	}
+	if conf.OptionsKey != DefaultOptionsKey {
+		t.Errorf("ForKey mutated the receiver: OptionsKey = %q", conf.OptionsKey)
+	}
+	if keyConf.RequestType != conf.RequestType {
+		t.Errorf("ForKey().RequestType = %v, want %v", keyConf.RequestType, conf.RequestType)
+	}
+
+	event := protogen.GoIdent{GoName: "Event", GoImportPath: "example.com/chat"}
+	conf.KeyModels = map[string]Models{"bot": {RequestType: event}}
+	keyConf = conf.ForKey("bot")
+	if keyConf.RequestType != event || keyConf.ResponseType != conf.ResponseType {
+		t.Errorf("ForKey(bot) models = %v, %v, want %v, %v", keyConf.RequestType, keyConf.ResponseType, event, conf.ResponseType)
+	}
+	if got := conf.ForKey("job").RequestType; got != conf.RequestType {
+		t.Errorf("ForKey(job).RequestType = %v, want %v", got, conf.RequestType)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	noModels := func(c *Config) *Config {
+		c.RequestType = protogen.GoIdent{}
+		c.ResponseType = protogen.GoIdent{}
+		return c
+	}
+	keyModels := func(c *Config, optionsKey string, models map[string]Models) *Config {
+		c.OptionsKey = optionsKey
+		c.KeyModels = models
+		return c
+	}
+	tracing := func(c *Config) *Config {
+		c.Tracing = true
+		return c
+	}
+	metrics := func(c *Config) *Config {
+		c.Metrics = true
+		return c
+	}
+	genericCodec := func(c *Config) *Config {
+		c.GenericCodec = true
+		return c
+	}
+	chat := Models{
+		RequestType:  protogen.GoIdent{GoName: "Event", GoImportPath: "example.com/chat"},
+		ResponseType: protogen.GoIdent{GoName: "Reply", GoImportPath: "example.com/chat"},
+	}
+	tests := []struct {
+		name    string
+		conf    *Config
+		wantErr bool
+	}{
+		{"default config", DefaultConfig(), false},
+		{"bot without models", noModels(DefaultConfig()), true},
+		{"job without models", noModels(&Config{Template: "job"}), false},
+		{"minimal without models", noModels(&Config{Template: "minimal"}), false},
+		{"mq without models", noModels(&Config{Template: "mq"}), true},
+		{"template delims", &Config{TemplateSource: "<< .ServiceType >>", TemplateDelims: [2]string{"<<", ">>"}}, false},
+		{"template delims with a built-in template", &Config{Template: "job", TemplateDelims: [2]string{"<<", ">>"}}, true},
+		{"tracing", tracing(DefaultConfig()), false},
+		{"tracing with a template file", tracing(&Config{TemplateFile: "route.tmpl"}), false},
+		{"tracing with the job template", tracing(&Config{Template: "job"}), true},
+		{"tracing with switch dispatch", tracing(&Config{Template: "bot", Dispatch: DispatchSwitch, RequestType: chat.RequestType, ResponseType: chat.ResponseType}), true},
+		{"metrics", metrics(DefaultConfig()), false},
+		{"metrics with the mq template", metrics(&Config{Template: "mq", RequestType: chat.RequestType, ResponseType: chat.ResponseType}), true},
+		{"generic codec", genericCodec(DefaultConfig()), false},
+		{"generic codec without models", genericCodec(noModels(DefaultConfig())), false},
+		{"generics", &Config{Template: "bot", Generics: true, RequestType: chat.RequestType, ResponseType: chat.ResponseType}, false},
+		{"generics with the job template", &Config{Template: "job", Generics: true}, true},
+		{"generics with the job template and invoker", &Config{Template: "job", Generics: true, Invoker: true}, false},
+		{"generic codec with the mq template", genericCodec(&Config{Template: "mq", RequestType: chat.RequestType, ResponseType: chat.ResponseType}), true},
+		{"data without models", noModels(&Config{Template: DataTemplate}), false},
+		{"data with mocks", &Config{Template: DataTemplate, Mocks: true}, true},
+		{"template file without models", noModels(&Config{TemplateFile: "custom.tmpl"}), false},
+		{"json manifest", &Config{Template: "job", Manifest: ManifestJSON}, false},
+		{"unknown manifest", &Config{Template: "job", Manifest: "xml"}, true},
+		{"markdown docs", &Config{Template: "job", Docs: DocsMarkdown}, false},
+		{"unknown docs", &Config{Template: "job", Docs: "html"}, true},
+		{"renders", &Config{Renders: []Render{{TemplateFile: "route.tmpl", Output: "%s.pb.go"}, {TemplateFile: "docs.tmpl", Output: "%s.md"}}}, false},
+		{"renders with template file", &Config{TemplateFile: "route.tmpl", Renders: []Render{{TemplateFile: "docs.tmpl", Output: "%s.md"}}}, true},
+		{"renders twice into an output", &Config{Renders: []Render{{TemplateFile: "a.tmpl", Output: "%s.md"}, {TemplateFile: "b.tmpl", Output: "{proto}.md"}}}, true},
+		{"render output with a directory", &Config{Renders: []Render{{TemplateFile: "a.tmpl", Output: "docs/%s.md"}}}, true},
+		{"render output without service", &Config{Split: SplitService, Renders: []Render{{TemplateFile: "a.tmpl", Output: "%s.md"}}}, true},
+		{"ts out file", &Config{Template: "job", TSOutFile: "web/src/routes.ts"}, false},
+		{"ts out file not typescript", &Config{Template: "job", TSOutFile: "web/src/routes.js"}, true},
+		{"ts out file outside output", &Config{Template: "job", TSOutFile: "../web/routes.ts"}, true},
+		{"file pattern", &Config{Template: "job", FilePattern: "{proto}_{key}.route.go"}, false},
+		{"unknown file pattern placeholder", &Config{Template: "job", FilePattern: "{file}.go"}, true},
+		{"toml i18n", &Config{Template: "job", I18n: I18nTOML}, false},
+		{"unknown i18n", &Config{Template: "job", I18n: "po"}, true},
+		{"json errors", &Config{Template: "job", ErrorFormat: ErrorFormatJSON}, false},
+		{"unknown error format", &Config{Template: "job", ErrorFormat: "xml"}, true},
+		{"switch dispatch", &Config{Template: "job", Dispatch: DispatchSwitch}, false},
+		{"unknown dispatch", &Config{Template: "job", Dispatch: "table"}, true},
+		{"split by service", &Config{Template: "job", Split: SplitService}, false},
+		{"split by service with pattern", &Config{Template: "job", Split: SplitService, FilePattern: "{proto}.{service}.go"}, false},
+		{"split by service without placeholder", &Config{Template: "job", Split: SplitService, FilePattern: "{proto}.go"}, true},
+		{"service placeholder without split", &Config{Template: "job", FilePattern: "{service}.go"}, true},
+		{"unknown split", &Config{Template: "job", Split: "method"}, true},
+		{"streaming error", &Config{Template: "job", Streaming: StreamingError}, false},
+		{"streaming include with a template file", &Config{TemplateFile: "route.tmpl", Streaming: StreamingInclude}, false},
+		{"streaming include with a built-in template", &Config{Template: "job", Streaming: StreamingInclude}, true},
+		{"streaming include with mocks", &Config{TemplateFile: "route.tmpl", Streaming: StreamingInclude, Mocks: true}, true},
+		{"unknown streaming", &Config{Template: "job", Streaming: "push"}, true},
+		{"build tags", &Config{Template: "job", BuildTags: "linux && !race"}, false},
+		{"invalid build tags", &Config{Template: "job", BuildTags: "linux &&"}, true},
+		{"models for every key", keyModels(noModels(DefaultConfig()), "bot;chat", map[string]Models{"bot": chat, "chat": chat}), false},
+		{"key without models", keyModels(noModels(DefaultConfig()), "bot;chat", map[string]Models{"chat": chat}), true},
+		{"key models override shared models", keyModels(DefaultConfig(), "bot;chat", map[string]Models{"chat": chat}), false},
+		{"models for a key matching a pattern", keyModels(DefaultConfig(), "bot;chat_*", map[string]Models{"chat_admin": chat}), false},
+		{"models for an unknown key", keyModels(DefaultConfig(), "bot", map[string]Models{"chat": chat}), true},
+		{"extra model without constructor", &Config{Template: "job", ExtraType: chat.RequestType}, true},
+		{"key extra model without constructor", keyModels(DefaultConfig(), "bot", map[string]Models{"bot": {ExtraType: chat.RequestType}}), true},
+		{"included and excluded tags", &Config{Template: "job", IncludeTags: []string{"admin"}, ExcludeTags: []string{"beta"}}, false},
+		{"tag both included and excluded", &Config{Template: "job", IncludeTags: []string{"admin", "beta"}, ExcludeTags: []string{"beta"}}, true},
+		{"vars", &Config{Template: "job", Vars: map[string]string{"bot_prefix": "acme_"}}, false},
+		{"invalid var", &Config{Template: "job", Vars: map[string]string{"bot-prefix": "acme_"}}, true},
+		{"env", &Config{Template: "job", Env: "prod"}, false},
+		{"invalid env", &Config{Template: "job", Env: "prod build"}, true},
+		{"version guard", &Config{Template: "job", VersionGuard: true, RequestType: chat.RequestType}, false},
+		{"version guard without a runtime package", &Config{Template: "job", VersionGuard: true}, true},
+		{"version guard with an identifier", &Config{Template: "job", VersionGuard: true, VersionGuardIdent: chat.RequestType}, false},
+		{"version guard with models for every key", keyModels(&Config{Template: "job", VersionGuard: true}, "bot;chat", map[string]Models{"bot": chat, "chat": chat}), false},
+		{"version guard without models for a key", keyModels(&Config{Template: "job", VersionGuard: true}, "bot;chat", map[string]Models{"chat": chat}), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.conf.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseList(t *testing.T) {
+	if got := ParseList(" command ;; callback_query;"); !reflect.DeepEqual(got, []string{"command", "callback_query"}) {
+		t.Errorf("ParseList() = %v", got)
+	}
+	if got := ParseList(""); got != nil {
+		t.Errorf("ParseList(\"\") = %v, want nil", got)
+	}
+}
+
+func TestParseTemplateDelims(t *testing.T) {
+	if got, err := ParseTemplateDelims(" << , >> "); err != nil || got != [2]string{"<<", ">>"} {
+		t.Errorf("ParseTemplateDelims() = %v, %v", got, err)
+	}
+	for _, raw := range []string{"<<", "<<,", ",>>", "<<,>>,>>"} {
+		if _, err := ParseTemplateDelims(raw); err == nil {
+			t.Errorf("ParseTemplateDelims(%q) = nil error, want an error", raw)
+		}
+	}
+}
+
+func TestParseGoPackage(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    GoPackage
+		wantErr bool
+	}{
+		{"example.com/app/internal/route;botroute", GoPackage{"example.com/app/internal/route", "botroute"}, false},
+		{"example.com/app/internal/route", GoPackage{"example.com/app/internal/route", "route"}, false},
+		{"example.com/app/route-v2", GoPackage{"example.com/app/route-v2", "route_v2"}, false},
+		{"example.com/app/2fa", GoPackage{"example.com/app/2fa", "_2fa"}, false},
+		{"example.com/app;bot-route", GoPackage{}, true},
+		{";route", GoPackage{}, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseGoPackage(tt.raw)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseGoPackage(%q) = %v, %v, want %v, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
@@ -0,0 +1,121 @@
+package route
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField describes the accepted range and names of one cron field.
+type cronField struct {
+	name     string
+	min, max int
+	names    []string // names[i] stands for min+i, e.g. JAN for 1
+	anyDay   bool     // accepts "?" as "no specific value"
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31, anyDay: true},
+	{name: "month", min: 1, max: 12, names: []string{"JAN", "FEB", "MAR", "APR", "MAY", "JUN", "JUL", "AUG", "SEP", "OCT", "NOV", "DEC"}},
+	{name: "day of week", min: 0, max: 6, names: []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}, anyDay: true},
+}
+
+var cronDescriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// validateCron checks a cron schedule: five space-separated fields (minute,
+// hour, day of month, month, day of week), a descriptor such as "@daily", or
+// "@every <duration>". Fields accept "*", "?" for the day fields, numbers,
+// month and weekday names, ranges ("1-5"), steps ("*/15", "0-30/5") and
+// comma-separated lists.
+func validateCron(spec string) error {
+	spec = strings.TrimSpace(spec)
+	if strings.HasPrefix(spec, "@") {
+		if every, ok := strings.CutPrefix(spec, "@every "); ok {
+			d, err := time.ParseDuration(strings.TrimSpace(every))
+			if err != nil || d <= 0 {
+				return fmt.Errorf("invalid @every duration %q", every)
+			}
+			return nil
+		}
+		if !cronDescriptors[spec] {
+			return fmt.Errorf("unknown descriptor %q", spec)
+		}
+		return nil
+	}
+	fields := strings.Fields(spec)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, got %d", len(cronFields), len(fields))
+	}
+	for i, field := range fields {
+		if err := cronFields[i].validate(field); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f cronField) validate(field string) error {
+	for _, item := range strings.Split(field, ",") {
+		if err := f.validateItem(item); err != nil {
+			return fmt.Errorf("%s %q: %v", f.name, field, err)
+		}
+	}
+	return nil
+}
+
+func (f cronField) validateItem(item string) error {
+	rangePart, step, hasStep := strings.Cut(item, "/")
+	if hasStep {
+		n, err := strconv.Atoi(step)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid step %q", step)
+		}
+	}
+	if rangePart == "*" || (rangePart == "?" && f.anyDay) {
+		return nil
+	}
+	low, high, isRange := strings.Cut(rangePart, "-")
+	lo, err := f.value(low)
+	if err != nil {
+		return err
+	}
+	if !isRange {
+		return nil
+	}
+	hi, err := f.value(high)
+	if err != nil {
+		return err
+	}
+	if lo > hi {
+		return fmt.Errorf("range %q is reversed", rangePart)
+	}
+	return nil
+}
+
+// value parses a number or name within the field's range.
+func (f cronField) value(s string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(s, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", n, f.min, f.max)
+	}
+	return n, nil
+}
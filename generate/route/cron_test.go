@@ -0,0 +1,42 @@
+package route
+
+import "testing"
+
+func TestValidateCron(t *testing.T) {
+	valid := []string{
+		"0 * * * *",
+		"0 9 * * *",
+		"*/15 0-6 1,15 * MON-FRI",
+		"30 2 ? jan,jul sun",
+		"0-30/5 * * * *",
+		"@daily",
+		"@every 1h30m",
+	}
+	for _, spec := range valid {
+		if err := validateCron(spec); err != nil {
+			t.Errorf("validateCron(%q) = %v, want nil", spec, err)
+		}
+	}
+	invalid := []string{
+		"",
+		"* * * *",
+		"0 0 0 * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 7",
+		"? * * * *",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"a * * * *",
+		"@sometimes",
+		"@every soon",
+		"@every -1m",
+	}
+	for _, spec := range invalid {
+		if err := validateCron(spec); err == nil {
+			t.Errorf("validateCron(%q) = nil, want an error", spec)
+		}
+	}
+}
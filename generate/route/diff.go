@@ -0,0 +1,136 @@
+package route
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ANSI escapes of DiffOutputs' colored output.
+const (
+	ansiRed   = "\x1b[31m"
+	ansiGreen = "\x1b[32m"
+	ansiBold  = "\x1b[1m"
+	ansiReset = "\x1b[0m"
+)
+
+// diffContext is the number of unchanged lines DiffOutputs shows around a
+// change.
+const diffContext = 2
+
+// DiffOutputs describes how the generated files next differ from prev, both
+// keyed by file name: a header per added, removed or changed file followed by
+// its changed lines, prefixed with + and -, and a few unchanged lines around
+// them. With color the added lines are green, the removed ones red and the
+// headers bold. Unchanged files are left out, so no change yields "".
+func DiffOutputs(prev, next map[string]string, color bool) string {
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + ansiReset
+	}
+	names := sortedKeys(next)
+	for _, name := range sortedKeys(prev) {
+		if _, ok := next[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	var b strings.Builder
+	for _, name := range sortedKeys(setOf(names)) {
+		before, hadBefore := prev[name]
+		after, hasAfter := next[name]
+		switch {
+		case !hadBefore:
+			fmt.Fprintln(&b, paint(ansiBold, "added "+name))
+			continue
+		case !hasAfter:
+			fmt.Fprintln(&b, paint(ansiBold, "removed "+name))
+			continue
+		case before == after:
+			continue
+		}
+		fmt.Fprintln(&b, paint(ansiBold, "changed "+name))
+		for _, line := range diffLines(splitLines(before), splitLines(after)) {
+			switch line[0] {
+			case '+':
+				fmt.Fprintln(&b, paint(ansiGreen, line))
+			case '-':
+				fmt.Fprintln(&b, paint(ansiRed, line))
+			default:
+				fmt.Fprintln(&b, line)
+			}
+		}
+	}
+	return b.String()
+}
+
+// setOf returns names as a set.
+func setOf(names []string) map[string]bool {
+	set := make(map[string]bool, len(names))
+	for _, name := range names {
+		set[name] = true
+	}
+	return set
+}
+
+// splitLines splits s into lines without their line breaks.
+func splitLines(s string) []string {
+	return strings.Split(strings.TrimSuffix(s, "\n"), "\n")
+}
+
+// diffLines returns the edit script turning a into b from their longest common
+// subsequence: added lines prefixed with "+", removed lines with "-", and up to
+// diffContext unchanged lines around each change prefixed with " ". Runs of
+// unchanged lines left out are marked with a "..." line.
+func diffLines(a, b []string) []string {
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and
+	// b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+	var script []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			script = append(script, " "+a[i])
+			i, j = i+1, j+1
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			script = append(script, "-"+a[i])
+			i++
+		default:
+			script = append(script, "+"+b[j])
+			j++
+		}
+	}
+
+	// Keep the changes and their context.
+	keep := make([]bool, len(script))
+	for k, line := range script {
+		if line[0] == ' ' {
+			continue
+		}
+		for c := max(0, k-diffContext); c <= min(len(script)-1, k+diffContext); c++ {
+			keep[c] = true
+		}
+	}
+	var out []string
+	for k, line := range script {
+		if keep[k] {
+			out = append(out, line)
+		} else if k == 0 || keep[k-1] {
+			out = append(out, " ...")
+		}
+	}
+	return out
+}
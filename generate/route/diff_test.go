@@ -0,0 +1,47 @@
+package route
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffOutputs(t *testing.T) {
+	prev := map[string]string{
+		"a.go":    "package a\n\n1\n2\n3\n4\n5\n6\n7\n",
+		"gone.go": "package gone\n",
+		"same.go": "package same\n",
+	}
+	next := map[string]string{
+		"a.go":    "package a\n\n1\n2\n3\nfour\n5\n6\n7\n",
+		"new.go":  "package new\n",
+		"same.go": "package same\n",
+	}
+	want := strings.Join([]string{
+		"changed a.go",
+		" ...",
+		" 2",
+		" 3",
+		"-4",
+		"+four",
+		" 5",
+		" 6",
+		" ...",
+		"removed gone.go",
+		"added new.go",
+		"",
+	}, "\n")
+	if got := DiffOutputs(prev, next, false); got != want {
+		t.Errorf("DiffOutputs() =\n%s\nwant\n%s", got, want)
+	}
+
+	colored := DiffOutputs(prev, next, true)
+	for _, want := range []string{ansiBold + "changed a.go" + ansiReset, ansiRed + "-4" + ansiReset, ansiGreen + "+four" + ansiReset} {
+		if !strings.Contains(colored, want) {
+			t.Errorf("colored DiffOutputs() lacks %q:\n%s", want, colored)
+		}
+	}
+
+	if got := DiffOutputs(prev, prev, true); got != "" {
+		t.Errorf("DiffOutputs() of unchanged files = %q, want empty", got)
+	}
+}
@@ -0,0 +1,197 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Discord application command and option types used by the manifest.
+const (
+	discordChatInput     = 1
+	discordOptionString  = 3
+	discordOptionInteger = 4
+	discordOptionBoolean = 5
+	discordOptionNumber  = 10
+)
+
+// discordNamePattern is the name syntax Discord accepts for chat input
+// commands and their options.
+var discordNamePattern = regexp.MustCompile(`^[-_\p{Ll}\p{N}]{1,32}$`)
+
+// Limits of Discord application commands.
+const (
+	discordMaxDescription = 100
+	discordMaxOptions     = 25
+	discordMaxChoices     = 25
+)
+
+// discordCommand is one application command of the bulk overwrite endpoint
+// (PUT /applications/{id}/commands).
+type discordCommand struct {
+	Name        string          `json:"name"`
+	Type        int             `json:"type"`
+	Description string          `json:"description"`
+	Options     []discordOption `json:"options,omitempty"`
+}
+
+type discordOption struct {
+	Type        int             `json:"type"`
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Required    bool            `json:"required,omitempty"`
+	Choices     []discordChoice `json:"choices,omitempty"`
+}
+
+type discordChoice struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// generateDiscordCommands emits the .<key>.discord.json sidecar: the JSON array
+// of application commands, ready for Discord's bulk overwrite endpoint, of the
+// services rendered into the Go file.
+func generateDiscordCommands(gen *protogen.Plugin, file *protogen.File, conf *Config, services []*template.ServiceDesc) error {
+	commands, err := buildDiscordCommands(file, services)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(commands, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := file.GeneratedFilenamePrefix + fmt.Sprintf(".%s.discord.json", strings.ToLower(conf.OptionsKey))
+	g := gen.NewGeneratedFile(filename, "")
+	_, err = g.Write(append(content, '\n'))
+	return err
+}
+
+// buildDiscordCommands describes every method with a command extra as a chat
+// input command named after its first command. Its options are the request
+// fields: scalars map to the matching option type, enums to a string option
+// whose choices are the enum values, and fields without presence or a oneof
+// are required. Descriptions are the first comment lines, falling back to the
+// method or field name.
+func buildDiscordCommands(file *protogen.File, services []*template.ServiceDesc) ([]discordCommand, error) {
+	commands := []discordCommand{}
+	seen := make(map[string]string) // command name -> method
+	var errs []error
+	for _, sd := range services {
+		for _, md := range sd.Methods {
+			if len(md.Commands) == 0 {
+				continue
+			}
+			method := findMethod(file, md.OperationPath)
+			name := md.Commands[0]
+			if !discordNamePattern.MatchString(name) {
+				errs = append(errs, descriptorErrorf(method.Desc, "discord command %q must be 1-32 lowercase letters, digits, '-' or '_'", name))
+				continue
+			}
+			if other, ok := seen[name]; ok {
+				errs = append(errs, descriptorErrorf(method.Desc, "discord command %q is already declared by %s", name, other))
+				continue
+			}
+			seen[name] = string(method.Desc.FullName())
+			description, err := discordDescription(method.Desc, md.Summary, md.OriginalName)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			options, oErrs := discordOptions(method.Input)
+			if len(oErrs) > 0 {
+				errs = append(errs, oErrs...)
+				continue
+			}
+			commands = append(commands, discordCommand{Name: name, Type: discordChatInput, Description: description, Options: options})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, joinErrors(errs)
+	}
+	return commands, nil
+}
+
+// discordOptions converts the fields of a request message into options,
+// required options first as Discord demands.
+func discordOptions(message *protogen.Message) ([]discordOption, []error) {
+	var required, optional []discordOption
+	var errs []error
+	if len(message.Fields) > discordMaxOptions {
+		return nil, []error{descriptorErrorf(message.Desc, "discord commands take at most %d options, the request has %d fields", discordMaxOptions, len(message.Fields))}
+	}
+	for _, field := range message.Fields {
+		option, err := discordOptionOf(field)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if option.Required {
+			required = append(required, option)
+		} else {
+			optional = append(optional, option)
+		}
+	}
+	return append(required, optional...), errs
+}
+
+func discordOptionOf(field *protogen.Field) (discordOption, error) {
+	name := string(field.Desc.Name())
+	if !discordNamePattern.MatchString(name) {
+		return discordOption{}, descriptorErrorf(field.Desc, "discord option %q must be 1-32 lowercase letters, digits, '-' or '_'", name)
+	}
+	if field.Desc.IsList() || field.Desc.IsMap() {
+		return discordOption{}, descriptorErrorf(field.Desc, "repeated and map fields cannot be discord options")
+	}
+	summary, _ := splitComment(string(field.Comments.Leading))
+	description, err := discordDescription(field.Desc, summary, name)
+	if err != nil {
+		return discordOption{}, err
+	}
+	option := discordOption{
+		Name:        name,
+		Description: description,
+		Required:    !field.Desc.HasPresence(),
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		option.Type = discordOptionString
+	case protoreflect.BoolKind:
+		option.Type = discordOptionBoolean
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		option.Type = discordOptionInteger
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		option.Type = discordOptionNumber
+	case protoreflect.EnumKind:
+		option.Type = discordOptionString
+		if len(field.Enum.Values) > discordMaxChoices {
+			return discordOption{}, descriptorErrorf(field.Desc, "discord options take at most %d choices, enum %s has %d values", discordMaxChoices, field.Enum.Desc.Name(), len(field.Enum.Values))
+		}
+		for _, value := range field.Enum.Values {
+			option.Choices = append(option.Choices, discordChoice{Name: string(value.Desc.Name()), Value: string(value.Desc.Name())})
+		}
+	default:
+		return discordOption{}, descriptorErrorf(field.Desc, "%s fields cannot be discord options", field.Desc.Kind())
+	}
+	return option, nil
+}
+
+// discordDescription returns summary, or fallback when it is empty, checking
+// Discord's length limit.
+func discordDescription(desc protoreflect.Descriptor, summary, fallback string) (string, error) {
+	if summary == "" {
+		summary = fallback
+	}
+	if utf8.RuneCountInString(summary) > discordMaxDescription {
+		return "", descriptorErrorf(desc, "discord description is longer than %d characters", discordMaxDescription)
+	}
+	return summary, nil
+}
@@ -0,0 +1,48 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestGoldenDiscordCommands(t *testing.T) {
+	conf := DefaultConfig()
+	conf.OptionsKey = "discord"
+	conf.DiscordCommands = true
+	content := generateSidecar(t, "testdata/pb/discord.pb", "discord.proto", conf, ".discord.discord.json")
+	compareGolden(t, "testdata/golden/discord.discord.discord.json", content)
+}
+
+func TestBuildDiscordCommands_Errors(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/fields.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "fields.proto"))
+	service := file.Services[0]
+	method := service.Methods[0]
+	tests := []struct {
+		name    string
+		command string
+		want    string
+	}{
+		{"invalid command", "Roll", `discord command "Roll" must be`},
+		{"repeated field", "search", "repeated and map fields cannot be discord options"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := []*template.ServiceDesc{{
+				ServiceName: string(service.Desc.FullName()),
+				Methods: []*template.MethodDesc{{
+					OriginalName:  string(method.Desc.Name()),
+					OperationPath: operationPath(string(service.Desc.FullName()), string(method.Desc.Name())),
+					Commands:      []string{tt.command},
+				}},
+			}}
+			_, err := buildDiscordCommands(file, services)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package route
+
+import (
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// dispatchOneof describes the oneof of the request of method named by the
+// dispatch_oneof extra, such as "payload", with its fields qualified against
+// g. The oneof must be a real oneof, not the synthetic one of a proto3
+// optional field. An empty extra yields nil.
+func dispatchOneof(g *protogen.GeneratedFile, method *protogen.Method, name string) (*template.OneofDesc, error) {
+	if name == "" {
+		return nil, nil
+	}
+	var oneof *protogen.Oneof
+	for _, o := range method.Input.Oneofs {
+		if string(o.Desc.Name()) == name && !o.Desc.IsSynthetic() {
+			oneof = o
+		}
+	}
+	if oneof == nil {
+		return nil, descriptorErrorf(method.Desc, "invalid %s %q: request %s has no oneof %q", ExtraDispatchOneof, name, method.Input.Desc.FullName(), name)
+	}
+	desc := &template.OneofDesc{Name: name, GoName: oneof.GoName}
+	for _, field := range oneof.Fields {
+		desc.Cases = append(desc.Cases, &template.OneofCaseDesc{
+			Name:    string(field.Desc.Name()),
+			GoName:  field.GoName,
+			Wrapper: g.QualifiedGoIdent(field.GoIdent),
+			GoType:  fieldGoType(g, field),
+		})
+	}
+	return desc, nil
+}
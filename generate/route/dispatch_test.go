@@ -0,0 +1,39 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestDispatchOneof(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/dispatch.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "dispatch.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	g := plugin.NewGeneratedFile("dispatch.route.pb.go", file.GoImportPath)
+	receive := file.Services[0].Methods[0]
+
+	got, err := dispatchOneof(g, receive, "payload")
+	if err != nil {
+		t.Fatalf("dispatchOneof() failed: %v", err)
+	}
+	if got.GoName != "Payload" || len(got.Cases) != 3 {
+		t.Fatalf("dispatchOneof() = %+v, want the 3 cases of Payload", got)
+	}
+	photo := got.Cases[1]
+	if photo.GoName != "Photo" || photo.Wrapper != "ReceiveRequest_Photo" || photo.GoType != "*Photo" {
+		t.Errorf("dispatchOneof() photo case = %+v", photo)
+	}
+
+	if got, err := dispatchOneof(g, receive, ""); got != nil || err != nil {
+		t.Errorf("dispatchOneof(\"\") = %v, %v, want nil, nil", got, err)
+	}
+	for _, name := range []string{"missing", "_caption"} {
+		_, err := dispatchOneof(g, receive, name)
+		want := `request testdata.dispatch.v1.ReceiveRequest has no oneof "` + name + `"`
+		if err == nil || !strings.HasSuffix(err.Error(), want) {
+			t.Errorf("dispatchOneof(%q) error = %v, want suffix %q", name, err, want)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package route
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// DocsMarkdown is the only supported documentation format.
+const DocsMarkdown = "markdown"
+
+// generateDocs emits the .<key>.routes.md command reference for the services
+// rendered into the Go file.
+func generateDocs(gen *protogen.Plugin, file *protogen.File, conf *Config, services []*template.ServiceDesc) error {
+	filename := file.GeneratedFilenamePrefix + fmt.Sprintf(".%s.routes.md", strings.ToLower(conf.OptionsKey))
+	g := gen.NewGeneratedFile(filename, "")
+	_, err := g.Write([]byte(formatMarkdownDocs(file.Desc.Path(), services)))
+	return err
+}
+
+// formatMarkdownDocs renders one table per service listing each method's
+// command and its aliases, callback query, and summary taken from the first line of its
+// leading comment. Methods whose comment goes on have the rest rendered in a
+// section of their own below the table.
+func formatMarkdownDocs(source string, services []*template.ServiceDesc) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "<!-- Code generated by protoc-gen-route. DO NOT EDIT. -->\n<!-- source: %s -->\n", source)
+	for _, sd := range services {
+		fmt.Fprintf(&b, "\n## %s\n\n", sd.ServiceName)
+		b.WriteString("| Method | Command | Callback Query | Description |\n")
+		b.WriteString("| --- | --- | --- | --- |\n")
+		for _, md := range sd.Methods {
+			fmt.Fprintf(&b, "| %s | %s | %s | %s |\n",
+				markdownCell(md.OriginalName),
+				markdownCommands(md.Commands),
+				markdownCode(md.ExtraValue("callback_query")),
+				markdownCell(md.Summary),
+			)
+		}
+		for _, md := range sd.Methods {
+			if md.Description != "" {
+				fmt.Fprintf(&b, "\n### %s\n\n%s\n", md.OriginalName, md.Description)
+			}
+		}
+	}
+	return b.String()
+}
+
+// markdownCell escapes a value for a single table cell: pipes are escaped and
+// line breaks are folded into spaces.
+func markdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// markdownCommands renders the command of a method followed by its aliases,
+// e.g. "`start` (aliases `begin`, `hello`)".
+func markdownCommands(commands []string) string {
+	if len(commands) == 0 {
+		return ""
+	}
+	cell := markdownCode(commands[0])
+	if len(commands) > 1 {
+		aliases := make([]string, len(commands)-1)
+		for i, alias := range commands[1:] {
+			aliases[i] = markdownCode(alias)
+		}
+		cell += " (aliases " + strings.Join(aliases, ", ") + ")"
+	}
+	return cell
+}
+
+// markdownCode renders a non-empty value as inline code inside a table cell.
+func markdownCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + markdownCell(s) + "`"
+}
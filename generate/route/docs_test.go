@@ -0,0 +1,50 @@
+package route
+
+import "testing"
+
+func TestGoldenDocs(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Docs = DocsMarkdown
+	content := generateSidecar(t, "testdata/pb/basic.pb", "basic.proto", conf, ".route.routes.md")
+	compareGolden(t, "testdata/golden/basic.route.routes.md", content)
+}
+
+// TestGoldenDocs_Comments verifies that multi-paragraph comments render their
+// summary in the table and the rest in a section per method.
+func TestGoldenDocs_Comments(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Docs = DocsMarkdown
+	content := generateSidecar(t, "testdata/pb/comments.pb", "comments.proto", conf, ".route.routes.md")
+	compareGolden(t, "testdata/golden/comments.route.routes.md", content)
+}
+
+// TestGoldenDocs_Aliases verifies that the command column lists the command
+// before its aliases.
+func TestGoldenDocs_Aliases(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Docs = DocsMarkdown
+	content := generateSidecar(t, "testdata/pb/aliases.pb", "aliases.proto", conf, ".route.routes.md")
+	compareGolden(t, "testdata/golden/aliases.route.routes.md", content)
+}
+
+func TestMarkdownCell(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", "plain"},
+		{"a|b", `a\|b`},
+		{"line1\nline2", "line1 line2"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := markdownCell(tt.in); got != tt.want {
+			t.Errorf("markdownCell(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if got := markdownCode(""); got != "" {
+		t.Errorf("markdownCode(\"\") = %q, want empty", got)
+	}
+	if got := markdownCode("menu_.*"); got != "`menu_.*`" {
+		t.Errorf("markdownCode() = %q", got)
+	}
+}
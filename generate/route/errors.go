@@ -0,0 +1,109 @@
+package route
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// Supported error formats.
+const (
+	ErrorFormatText = "text"
+	ErrorFormatJSON = "json"
+)
+
+// DescriptorError is a generation error located at a proto element, such as a
+// method with an invalid extra. Run reports every such error of an invocation
+// together, joined with errors.Join.
+type DescriptorError struct {
+	Path    string // proto file path
+	Line    int    // 1-based line, 0 when the file has no source info
+	Column  int    // 1-based column, 0 when the file has no source info
+	Element string // full name of the element, e.g. bot.v1.MenuService.Start
+	Message string
+}
+
+// Error formats e as "path:line:column: element: message".
+func (e *DescriptorError) Error() string {
+	position := e.Path
+	if e.Line > 0 {
+		position = fmt.Sprintf("%s:%d:%d", e.Path, e.Line, e.Column)
+	}
+	return fmt.Sprintf("%s: %s: %s", position, e.Element, e.Message)
+}
+
+// warnings receives the warnings of generation, such as skipped streaming
+// methods. protoc shows the plugin's standard error to the user.
+var warnings io.Writer = os.Stderr
+
+// warnf writes a warning located at desc to w.
+func warnf(w io.Writer, desc protoreflect.Descriptor, format string, args ...any) {
+	fmt.Fprintf(w, "protoc-gen-route: warning: %v\n", descriptorErrorf(desc, format, args...))
+}
+
+// descriptorErrorf returns a DescriptorError located at desc.
+func descriptorErrorf(desc protoreflect.Descriptor, format string, args ...any) error {
+	e := &DescriptorError{
+		Path:    desc.ParentFile().Path(),
+		Element: string(desc.FullName()),
+		Message: fmt.Sprintf(format, args...),
+	}
+	if loc := desc.ParentFile().SourceLocations().ByDescriptor(desc); loc.Path != nil {
+		e.Line, e.Column = loc.StartLine+1, loc.StartColumn+1
+	}
+	return e
+}
+
+// jsonError is one entry of a JSON error report. Errors that are not located
+// at a proto element only carry a message.
+type jsonError struct {
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Element string `json:"element,omitempty"`
+	Message string `json:"message"`
+}
+
+// formatError renders err, possibly a tree of joined errors, in the given
+// error format. ErrorFormatJSON yields a JSON array with one object per
+// error, for editor integration; any other format returns err unchanged.
+func formatError(format string, err error) error {
+	if err == nil || format != ErrorFormatJSON {
+		return err
+	}
+	var list []jsonError
+	for _, e := range flattenErrors(err) {
+		var de *DescriptorError
+		if errors.As(e, &de) {
+			list = append(list, jsonError{File: de.Path, Line: de.Line, Column: de.Column, Element: de.Element, Message: de.Message})
+			continue
+		}
+		list = append(list, jsonError{Message: e.Error()})
+	}
+	data, mErr := json.Marshal(list)
+	if mErr != nil {
+		return err
+	}
+	return errors.New(string(data))
+}
+
+// flattenErrors returns the leaves of a tree of errors joined with
+// errors.Join, in order. A nil error yields nil.
+func flattenErrors(err error) []error {
+	if err == nil {
+		return nil
+	}
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var list []error
+	for _, e := range joined.Unwrap() {
+		list = append(list, flattenErrors(e)...)
+	}
+	return list
+}
@@ -0,0 +1,45 @@
+package route
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDescriptorError(t *testing.T) {
+	located := &DescriptorError{Path: "bot.proto", Line: 12, Column: 3, Element: "bot.v1.Menu.Start", Message: "bad extra"}
+	if got, want := located.Error(), "bot.proto:12:3: bot.v1.Menu.Start: bad extra"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+	noSource := &DescriptorError{Path: "bot.proto", Element: "bot.v1.Menu.Start", Message: "bad extra"}
+	if got, want := noSource.Error(), "bot.proto: bot.v1.Menu.Start: bad extra"; got != want {
+		t.Errorf("Error() = %q, want %q", got, want)
+	}
+}
+
+func TestFormatError(t *testing.T) {
+	err := errors.Join(
+		&DescriptorError{Path: "bot.proto", Line: 12, Column: 3, Element: "bot.v1.Menu.Start", Message: "bad extra"},
+		errors.Join(errors.New("template failed")),
+	)
+	if got := formatError(ErrorFormatText, err); got != err {
+		t.Errorf("formatError(text) = %v, want the error unchanged", got)
+	}
+	want := `[{"file":"bot.proto","line":12,"column":3,"element":"bot.v1.Menu.Start","message":"bad extra"},{"message":"template failed"}]`
+	if got := formatError(ErrorFormatJSON, err); got == nil || got.Error() != want {
+		t.Errorf("formatError(json) = %v, want %s", got, want)
+	}
+	if got := formatError(ErrorFormatJSON, nil); got != nil {
+		t.Errorf("formatError(json, nil) = %v, want nil", got)
+	}
+}
+
+func TestJoinErrors(t *testing.T) {
+	a, b := errors.New("a"), errors.New("b")
+	err := joinErrors([]error{errors.Join(a, b), errors.New("a")})
+	if got, want := err.Error(), "a\nb"; got != want {
+		t.Errorf("joinErrors() = %q, want %q", got, want)
+	}
+	if err := joinErrors(nil); err != nil {
+		t.Errorf("joinErrors(nil) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,190 @@
+package route
+
+import (
+	"fmt"
+
+	"github.com/go-sphere/options/sphere/options"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// ruleExtension identifies the options extension the rules are read from: its
+// field number and the name errors refer to it by.
+type ruleExtension struct {
+	number protowire.Number
+	name   string
+}
+
+// sphereRules is the (sphere.options.options) extension, the default rule
+// extension.
+var sphereRules = ruleExtension{
+	number: options.E_Options.TypeDescriptor().Number(),
+	name:   string(options.E_Options.TypeDescriptor().FullName()),
+}
+
+// ruleExtension returns the rule extension of c: the one Run resolved, else
+// OptionsExtensionNumber, else sphereRules.
+func (c *Config) ruleExtension() ruleExtension {
+	switch {
+	case c.rules.number != 0:
+		return c.rules
+	case c.OptionsExtensionNumber != 0:
+		name := c.OptionsExtension
+		if name == "" {
+			name = fmt.Sprintf("extension %d", c.OptionsExtensionNumber)
+		}
+		return ruleExtension{number: protowire.Number(c.OptionsExtensionNumber), name: name}
+	}
+	return sphereRules
+}
+
+// resolveRuleExtension returns the rule extension of conf, looking up the
+// field number of OptionsExtension in the files of the request when
+// OptionsExtensionNumber is not set. A declared extension must extend
+// google.protobuf.MethodOptions with a repeated message shaped like
+// sphere.options.KeyValuePair: a string key in field 1 and a map<string,
+// string> extra in field 5.
+func resolveRuleExtension(gen *protogen.Plugin, conf *Config) (ruleExtension, error) {
+	if conf.OptionsExtension == "" || protoreflect.FullName(conf.OptionsExtension) == options.E_Options.TypeDescriptor().FullName() {
+		return conf.ruleExtension(), nil
+	}
+	var found *protogen.Extension
+	for _, file := range gen.Files {
+		for _, ext := range allExtensions(file) {
+			if string(ext.Desc.FullName()) == conf.OptionsExtension {
+				found = ext
+			}
+		}
+	}
+	if found == nil {
+		if conf.OptionsExtensionNumber == 0 {
+			return ruleExtension{}, fmt.Errorf("options_extension %s is not declared by the files of the request; set options_extension_number", conf.OptionsExtension)
+		}
+		return conf.ruleExtension(), nil
+	}
+	desc := found.Desc
+	if desc.ContainingMessage().FullName() != "google.protobuf.MethodOptions" {
+		return ruleExtension{}, fmt.Errorf("options_extension %s extends %s, not google.protobuf.MethodOptions", desc.FullName(), desc.ContainingMessage().FullName())
+	}
+	if conf.OptionsExtensionNumber != 0 && protowire.Number(conf.OptionsExtensionNumber) != desc.Number() {
+		return ruleExtension{}, fmt.Errorf("options_extension %s has field number %d, not options_extension_number %d", desc.FullName(), desc.Number(), conf.OptionsExtensionNumber)
+	}
+	if err := checkRuleShape(desc); err != nil {
+		return ruleExtension{}, fmt.Errorf("options_extension %s: %w", desc.FullName(), err)
+	}
+	return ruleExtension{number: desc.Number(), name: string(desc.FullName())}, nil
+}
+
+// withRuleExtension returns a copy of conf with its rule extension resolved
+// against the files of gen, see resolveRuleExtension, and the output paths of
+// the request of gen.
+func withRuleExtension(gen *protogen.Plugin, conf *Config) (*Config, error) {
+	rules, err := resolveRuleExtension(gen, conf)
+	if err != nil {
+		return nil, err
+	}
+	resolved := *conf
+	resolved.rules = rules
+	resolved.paths = parseOutputPaths(gen.Request.GetParameter())
+	return &resolved, nil
+}
+
+// checkRuleShape checks that the rules of ext decode as
+// sphere.options.KeyValuePair.
+func checkRuleShape(ext protoreflect.ExtensionDescriptor) error {
+	if ext.Cardinality() != protoreflect.Repeated || ext.Message() == nil {
+		return fmt.Errorf("must be a repeated message")
+	}
+	fields := ext.Message().Fields()
+	if key := fields.ByNumber(1); key == nil || key.Kind() != protoreflect.StringKind || key.IsList() {
+		return fmt.Errorf("%s must have a string key in field 1", ext.Message().FullName())
+	}
+	extra := fields.ByNumber(5)
+	if extra == nil || !extra.IsMap() || extra.MapKey().Kind() != protoreflect.StringKind || extra.MapValue().Kind() != protoreflect.StringKind {
+		return fmt.Errorf("%s must have a map<string, string> extra in field 5", ext.Message().FullName())
+	}
+	return nil
+}
+
+// allExtensions returns the extensions declared by file, at the top level
+// and nested in messages.
+func allExtensions(file *protogen.File) []*protogen.Extension {
+	exts := append([]*protogen.Extension(nil), file.Extensions...)
+	var walk func(messages []*protogen.Message)
+	walk = func(messages []*protogen.Message) {
+		for _, message := range messages {
+			exts = append(exts, message.Extensions...)
+			walk(message.Messages)
+		}
+	}
+	walk(file.Messages)
+	return exts
+}
+
+// readRules returns the rules opts carries in the field of ext. The field is
+// read as an extension when the Go type registry knows it, as it does
+// (sphere.options.options), and from the unknown fields of opts otherwise,
+// such as for a service rule or an extension of another proto package.
+func readRules(opts proto.Message, ext ruleExtension) ([]*options.KeyValuePair, error) {
+	if opts == nil || !opts.ProtoReflect().IsValid() {
+		return nil, nil
+	}
+	var rules []*options.KeyValuePair
+	var err error
+	opts.ProtoReflect().Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if !fd.IsExtension() || fd.Number() != ext.number || !fd.IsList() || fd.Message() == nil {
+			return true
+		}
+		list := value.List()
+		for i := range list.Len() {
+			message := list.Get(i).Message().Interface()
+			rule, ok := message.(*options.KeyValuePair)
+			if !ok {
+				rule = &options.KeyValuePair{}
+				var raw []byte
+				if raw, err = proto.Marshal(message); err == nil {
+					err = proto.Unmarshal(raw, rule)
+				}
+				if err != nil {
+					err = fmt.Errorf("invalid (%s) rule: %w", ext.name, err)
+					return false
+				}
+			}
+			rules = append(rules, rule)
+		}
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	raw := opts.ProtoReflect().GetUnknown()
+	for len(raw) > 0 {
+		num, typ, n := protowire.ConsumeTag(raw)
+		if n < 0 {
+			return nil, fmt.Errorf("malformed options: %w", protowire.ParseError(n))
+		}
+		raw = raw[n:]
+		if num != ext.number || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, raw)
+			if n < 0 {
+				return nil, fmt.Errorf("malformed options: %w", protowire.ParseError(n))
+			}
+			raw = raw[n:]
+			continue
+		}
+		value, n := protowire.ConsumeBytes(raw)
+		if n < 0 {
+			return nil, fmt.Errorf("malformed options: %w", protowire.ParseError(n))
+		}
+		raw = raw[n:]
+		rule := &options.KeyValuePair{}
+		if err := proto.Unmarshal(value, rule); err != nil {
+			return nil, fmt.Errorf("invalid (%s) rule: %w", ext.name, err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
@@ -0,0 +1,73 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestResolveRuleExtension(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/custom_extension.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "custom_extension.proto")
+
+	tests := []struct {
+		name    string
+		ext     string
+		number  int32
+		want    ruleExtension
+		wantErr string
+	}{
+		{name: "default", want: sphereRules},
+		{name: "sphere by name", ext: "sphere.options.options", want: sphereRules},
+		{name: "declared", ext: "testdata.custom_extension.v1.route", want: ruleExtension{number: 50100, name: "testdata.custom_extension.v1.route"}},
+		{name: "declared with its number", ext: "testdata.custom_extension.v1.route", number: 50100, want: ruleExtension{number: 50100, name: "testdata.custom_extension.v1.route"}},
+		{name: "undeclared with a number", ext: "acme.options.route", number: 50200, want: ruleExtension{number: 50200, name: "acme.options.route"}},
+		{name: "number only", number: 50100, want: ruleExtension{number: 50100, name: "extension 50100"}},
+		{name: "undeclared", ext: "acme.options.route", wantErr: "is not declared by the files of the request"},
+		{name: "number mismatch", ext: "testdata.custom_extension.v1.route", number: 1, wantErr: "has field number 50100, not options_extension_number 1"},
+		{name: "service options", ext: "testdata.custom_extension.v1.service_route", wantErr: "extends google.protobuf.ServiceOptions"},
+		{name: "wrong shape", ext: "testdata.custom_extension.v1.bad_route", wantErr: "BadRule must have a map<string, string> extra in field 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := DefaultConfig()
+			conf.OptionsExtension, conf.OptionsExtensionNumber = tt.ext, tt.number
+			got, err := resolveRuleExtension(plugin, conf)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("resolveRuleExtension() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveRuleExtension() failed: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("resolveRuleExtension() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReadRules_CustomExtension(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/custom_extension.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "custom_extension.proto")
+	service := testutil.FileToGenerate(t, plugin).Services[0]
+	custom := ruleExtension{number: 50100, name: "testdata.custom_extension.v1.route"}
+
+	rule, err := extractOptionsRule(service.Methods[0], "route", custom)
+	if err != nil || rule.GetExtra()["command"] != "buy" {
+		t.Errorf("extractOptionsRule(Buy) = %v, %v, want the custom rule", rule, err)
+	}
+	if rule, err := extractOptionsRule(service.Methods[1], "route", custom); rule != nil || err != nil {
+		t.Errorf("extractOptionsRule(Ignored) = %v, %v, want no rule of the custom extension", rule, err)
+	}
+	if rule, err := extractOptionsRule(service.Methods[1], "route", sphereRules); err != nil || rule.GetExtra()["command"] != "ignored" {
+		t.Errorf("extractOptionsRule(Ignored) with sphere rules = %v, %v", rule, err)
+	}
+	serviceRule, err := extractServiceRule(service, "route", custom)
+	if err != nil || serviceRule.GetExtra()["group"] != "shop" {
+		t.Errorf("extractServiceRule() = %v, %v, want the custom service rule", serviceRule, err)
+	}
+}
@@ -0,0 +1,95 @@
+package route
+
+import (
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// buildFieldDescs describes the fields of a message in declaration order.
+// Message and enum types are qualified against g, registering their imports,
+// once a template calls FieldDesc.GoType.
+func buildFieldDescs(g *protogen.GeneratedFile, message *protogen.Message) []*template.FieldDesc {
+	if message == nil || len(message.Fields) == 0 {
+		return nil
+	}
+	fields := make([]*template.FieldDesc, 0, len(message.Fields))
+	for _, field := range message.Fields {
+		fd := &template.FieldDesc{
+			Name:     string(field.Desc.Name()),
+			GoName:   field.GoName,
+			JSONName: field.Desc.JSONName(),
+			Number:   int(field.Desc.Number()),
+			Kind:     field.Desc.Kind().String(),
+			Repeated: field.Desc.IsList(),
+			Map:      field.Desc.IsMap(),
+			Optional: hasScalarPresence(field),
+			Comment:  plainComment(string(field.Comments.Leading)),
+		}
+		fd.GoTypeFunc = func() string { return fieldGoType(g, field) }
+		if oneof := field.Desc.ContainingOneof(); oneof != nil && !oneof.IsSynthetic() {
+			fd.Oneof = string(oneof.Name())
+		}
+		fields = append(fields, fd)
+	}
+	return fields
+}
+
+// fieldGoType returns the Go type protoc-gen-go uses for the struct field of
+// field, e.g. "string", "*int64", "[]string", "map[string]int32" or "*Item".
+func fieldGoType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	if field.Desc.IsMap() {
+		return "map[" + singularGoType(g, field.Message.Fields[0]) + "]" + singularGoType(g, field.Message.Fields[1])
+	}
+	goType := singularGoType(g, field)
+	switch {
+	case field.Desc.IsList():
+		return "[]" + goType
+	case field.Desc.Kind() == protoreflect.MessageKind || field.Desc.Kind() == protoreflect.GroupKind:
+		return goType
+	case hasScalarPresence(field):
+		return "*" + goType
+	}
+	return goType
+}
+
+// hasScalarPresence reports whether field is a singular non-message field with
+// explicit presence outside a real oneof: proto3 optional, proto2 optional, or an
+// edition field whose field_presence feature is EXPLICIT. protoc-gen-go
+// generates such fields as pointers.
+func hasScalarPresence(field *protogen.Field) bool {
+	if field.Desc.IsList() || field.Desc.IsMap() || field.Message != nil {
+		return false
+	}
+	return field.Desc.HasPresence() && (field.Oneof == nil || field.Oneof.Desc.IsSynthetic())
+}
+
+// singularGoType returns the Go type of a single value of field, ignoring
+// cardinality. Messages are returned as pointers.
+func singularGoType(g *protogen.GeneratedFile, field *protogen.Field) string {
+	switch field.Desc.Kind() {
+	case protoreflect.BoolKind:
+		return "bool"
+	case protoreflect.EnumKind:
+		return g.QualifiedGoIdent(field.Enum.GoIdent)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int32"
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return "uint32"
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return "int64"
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return "uint64"
+	case protoreflect.FloatKind:
+		return "float32"
+	case protoreflect.DoubleKind:
+		return "float64"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "[]byte"
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "*" + g.QualifiedGoIdent(field.Message.GoIdent)
+	}
+	return "any"
+}
@@ -0,0 +1,97 @@
+package route
+
+import (
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestBuildFieldDescs(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/fields.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "fields.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	g := plugin.NewGeneratedFile("fields.route.pb.go", file.GoImportPath)
+
+	fields := buildFieldDescs(g, file.Services[0].Methods[0].Input)
+	want := []template.FieldDesc{
+		{Name: "query_text", GoName: "QueryText", JSONName: "queryText", Number: 1, Kind: "string", Comment: "The search query."},
+		{Name: "tags", GoName: "Tags", JSONName: "tags", Number: 2, Kind: "string", Repeated: true},
+		{Name: "weights", GoName: "Weights", JSONName: "weights", Number: 3, Kind: "message", Map: true},
+		{Name: "limit", GoName: "Limit", JSONName: "limit", Number: 4, Kind: "uint32", Optional: true},
+		{Name: "scope", GoName: "Scope", JSONName: "scope", Number: 5, Kind: "enum"},
+		{Name: "page", GoName: "Page", JSONName: "page", Number: 6, Kind: "message"},
+		{Name: "user_id", GoName: "UserId", JSONName: "userId", Number: 7, Kind: "int64", Oneof: "target"},
+		{Name: "raw", GoName: "Raw", JSONName: "raw", Number: 8, Kind: "bytes", Oneof: "target"},
+		{Name: "pages", GoName: "Pages", JSONName: "pages", Number: 9, Kind: "message", Repeated: true},
+	}
+	wantTypes := []string{"string", "[]string", "map[string]int64", "*uint32", "Scope", "*Page", "int64", "[]byte", "[]*Page"}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(fields), len(want))
+	}
+	for i, got := range fields {
+		if goType := got.GoType(); goType != wantTypes[i] {
+			t.Errorf("field %d GoType() = %q, want %q", i, goType, wantTypes[i])
+		}
+		field := *got
+		field.GoTypeFunc = nil
+		if !reflect.DeepEqual(field, want[i]) {
+			t.Errorf("field %d = %+v, want %+v", i, field, want[i])
+		}
+	}
+}
+
+func TestBuildFieldDescs_Editions(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/editions.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "editions.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	g := plugin.NewGeneratedFile("editions.route.pb.go", file.GoImportPath)
+
+	fields := buildFieldDescs(g, file.Services[0].Methods[0].Input)
+	want := []template.FieldDesc{
+		{Name: "text", GoName: "Text", JSONName: "text", Number: 1, Kind: "string", Optional: true, Comment: "Text has explicit presence, the edition 2023 default."},
+		{Name: "priority", GoName: "Priority", JSONName: "priority", Number: 2, Kind: "int32", Comment: "Priority opts out of presence tracking."},
+		{Name: "tags", GoName: "Tags", JSONName: "tags", Number: 3, Kind: "string", Repeated: true},
+	}
+	wantTypes := []string{"*string", "int32", "[]string"}
+	if len(fields) != len(want) {
+		t.Fatalf("got %d fields, want %d", len(fields), len(want))
+	}
+	for i, got := range fields {
+		if goType := got.GoType(); goType != wantTypes[i] {
+			t.Errorf("field %d GoType() = %q, want %q", i, goType, wantTypes[i])
+		}
+		field := *got
+		field.GoTypeFunc = nil
+		if !reflect.DeepEqual(field, want[i]) {
+			t.Errorf("field %d = %+v, want %+v", i, field, want[i])
+		}
+	}
+}
+
+// TestGenerateFile_ForeignFieldImports verifies that the message types of other
+// packages in request fields are not imported into a file that never renders
+// them: every import of the file generated for callback_schema.proto, whose
+// requests hold well-known types, is used.
+func TestGenerateFile_ForeignFieldImports(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/callback_schema.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "callback_schema.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	if _, err := GenerateFile(plugin, file, DefaultConfig()); err != nil {
+		t.Fatalf("GenerateFile failed: %v", err)
+	}
+	content := plugin.Response().GetFile()[0].GetContent()
+	parsed, err := parser.ParseFile(token.NewFileSet(), "", content, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("generated file does not parse: %v", err)
+	}
+	for _, spec := range parsed.Imports {
+		if spec.Name != nil && !strings.Contains(content, spec.Name.Name+".") {
+			t.Errorf("import %s %s is not used", spec.Name.Name, spec.Path.Value)
+		}
+	}
+}
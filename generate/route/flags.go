@@ -0,0 +1,383 @@
+package route
+
+import (
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// keyModelParams are the parameters that can be set per options key as
+// <key>.<param>, e.g. bot.request_model.
+var keyModelParams = []string{"request_model", "response_model", "extra_data_model", "extra_data_constructor"}
+
+// Flags binds the plugin parameters of protoc-gen-route to a flag.FlagSet.
+// Plugins embedding the generator can bind them to their own flag set and pass
+// Flags.Set as protogen.Options.ParamFunc, then build the Config with
+// Flags.Config.
+type Flags struct {
+	fs *flag.FlagSet
+
+	optionsKey   *string
+	extension    *string
+	extensionNum *int
+	templateName *string
+	templateFile *string
+	templateDir  *string
+	delims       *string
+	dataOnly     *bool
+	manifest     *string
+	docs         *string
+	i18n         *string
+	telegram     *bool
+	discord      *bool
+	callbacks    *bool
+	tsOutFile    *string
+	renders      renderFlag
+	vars         varFlag
+	uniqueExtras *string
+	includeTags  *string
+	excludeTags  *string
+	env          *string
+	extrasSchema *string
+	overrides    *string
+	filePattern  *string
+	registerAll  *bool
+	descriptors  *bool
+	typeRegistry *bool
+	invoker      *bool
+	routeInfo    *bool
+	mocks        *bool
+	selfTest     *bool
+	validate     *bool
+	dispatch     *string
+	split        *string
+	errorFormat  *string
+	strictKeys   *bool
+	streaming    *string
+	tracing      *bool
+	metrics      *bool
+	genericCodec *bool
+	generics     *bool
+
+	goPackage     *string
+	licenseFile   *string
+	buildTags     *string
+	headerVersion *bool
+	cacheDir      *string
+	parallel      *int
+	stats         *bool
+	statsOut      *string
+	versionGuard  *bool
+	guardIdent    *string
+
+	requestModel         *string
+	responseModel        *string
+	extraDataModel       *string
+	extraDataConstructor *string
+
+	// keyModels holds the <key>.<param> values by key, then parameter.
+	keyModels map[string]map[string]string
+}
+
+// BindFlags registers the generator parameters on fs.
+func BindFlags(fs *flag.FlagSet) *Flags {
+	f := &Flags{
+		fs: fs,
+
+		optionsKey:   fs.String("options_key", DefaultOptionsKey, "options key in proto, multiple keys are separated by ';', a key may be a glob pattern such as * to generate every key found"),
+		extension:    fs.String("options_extension", "", "full name of the MethodOptions extension shaped like sphere.options.options to read the rules from instead"),
+		extensionNum: fs.Int("options_extension_number", 0, "field number of options_extension, default the number its declaration in the request has"),
+		templateName: fs.String("template", "bot", "built-in template name: bot, job, mq, minimal or data"),
+		templateFile: fs.String("template_file", "", "template file, if not set, use the built-in template"),
+		templateDir:  fs.String("template_dir", "", "directory of *.tmpl partials, its route.tmpl is the entry template unless template_file is set"),
+		delims:       fs.String("template_delims", "", "left and right action delimiters of the custom template separated by ',', e.g. <<,>>"),
+		dataOnly:     fs.Bool("data_only", false, "generate only the route tables of the services, without handler wiring; same as template="+DataTemplate),
+		manifest:     fs.String("manifest", "", "also emit a route manifest sidecar: json or yaml"),
+		docs:         fs.String("docs", "", "also emit a command reference: markdown"),
+		i18n:         fs.String("i18n", "", "also emit an i18n resource skeleton of the command descriptions, json or toml, and Go constants of its keys"),
+		discord:      fs.Bool("discord_commands", false, "also emit the Discord application commands of the commands, with options from the request fields"),
+		callbacks:    fs.Bool("callback_schema", false, "also emit the JSON Schema of the request messages of the methods routed by callbacks"),
+		telegram:     fs.Bool("telegram_commands", false, "also emit the Telegram setMyCommands payloads of the commands, one per scope extra"),
+		tsOutFile:    fs.String("ts_out_file", "", "also emit this TypeScript file of the operation constants, commands and callback patterns of every generated service"),
+		uniqueExtras: fs.String("unique_extras", "", "extra keys whose values must be unique per options key, separated by ';'"),
+		includeTags:  fs.String("include_tags", "", "generate only the methods whose tags extra lists one of these tags, separated by ';'"),
+		excludeTags:  fs.String("exclude_tags", "", "leave out the methods whose tags extra lists one of these tags, separated by ';'"),
+		env:          fs.String("env", "", "the environment generated for, e.g. prod: leave out the methods whose env extra does not list it"),
+		extrasSchema: fs.String("extras_schema", "", "YAML or JSON file declaring the extras allowed per options key, whether they are required, and their value patterns"),
+		overrides:    fs.String("extras_overrides", "", "YAML or JSON file adding or replacing extras per fully-qualified method and options key"),
+		filePattern:  fs.String("file_pattern", "", "generated file name with {proto}, {package} and {key} placeholders, default "+DefaultFilePattern),
+		registerAll:  fs.Bool("register_all", false, "also emit a per-package file registering every service, e.g. RegisterAll<Key>Routes"),
+		split:        fs.String("split", "", "generated Go files: one per proto file (default) or one per service, named by the {service} placeholder"),
+		dispatch:     fs.String("dispatch", "", "how the bot template dispatches requests: map of handlers (default) or switch on the operation"),
+		streaming:    fs.String("streaming", "", "how streaming methods with a rule are handled: skip with a warning (default), error, or include for custom templates"),
+		tracing:      fs.Bool("tracing", false, "let the bot registration take an OpenTelemetry tracer starting a span around every handler"),
+		metrics:      fs.Bool("metrics", false, "let the bot registration take a Prometheus registerer counting requests and errors and observing durations per route"),
+		genericCodec: fs.Bool("generic_codec", false, "make the bot codec, handlers and registration generic in the transport request and response types, the models becoming optional"),
+		generics:     fs.Bool("generics", false, "build the bot handlers from a generic adapter typed by the request and reply messages, and emit typed invoker routes; needs Go 1.21"),
+		errorFormat:  fs.String("error_format", "", "how generation errors are reported: text (default) or json, one object per error"),
+		strictKeys:   fs.Bool("strict_keys", false, "fail instead of warning when a method's rules are all for keys options_key does not select"),
+		descriptors:  fs.Bool("descriptors", false, "expose the protogen service and method to templates as .Descriptor"),
+		validate:     fs.Bool("validate", false, "validate decoded requests through the codec before calling the server, opt out per method with a false validate extra"),
+		routeInfo:    fs.Bool("route_info", false, "also emit a Get<Service><Key>RouteInfo lookup of every operation's command, comment and extras"),
+		mocks:        fs.Bool("mocks", false, "also emit a Mock<Server> stub of every server interface for unit tests"),
+		selfTest:     fs.Bool("self_test", false, "also emit a _test.go checking that routes are unique and their extras valid"),
+		typeRegistry: fs.Bool("type_registry", false, "also emit a map from every operation to its request and reply message types"),
+		invoker:      fs.Bool("invoker", false, "also emit a <Service><Key>Invoker calling the method of an operation with a request message"),
+
+		goPackage:     fs.String("go_package_override", "", "generate the Go files into this package, 'import/path;name' like go_package, instead of the package of the messages"),
+		licenseFile:   fs.String("license_file", "", "file whose text is written as a comment banner at the top of every generated Go file"),
+		buildTags:     fs.String("build_tags", "", "build constraint written as a //go:build line into every generated Go file, e.g. linux && !race"),
+		headerVersion: fs.Bool("header_version", false, "list the protoc-gen-route version in the header of every generated Go file"),
+		versionGuard:  fs.Bool("version_guard", false, fmt.Sprintf("make every generated Go file reference SupportPackageIsVersion%d of the request model package, failing to compile against an older runtime", RuntimeVersion)),
+		guardIdent:    fs.String("version_guard_ident", "", "identifier the version guard references instead, 'import/path;Ident'"),
+		parallel:      fs.Int("parallel", 0, "number of proto files generated concurrently, default GOMAXPROCS"),
+		stats:         fs.Bool("stats", false, "print a summary of the run: files processed, services and methods per key, skipped methods and render time per file"),
+		statsOut:      fs.String("stats_out", "", "write the summary of stats to this file instead of stderr"),
+		cacheDir:      fs.String("cache_dir", "", "directory caching the generated files of every proto file, reused while its descriptors, the template and the parameters are unchanged"),
+
+		requestModel:   fs.String("request_model", "", "request model"),
+		responseModel:  fs.String("response_model", "", "response model"),
+		extraDataModel: fs.String("extra_data_model", "", "extra data model"),
+
+		extraDataConstructor: fs.String("extra_data_constructor", "", "extra data constructor, and return a pointer of extra data"),
+	}
+	fs.Var(&f.vars, "var", "the value of the ${name} placeholders of extra values, 'name=value', e.g. bot_prefix=tenant_; repeat it for several variables")
+	fs.Var(&f.renders, "render", "render every proto file with this template file into this output pattern too, 'template:output' with %s for the proto name, e.g. docs.tmpl:%s_routes.md; repeat it for several templates, replacing template_file and file_pattern")
+	return f
+}
+
+// Set sets the parameter name to value. Names of the form <key>.<param>, where
+// param is one of the model parameters, set that model for one options key
+// only; every other name is set on the bound flag set.
+func (f *Flags) Set(name, value string) error {
+	key, param, ok := strings.Cut(name, ".")
+	if !ok {
+		return f.fs.Set(name, value)
+	}
+	if key == "" || !slices.Contains(keyModelParams, param) {
+		return fmt.Errorf("invalid parameter %q: per-key parameters are <key>.%s", name, strings.Join(keyModelParams, ", <key>."))
+	}
+	if f.keyModels == nil {
+		f.keyModels = make(map[string]map[string]string)
+	}
+	if f.keyModels[key] == nil {
+		f.keyModels[key] = make(map[string]string)
+	}
+	f.keyModels[key][param] = value
+	return nil
+}
+
+// SetKeyArgs sets the <key>.<param> parameters among the command-line args,
+// -bot.request_model=value or -bot.request_model value, with Set, and returns
+// the other args for the flag set to parse, which would reject those as
+// undefined flags. Args after "--" are left alone, as the flag package does.
+func (f *Flags) SetKeyArgs(args []string) ([]string, error) {
+	var rest []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			return append(rest, args[i:]...), nil
+		}
+		name, ok := strings.CutPrefix(arg, "-")
+		if ok {
+			name = strings.TrimPrefix(name, "-")
+		}
+		name, value, hasValue := strings.Cut(name, "=")
+		if !ok || !strings.Contains(name, ".") || f.fs.Lookup(name) != nil {
+			rest = append(rest, arg)
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return nil, fmt.Errorf("flag needs an argument: -%s", name)
+			}
+			i++
+			value = args[i]
+		}
+		if err := f.Set(name, value); err != nil {
+			return nil, err
+		}
+	}
+	return rest, nil
+}
+
+// Renders returns the render parameters set so far.
+func (f *Flags) Renders() []Render {
+	return slices.Clone(f.renders)
+}
+
+// Config builds and validates the Config described by the parsed flags.
+func (f *Flags) Config() (*Config, error) {
+	return f.config(false)
+}
+
+// CheckConfig is Config for Check, which does not render the templates and so
+// does not require their models.
+func (f *Flags) CheckConfig() (*Config, error) {
+	return f.config(true)
+}
+
+func (f *Flags) config(checkOnly bool) (*Config, error) {
+	conf := &Config{
+		checkOnly:    checkOnly,
+		OptionsKey:   *f.optionsKey,
+		Template:     *f.templateName,
+		TemplateFile: *f.templateFile,
+		TemplateDir:  *f.templateDir,
+		Manifest:     *f.manifest,
+		Docs:         *f.docs,
+		I18n:         *f.i18n,
+		UniqueExtras: ParseList(*f.uniqueExtras),
+		IncludeTags:  ParseList(*f.includeTags),
+		ExcludeTags:  ParseList(*f.excludeTags),
+		Env:          *f.env,
+		FilePattern:  *f.filePattern,
+		RegisterAll:  *f.registerAll,
+		Descriptors:  *f.descriptors,
+		TypeRegistry: *f.typeRegistry,
+		Invoker:      *f.invoker,
+		RouteInfo:    *f.routeInfo,
+		Mocks:        *f.mocks,
+		SelfTest:     *f.selfTest,
+
+		ValidateRequests: *f.validate,
+		OptionsExtension: *f.extension,
+		TelegramCommands: *f.telegram,
+		DiscordCommands:  *f.discord,
+		CallbackSchema:   *f.callbacks,
+		TSOutFile:        *f.tsOutFile,
+		Renders:          slices.Clone(f.renders),
+		Vars:             maps.Clone(f.vars),
+		Dispatch:         *f.dispatch,
+		Split:            *f.split,
+		ErrorFormat:      *f.errorFormat,
+		StrictKeys:       *f.strictKeys,
+		Streaming:        *f.streaming,
+		Tracing:          *f.tracing,
+		Metrics:          *f.metrics,
+		GenericCodec:     *f.genericCodec,
+		Generics:         *f.generics,
+		BuildTags:        *f.buildTags,
+		HeaderVersion:    *f.headerVersion,
+		CacheDir:         *f.cacheDir,
+		Parallel:         *f.parallel,
+		Stats:            *f.stats,
+		StatsOut:         *f.statsOut,
+		VersionGuard:     *f.versionGuard,
+	}
+
+	if *f.delims != "" {
+		delims, err := ParseTemplateDelims(*f.delims)
+		if err != nil {
+			return nil, err
+		}
+		conf.TemplateDelims = delims
+	}
+	if *f.extensionNum != 0 {
+		if *f.extensionNum < 1 || *f.extensionNum > int(protowire.MaxValidNumber) {
+			return nil, fmt.Errorf("options_extension_number %d is not a valid field number", *f.extensionNum)
+		}
+		conf.OptionsExtensionNumber = int32(*f.extensionNum)
+	}
+	if *f.dataOnly {
+		conf.Template = DataTemplate
+	}
+	if *f.extrasSchema != "" {
+		schema, err := ReadExtrasSchema(*f.extrasSchema)
+		if err != nil {
+			return nil, err
+		}
+		conf.ExtrasSchema = schema
+	}
+	if *f.overrides != "" {
+		overrides, err := ReadExtrasOverrides(*f.overrides)
+		if err != nil {
+			return nil, err
+		}
+		conf.ExtrasOverrides = overrides
+	}
+	if *f.goPackage != "" {
+		pkg, err := ParseGoPackage(*f.goPackage)
+		if err != nil {
+			return nil, err
+		}
+		conf.GoPackageOverride = pkg
+	}
+	if *f.licenseFile != "" {
+		license, err := os.ReadFile(*f.licenseFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading license_file: %w", err)
+		}
+		conf.License = string(license)
+	}
+
+	if *f.guardIdent != "" {
+		guard, err := ParseGoIdent(*f.guardIdent)
+		if err != nil {
+			return nil, err
+		}
+		conf.VersionGuardIdent = guard
+	}
+
+	if *f.requestModel != "" {
+		requestModel, err := ParseGoIdent(*f.requestModel)
+		if err != nil {
+			return nil, err
+		}
+		conf.RequestType = requestModel
+	}
+	if *f.responseModel != "" {
+		responseModel, err := ParseGoIdent(*f.responseModel)
+		if err != nil {
+			return nil, err
+		}
+		conf.ResponseType = responseModel
+	}
+	if *f.extraDataModel != "" {
+		extraDataModel, err := ParseGoIdent(*f.extraDataModel)
+		if err != nil {
+			return nil, err
+		}
+		conf.ExtraType = extraDataModel
+	}
+	if *f.extraDataConstructor != "" {
+		extraDataConstructor, err := ParseGoIdent(*f.extraDataConstructor)
+		if err != nil {
+			return nil, err
+		}
+		conf.ExtraConstructor = extraDataConstructor
+	}
+	for key, params := range f.keyModels {
+		var models Models
+		for param, dst := range map[string]*protogen.GoIdent{
+			"request_model":          &models.RequestType,
+			"response_model":         &models.ResponseType,
+			"extra_data_model":       &models.ExtraType,
+			"extra_data_constructor": &models.ExtraConstructor,
+		} {
+			raw, ok := params[param]
+			if !ok {
+				continue
+			}
+			id, err := ParseGoIdent(raw)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: %v", key, param, err)
+			}
+			*dst = id
+		}
+		if conf.KeyModels == nil {
+			conf.KeyModels = make(map[string]Models)
+		}
+		conf.KeyModels[key] = models
+	}
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+	return conf, nil
+}
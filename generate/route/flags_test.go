@@ -0,0 +1,198 @@
+package route
+
+import (
+	"flag"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestFlagsConfig(t *testing.T) {
+	fs := flag.NewFlagSet("route", flag.ContinueOnError)
+	flags := BindFlags(fs)
+	params := map[string]string{
+		"options_key":            "bot;job",
+		"manifest":               "json",
+		"unique_extras":          "command;callback_query",
+		"request_model":          exampleRequestType,
+		"response_model":         exampleResponseType,
+		"extra_data_model":       exampleExtraType,
+		"extra_data_constructor": exampleExtraConstructor,
+	}
+	for name, value := range params {
+		if err := fs.Set(name, value); err != nil {
+			t.Fatalf("Set(%s) failed: %v", name, err)
+		}
+	}
+
+	conf, err := flags.Config()
+	if err != nil {
+		t.Fatalf("Config() failed: %v", err)
+	}
+	want := DefaultConfig()
+	want.OptionsKey = "bot;job"
+	want.Template = "bot"
+	want.Manifest = ManifestJSON
+	want.UniqueExtras = []string{"command", "callback_query"}
+	if !reflect.DeepEqual(conf, want) {
+		t.Errorf("Config() = %+v, want %+v", conf, want)
+	}
+}
+
+func TestFlagsKeyModels(t *testing.T) {
+	fs := flag.NewFlagSet("route", flag.ContinueOnError)
+	flags := BindFlags(fs)
+	params := [][2]string{
+		{"options_key", "bot;chat"},
+		{"request_model", exampleRequestType},
+		{"response_model", exampleResponseType},
+		{"chat.request_model", "example.com/chat;Event"},
+		{"chat.extra_data_model", "example.com/chat;Extra"},
+		{"chat.extra_data_constructor", "example.com/chat;NewExtra"},
+	}
+	for _, p := range params {
+		if err := flags.Set(p[0], p[1]); err != nil {
+			t.Fatalf("Set(%s) failed: %v", p[0], err)
+		}
+	}
+	conf, err := flags.Config()
+	if err != nil {
+		t.Fatalf("Config() failed: %v", err)
+	}
+	want := map[string]Models{"chat": {
+		RequestType:      protogen.GoIdent{GoName: "Event", GoImportPath: "example.com/chat"},
+		ExtraType:        protogen.GoIdent{GoName: "Extra", GoImportPath: "example.com/chat"},
+		ExtraConstructor: protogen.GoIdent{GoName: "NewExtra", GoImportPath: "example.com/chat"},
+	}}
+	if !reflect.DeepEqual(conf.KeyModels, want) {
+		t.Errorf("KeyModels = %+v, want %+v", conf.KeyModels, want)
+	}
+
+	for _, name := range []string{"chat.template", ".request_model"} {
+		if err := flags.Set(name, "x"); err == nil {
+			t.Errorf("Set(%s) = nil error, want an error", name)
+		}
+	}
+}
+
+func TestFlagsSetKeyArgs(t *testing.T) {
+	fs := flag.NewFlagSet("route", flag.ContinueOnError)
+	flags := BindFlags(fs)
+	args := []string{
+		"-options_key=bot;chat",
+		"--chat.request_model=example.com/chat;Event",
+		"-chat.response_model", "example.com/chat;Reply",
+		"check",
+		"--", "-chat.extra_data_model=x",
+	}
+	rest, err := flags.SetKeyArgs(args)
+	if err != nil {
+		t.Fatalf("SetKeyArgs failed: %v", err)
+	}
+	wantRest := []string{"-options_key=bot;chat", "check", "--", "-chat.extra_data_model=x"}
+	if !reflect.DeepEqual(rest, wantRest) {
+		t.Errorf("rest = %q, want %q", rest, wantRest)
+	}
+	want := map[string]map[string]string{"chat": {
+		"request_model":  "example.com/chat;Event",
+		"response_model": "example.com/chat;Reply",
+	}}
+	if !reflect.DeepEqual(flags.keyModels, want) {
+		t.Errorf("keyModels = %v, want %v", flags.keyModels, want)
+	}
+
+	for _, args := range [][]string{{"-chat.request_model"}, {"-chat.template=x"}} {
+		if _, err := flags.SetKeyArgs(args); err == nil {
+			t.Errorf("SetKeyArgs(%q) = nil error, want an error", args)
+		}
+	}
+}
+
+func TestFlagsConfigErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		params map[string]string
+	}{
+		{"missing models", map[string]string{}},
+		{"invalid model", map[string]string{"request_model": "Update", "response_model": exampleResponseType}},
+		{"invalid manifest", map[string]string{"template": "job", "manifest": "xml"}},
+		{"extra model without constructor", map[string]string{"template": "job", "extra_data_model": exampleExtraType}},
+		{"invalid key model", map[string]string{"template": "job", "route.request_model": "Update"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := flag.NewFlagSet("route", flag.ContinueOnError)
+			flags := BindFlags(fs)
+			for name, value := range tt.params {
+				if err := flags.Set(name, value); err != nil {
+					t.Fatalf("Set(%s) failed: %v", name, err)
+				}
+			}
+			if _, err := flags.Config(); err == nil {
+				t.Error("Config() = nil error, want an error")
+			}
+		})
+	}
+}
+
+func TestFlagsConfig_ExtraModelWithoutConstructor(t *testing.T) {
+	fs := flag.NewFlagSet("route", flag.ContinueOnError)
+	flags := BindFlags(fs)
+	for name, value := range map[string]string{"template": "job", "extra_data_model": exampleExtraType} {
+		if err := flags.Set(name, value); err != nil {
+			t.Fatalf("Set(%s) failed: %v", name, err)
+		}
+	}
+	_, err := flags.Config()
+	if want := "extra_data_constructor is required with extra_data_model"; err == nil || err.Error() != want {
+		t.Errorf("Config() = %v, want %q", err, want)
+	}
+}
+
+func TestFlagsVars(t *testing.T) {
+	fs := flag.NewFlagSet("route", flag.ContinueOnError)
+	flags := BindFlags(fs)
+	if err := flags.Set("template", "job"); err != nil {
+		t.Fatal(err)
+	}
+	for _, value := range []string{"bot_prefix=acme_", "empty=", "url=https://example.com/?a=b"} {
+		if err := flags.Set("var", value); err != nil {
+			t.Fatalf("Set(var, %q) failed: %v", value, err)
+		}
+	}
+	for _, value := range []string{"bot_prefix=other", "bot-prefix=x", "1st=x", "prefix"} {
+		if err := flags.Set("var", value); err == nil {
+			t.Errorf("Set(var, %q) succeeded", value)
+		}
+	}
+	conf, err := flags.Config()
+	if err != nil {
+		t.Fatalf("Config() failed: %v", err)
+	}
+	want := map[string]string{"bot_prefix": "acme_", "empty": "", "url": "https://example.com/?a=b"}
+	if !reflect.DeepEqual(conf.Vars, want) {
+		t.Errorf("Vars = %v, want %v", conf.Vars, want)
+	}
+}
+
+func TestFlagsRenders(t *testing.T) {
+	fs := flag.NewFlagSet("route", flag.ContinueOnError)
+	flags := BindFlags(fs)
+	for _, value := range []string{"route.tmpl:%s_route.pb.go", "docs.tmpl:%s_routes.md"} {
+		if err := flags.Set("render", value); err != nil {
+			t.Fatalf("Set(render) failed: %v", err)
+		}
+	}
+	if err := flags.Set("render", "route.tmpl"); err == nil {
+		t.Error("Set(render) without an output succeeded")
+	}
+	conf, err := flags.Config()
+	if err != nil {
+		t.Fatalf("Config() failed: %v", err)
+	}
+	want := []Render{{TemplateFile: "route.tmpl", Output: "%s_route.pb.go"}, {TemplateFile: "docs.tmpl", Output: "%s_routes.md"}}
+	if !reflect.DeepEqual(conf.Renders, want) {
+		t.Errorf("Renders = %+v, want %+v", conf.Renders, want)
+	}
+}
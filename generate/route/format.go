@@ -1,10 +1,14 @@
 package route
 
 import (
+	"errors"
 	"fmt"
+	"go/format"
+	"go/scanner"
+	"path"
 	"strings"
-	"unicode"
 
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/types/pluginpb"
 )
@@ -22,24 +26,112 @@ func formatProtocVersion(v *pluginpb.Version) string {
 	return fmt.Sprintf("v%d.%d.%d%s", v.GetMajor(), v.GetMinor(), v.GetPatch(), suffix)
 }
 
+// fileHeader describes the header of a generated Go file.
+type fileHeader struct {
+	ProtocVersion string
+	// PluginVersion is listed under the versions when non-empty.
+	PluginVersion string
+	Source        string
+	Package       string
+	// Deprecated replaces the source line with a deprecation notice.
+	Deprecated bool
+	// License is written as line comments above everything else, and
+	// BuildTags as a //go:build constraint; both are omitted when empty.
+	License   string
+	BuildTags string
+}
+
 // formatFileHeader builds the standard "Code generated ... DO NOT EDIT" header
-// lines, including the package declaration. When deprecated is true the source
-// line is replaced with a deprecation notice.
-func formatFileHeader(version, sourcePath, pkgName string, deprecated bool) []string {
-	lines := []string{
+// lines, including the package declaration.
+func formatFileHeader(h fileHeader) []string {
+	var lines []string
+	if h.License != "" {
+		lines = append(lines, licenseComment(h.License)...)
+		lines = append(lines, "")
+	}
+	if h.BuildTags != "" {
+		lines = append(lines, "//go:build "+h.BuildTags, "")
+	}
+	lines = append(lines,
 		"// Code generated by protoc-gen-route. DO NOT EDIT.",
 		"// versions:",
-		fmt.Sprintf("// - protoc             %s", version),
+	)
+	if h.PluginVersion != "" {
+		lines = append(lines, fmt.Sprintf("// - protoc-gen-route %s", h.PluginVersion))
 	}
-	if deprecated {
-		lines = append(lines, fmt.Sprintf("// %s is a deprecated file.", sourcePath))
+	lines = append(lines, fmt.Sprintf("// - protoc             %s", h.ProtocVersion))
+	if h.Deprecated {
+		lines = append(lines, fmt.Sprintf("// %s is a deprecated file.", h.Source))
 	} else {
-		lines = append(lines, fmt.Sprintf("// source: %s", sourcePath))
+		lines = append(lines, fmt.Sprintf("// source: %s", h.Source))
+	}
+	lines = append(lines, "", fmt.Sprintf("package %s", h.Package), "")
+	return lines
+}
+
+// licenseComment turns license text into line comments. Lines that already
+// are line comments are kept as they are, so a license file written for Go
+// sources can be used unchanged; blank lines become bare "//" lines.
+func licenseComment(license string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(license, "\n"), "\n") {
+		line = strings.TrimRight(line, " \t\r")
+		switch {
+		case strings.HasPrefix(line, "//"):
+			lines = append(lines, line)
+		case line == "":
+			lines = append(lines, "//")
+		default:
+			lines = append(lines, "// "+line)
+		}
 	}
-	lines = append(lines, "", fmt.Sprintf("package %s", pkgName), "")
 	return lines
 }
 
+// filePatternPlaceholders are the placeholders accepted in a file pattern.
+var filePatternPlaceholders = []string{"{proto}", "{package}", "{key}", "{service}"}
+
+// formatFilename expands a file pattern for one proto file. prefix is the
+// file's GeneratedFilenamePrefix: its directory is kept and its base name
+// replaces {proto}. {package} is the Go package name, {key} the lower-cased
+// options key and {service} the snake_case Go name of service, which is only
+// set when services are split into files of their own. An empty pattern
+// selects DefaultFilePattern, or DefaultServiceFilePattern for a service.
+func formatFilename(pattern, prefix, goPackage, optionsKey, service string) string {
+	if pattern == "" {
+		pattern = DefaultFilePattern
+		if service != "" {
+			pattern = DefaultServiceFilePattern
+		}
+	}
+	name := strings.NewReplacer(
+		"{proto}", path.Base(prefix),
+		"{package}", goPackage,
+		"{key}", strings.ToLower(optionsKey),
+		"{service}", template.SnakeCase(service),
+	).Replace(pattern)
+	return path.Join(path.Dir(prefix), name)
+}
+
+// validateFilePattern rejects file patterns with unknown placeholders or that
+// escape the proto file's output directory.
+func validateFilePattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	rest := pattern
+	for _, placeholder := range filePatternPlaceholders {
+		rest = strings.ReplaceAll(rest, placeholder, "")
+	}
+	if strings.ContainsAny(rest, "{}") {
+		return fmt.Errorf("invalid file_pattern %q: placeholders are %s", pattern, strings.Join(filePatternPlaceholders, ", "))
+	}
+	if strings.Contains(pattern, "/") || strings.Contains(pattern, "\\") {
+		return fmt.Errorf("invalid file_pattern %q: must not contain a path separator", pattern)
+	}
+	return nil
+}
+
 // formatMethodComment formats a method's leading proto comment into Go doc
 // comment lines, prefixing the first line with the method name. Empty input
 // yields an empty string.
@@ -61,22 +153,70 @@ func formatMethodComment(methodName, leading string) string {
 	return strings.Join(lines, "\n")
 }
 
-// pascalCase converts a space/underscore/dash separated string into PascalCase.
-// It is used to turn the options key (e.g. "callback_query") into a Go-friendly
-// identifier fragment (e.g. "CallbackQuery").
-func pascalCase(s string) string {
-	words := strings.FieldsFunc(s, func(r rune) bool {
-		return r == ' ' || r == '_' || r == '-'
-	})
-	var result strings.Builder
-	for _, word := range words {
-		if word == "" {
-			continue
+// plainComment trims a proto comment into plain text: every line is trimmed,
+// blank lines are dropped, and the remaining lines are joined with "\n".
+func plainComment(comment string) string {
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// splitComment splits a proto comment into its summary, the first non-blank
+// line, and its description, the remaining lines. Every line is trimmed; the
+// description keeps paragraph breaks as single blank lines.
+func splitComment(comment string) (summary, description string) {
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(comment, "\n") {
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "":
+			blank = true
+		case summary == "":
+			summary = line
+		default:
+			if blank && len(lines) > 0 {
+				lines = append(lines, "")
+			}
+			blank = false
+			lines = append(lines, line)
+		}
+	}
+	return summary, strings.Join(lines, "\n")
+}
+
+// plainComments converts detached proto comments into plain text, dropping
+// empty ones.
+func plainComments(comments []protogen.Comments) []string {
+	var list []string
+	for _, comment := range comments {
+		if text := plainComment(string(comment)); text != "" {
+			list = append(list, text)
 		}
-		word = strings.ToLower(word)
-		result.WriteString(string(unicode.ToUpper(rune(word[0]))) + word[1:])
 	}
-	return result.String()
+	return list
+}
+
+// operationName builds the exported operation constant name for a method, e.g.
+// "OperationBotMenuServiceUpdateCount". optionsKey is expected in PascalCase.
+func operationName(optionsKey, serviceType, methodName string) string {
+	return "Operation" + optionsKey + serviceType + methodName
+}
+
+// serverName builds the server interface name for a service, e.g.
+// "MenuServiceBotServer". optionsKey is expected in PascalCase.
+func serverName(serviceType, optionsKey string) string {
+	return serviceType + optionsKey + "Server"
+}
+
+// operationPath builds the operation constant value for a method in the
+// "/<full service name>/<method>" form, e.g. "/bot.v1.MenuService/UpdateCount".
+func operationPath(serviceName, methodName string) string {
+	return "/" + serviceName + "/" + methodName
 }
 
 // importKeepAlives returns the config idents that must be referenced via
@@ -102,3 +242,39 @@ func importKeepAlives(conf *Config) (newRefs []protogen.GoIdent, exprRefs []prot
 	add(&exprRefs, conf.ExtraConstructor)
 	return newRefs, exprRefs
 }
+
+// formatRendered gofmt-formats the code one template execution produced for
+// what, e.g. a service name. A syntax error is reported with the template name
+// and the offending rendered lines, instead of surfacing later as an
+// unparsable generated file.
+func formatRendered(templateName, what, content string) (string, error) {
+	formatted, err := format.Source([]byte(content))
+	if err == nil {
+		return string(formatted), nil
+	}
+	line := 0
+	var list scanner.ErrorList
+	if errors.As(err, &list) && len(list) > 0 {
+		line = list[0].Pos.Line
+	}
+	return "", fmt.Errorf("template %q rendered invalid Go for %s: %v%s", templateName, what, err, sourceContext(content, line, 2))
+}
+
+// sourceContext returns the lines of src around the 1-based line, each
+// prefixed with its number and the offending one marked with '>'. A line
+// outside src yields "".
+func sourceContext(src string, line, radius int) string {
+	lines := strings.Split(src, "\n")
+	if line < 1 || line > len(lines) {
+		return ""
+	}
+	var b strings.Builder
+	for i := max(line-radius, 1); i <= min(line+radius, len(lines)); i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		fmt.Fprintf(&b, "\n%s %4d | %s", marker, i, lines[i-1])
+	}
+	return b.String()
+}
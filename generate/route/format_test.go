@@ -2,6 +2,7 @@ package route
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"google.golang.org/protobuf/compiler/protogen"
@@ -29,7 +30,7 @@ func TestFormatProtocVersion(t *testing.T) {
 }
 
 func TestFormatFileHeader(t *testing.T) {
-	got := formatFileHeader("v5.29.0", "api/v1/foo.proto", "foov1", false)
+	got := formatFileHeader(fileHeader{ProtocVersion: "v5.29.0", Source: "api/v1/foo.proto", Package: "foov1"})
 	want := []string{
 		"// Code generated by protoc-gen-route. DO NOT EDIT.",
 		"// versions:",
@@ -43,10 +44,38 @@ func TestFormatFileHeader(t *testing.T) {
 		t.Errorf("formatFileHeader() = %#v, want %#v", got, want)
 	}
 
-	dep := formatFileHeader("v5.29.0", "api/v1/foo.proto", "foov1", true)
+	dep := formatFileHeader(fileHeader{ProtocVersion: "v5.29.0", Source: "api/v1/foo.proto", Package: "foov1", Deprecated: true})
 	if dep[3] != "// api/v1/foo.proto is a deprecated file." {
 		t.Errorf("deprecated header line = %q", dep[3])
 	}
+
+	full := formatFileHeader(fileHeader{
+		ProtocVersion: "v5.29.0",
+		PluginVersion: "v0.0.1",
+		Source:        "api/v1/foo.proto",
+		Package:       "foov1",
+		License:       "Copyright 2026 Example Inc.\n\n// SPDX-License-Identifier: MIT\n",
+		BuildTags:     "linux && !race",
+	})
+	want = []string{
+		"// Copyright 2026 Example Inc.",
+		"//",
+		"// SPDX-License-Identifier: MIT",
+		"",
+		"//go:build linux && !race",
+		"",
+		"// Code generated by protoc-gen-route. DO NOT EDIT.",
+		"// versions:",
+		"// - protoc-gen-route v0.0.1",
+		"// - protoc             v5.29.0",
+		"// source: api/v1/foo.proto",
+		"",
+		"package foov1",
+		"",
+	}
+	if !reflect.DeepEqual(full, want) {
+		t.Errorf("formatFileHeader() with license and build tags = %#v, want %#v", full, want)
+	}
 }
 
 func TestFormatMethodComment(t *testing.T) {
@@ -70,25 +99,78 @@ func TestFormatMethodComment(t *testing.T) {
 	}
 }
 
-func TestPascalCase(t *testing.T) {
+func TestPlainComment(t *testing.T) {
+	if got := plainComment(" UpdateCount updates the menu counter.\n\n It is triggered by start.\n"); got != "UpdateCount updates the menu counter.\nIt is triggered by start." {
+		t.Errorf("plainComment() = %q", got)
+	}
+	if got := plainComment(""); got != "" {
+		t.Errorf("plainComment(\"\") = %q", got)
+	}
+}
+
+func TestSplitComment(t *testing.T) {
 	tests := []struct {
-		in   string
-		want string
+		in, summary, description string
 	}{
-		{"", ""},
-		{"route", "Route"},
-		{"callback_query", "CallbackQuery"},
-		{"bot-command", "BotCommand"},
-		{"multi word key", "MultiWordKey"},
-		{"ALLCAPS", "Allcaps"},
-		{"__leading", "Leading"},
+		{"", "", ""},
+		{" Start begins.\n", "Start begins.", ""},
+		{"\n Start begins.\n It greets\n the user.\n", "Start begins.", "It greets\nthe user."},
+		{" Start begins.\n\n First paragraph.\n\n\n Second paragraph.\n\n", "Start begins.", "First paragraph.\n\nSecond paragraph."},
 	}
 	for _, tt := range tests {
-		t.Run(tt.in, func(t *testing.T) {
-			if got := pascalCase(tt.in); got != tt.want {
-				t.Errorf("pascalCase(%q) = %q, want %q", tt.in, got, tt.want)
-			}
-		})
+		summary, description := splitComment(tt.in)
+		if summary != tt.summary || description != tt.description {
+			t.Errorf("splitComment(%q) = %q, %q, want %q, %q", tt.in, summary, description, tt.summary, tt.description)
+		}
+	}
+}
+
+func TestOperationNameAndPath(t *testing.T) {
+	if got := operationName("Bot", "MenuService", "UpdateCount"); got != "OperationBotMenuServiceUpdateCount" {
+		t.Errorf("operationName() = %q", got)
+	}
+	if got := serverName("MenuService", "Bot"); got != "MenuServiceBotServer" {
+		t.Errorf("serverName() = %q", got)
+	}
+	if got := operationPath("bot.v1.MenuService", "UpdateCount"); got != "/bot.v1.MenuService/UpdateCount" {
+		t.Errorf("operationPath() = %q", got)
+	}
+}
+
+func TestFormatFilename(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    string
+	}{
+		{"", "api/bot/v1/menu.bot.pb.go"},
+		{"{proto}_{key}.route.go", "api/bot/v1/menu_bot.route.go"},
+		{"{package}.{proto}.{key}.go", "api/bot/v1/botv1.menu.bot.go"},
+	}
+	for _, tt := range tests {
+		if got := formatFilename(tt.pattern, "api/bot/v1/menu", "botv1", "Bot", ""); got != tt.want {
+			t.Errorf("formatFilename(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+	for pattern, want := range map[string]string{
+		"":                           "api/bot/v1/menu_service.bot.pb.go",
+		"{proto}.{service}.{key}.go": "api/bot/v1/menu.menu_service.bot.go",
+	} {
+		if got := formatFilename(pattern, "api/bot/v1/menu", "botv1", "Bot", "MenuService"); got != want {
+			t.Errorf("formatFilename(%q, MenuService) = %q, want %q", pattern, got, want)
+		}
+	}
+}
+
+func TestValidateFilePattern(t *testing.T) {
+	for _, pattern := range []string{"", DefaultFilePattern, "{proto}_{key}.route.go"} {
+		if err := validateFilePattern(pattern); err != nil {
+			t.Errorf("validateFilePattern(%q) = %v, want nil", pattern, err)
+		}
+	}
+	for _, pattern := range []string{"{name}.go", "{proto.go", "gen/{proto}.go"} {
+		if err := validateFilePattern(pattern); err == nil {
+			t.Errorf("validateFilePattern(%q) = nil, want an error", pattern)
+		}
 	}
 }
 
@@ -143,3 +225,28 @@ func TestImportKeepAlives(t *testing.T) {
 		}
 	})
 }
+
+func TestFormatRendered(t *testing.T) {
+	got, err := formatRendered("bot", "bot.v1.MenuService", "const (\n  A = 1\n)\n")
+	if err != nil {
+		t.Fatalf("formatRendered() failed: %v", err)
+	}
+	if want := "const (\n\tA = 1\n)\n"; got != want {
+		t.Errorf("formatRendered() = %q, want %q", got, want)
+	}
+
+	_, err = formatRendered("bot", "bot.v1.MenuService", "const A = 1\n\nfunc Broken( {\n}\n\nvar B = 2\n")
+	if err == nil {
+		t.Fatal("formatRendered() = nil error, want a syntax error")
+	}
+	for _, want := range []string{
+		`template "bot" rendered invalid Go for bot.v1.MenuService: 3:`,
+		"\n>    3 | func Broken( {",
+		"\n     1 | const A = 1",
+		"\n     4 | }",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+}
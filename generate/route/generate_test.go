@@ -1,8 +1,10 @@
 package route
 
 import (
+	"slices"
 	"testing"
 
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
@@ -100,3 +102,77 @@ func TestGenerateFile_NoOptions(t *testing.T) {
 		t.Error("expected nil for service without options, got non-nil")
 	}
 }
+
+// TestDeclareSupport verifies that the plugin advertises proto3 optional and
+// editions support through the latest edition protobuf-go handles.
+func TestDeclareSupport(t *testing.T) {
+	plugin := newPlugin(t, &descriptorpb.FileDescriptorProto{
+		Name:    proto.String("empty.proto"),
+		Syntax:  proto.String("proto3"),
+		Options: &descriptorpb.FileOptions{GoPackage: proto.String("github.com/example/api;api")},
+	})
+	DeclareSupport(plugin)
+
+	want := uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+	if plugin.SupportedFeatures != want {
+		t.Errorf("SupportedFeatures = %d, want %d", plugin.SupportedFeatures, want)
+	}
+	if plugin.SupportedEditionsMinimum != descriptorpb.Edition_EDITION_PROTO2 || plugin.SupportedEditionsMaximum != descriptorpb.Edition_EDITION_2024 {
+		t.Errorf("supported editions = %v..%v, want EDITION_PROTO2..EDITION_2024", plugin.SupportedEditionsMinimum, plugin.SupportedEditionsMaximum)
+	}
+	resp := plugin.Response()
+	if resp.GetMinimumEdition() != int32(descriptorpb.Edition_EDITION_PROTO2) || resp.GetMaximumEdition() != int32(descriptorpb.Edition_EDITION_2024) {
+		t.Errorf("response editions = %d..%d", resp.GetMinimumEdition(), resp.GetMaximumEdition())
+	}
+}
+
+// TestMethodNums verifies that methods sharing a Go name are numbered in
+// service full name order, counting methods without a rule and ignoring the
+// declaration order, and that only they get the number in their unique name.
+func TestMethodNums(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/duplicate_names.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "duplicate_names.proto"))
+
+	want := map[string]methodNum{
+		"testdata.duplicatenames.v1.AlphaService.Ping":   {0, "Ping0"},
+		"testdata.duplicatenames.v1.AlphaService.Status": {0, "Status"},
+		"testdata.duplicatenames.v1.BetaService.Ping":    {1, "Ping1"},
+		"testdata.duplicatenames.v1.ZetaService.Ping":    {2, "Ping2"},
+	}
+	check := func(t *testing.T, services []*protogen.Service) {
+		t.Helper()
+		nums := methodNums(services)
+		for _, service := range services {
+			for _, method := range service.Methods {
+				if got := nums[method]; got != want[string(method.Desc.FullName())] {
+					t.Errorf("methodNums()[%s] = %+v, want %+v", method.Desc.FullName(), got, want[string(method.Desc.FullName())])
+				}
+			}
+		}
+	}
+	check(t, file.Services)
+
+	t.Run("reordered", func(t *testing.T) {
+		services := slices.Clone(file.Services)
+		slices.Reverse(services)
+		for _, service := range services {
+			methods := service.Methods
+			t.Cleanup(func() { service.Methods = methods })
+			service.Methods = slices.Clone(methods)
+			slices.Reverse(service.Methods)
+		}
+		check(t, services)
+	})
+
+	t.Run("clash", func(t *testing.T) {
+		// A method whose Go name is a numbered unique name keeps it; the
+		// shared name steps aside.
+		status := file.Services[0].Methods[slices.IndexFunc(file.Services[0].Methods, func(m *protogen.Method) bool { return m.GoName == "Status" })]
+		goName := status.GoName
+		status.GoName = "Ping0"
+		t.Cleanup(func() { status.GoName = goName })
+		want["testdata.duplicatenames.v1.AlphaService.Status"] = methodNum{0, "Ping0"}
+		want["testdata.duplicatenames.v1.AlphaService.Ping"] = methodNum{0, "Ping0_"}
+		check(t, file.Services)
+	})
+}
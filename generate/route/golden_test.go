@@ -1,15 +1,19 @@
 package route
 
 import (
+	"bytes"
 	"flag"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 var updateGolden = flag.Bool("update-golden", false, "update golden files")
@@ -20,6 +24,7 @@ type goldenCase struct {
 	protoName  string // proto path inside the descriptor set
 	wantFile   bool   // whether a generated file is expected
 	goldenFile string // testdata/golden/<name>.route.pb.go (empty when wantFile is false)
+	template   string // built-in template name (empty selects the default)
 	config     func() *Config
 }
 
@@ -68,6 +73,615 @@ func goldenCases() []goldenCase {
 				return c
 			},
 		},
+		{
+			// Service-level defaults declared through a ServiceOptions extension
+			// are merged into every method, with method values winning.
+			name:       "service_defaults",
+			pbFile:     "testdata/pb/service_defaults.pb",
+			protoName:  "service_defaults.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/service_defaults.route.pb.go",
+		},
+		{
+			name:       "template_job",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/template_job.route.pb.go",
+			template:   "job",
+		},
+		{
+			// cron extras add a job runner with Start and Stop, applying the
+			// timeout and retry extras of its jobs.
+			name:       "job_runner",
+			pbFile:     "testdata/pb/jobs.pb",
+			protoName:  "jobs.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/job_runner.route.pb.go",
+			template:   "job",
+		},
+		{
+			// The mq template requires a topic extra on every method.
+			name:       "template_mq",
+			pbFile:     "testdata/pb/mq.pb",
+			protoName:  "mq.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/template_mq.route.pb.go",
+			template:   "mq",
+		},
+		{
+			name:       "template_minimal",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/template_minimal.route.pb.go",
+			template:   "minimal",
+		},
+		{
+			name:       "template_data",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/template_data.route.pb.go",
+			template:   DataTemplate,
+		},
+		{
+			// ReportService.Daily carries rules for both "route" and "job"; each
+			// key generates it independently with its own extras.
+			name:       "multi_key",
+			pbFile:     "testdata/pb/multi_key.pb",
+			protoName:  "multi_key.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/multi_key.route.pb.go",
+		},
+		{
+			name:       "multi_key_job",
+			pbFile:     "testdata/pb/multi_key.pb",
+			protoName:  "multi_key.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/multi_key_job.route.pb.go",
+			template:   "job",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.OptionsKey = "job"
+				return c
+			},
+		},
+		{
+			// Request and reply types come from two packages both named "v1",
+			// so the imports must be aliased apart.
+			name:       "cross_package",
+			pbFile:     "testdata/pb/cross_package.pb",
+			protoName:  "cross_package.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/cross_package.route.pb.go",
+		},
+		{
+			// callback_query_pattern extras produce a compiled pattern table
+			// and a matcher.
+			name:       "callback_pattern",
+			pbFile:     "testdata/pb/callback_pattern.pb",
+			protoName:  "callback_pattern.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/callback_pattern.route.pb.go",
+		},
+		{
+			// Methods sharing a Go name are numbered in service full name
+			// order, counting unrouted methods, whatever the declaration order.
+			name:       "duplicate_names",
+			pbFile:     "testdata/pb/duplicate_names.pb",
+			protoName:  "duplicate_names.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/duplicate_names.route.pb.go",
+		},
+		{
+			// error_domain extras produce a sphere error type and a mapper,
+			// the default error handler of the registration function.
+			name:       "error_domain",
+			pbFile:     "testdata/pb/error_domain.pb",
+			protoName:  "error_domain.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/error_domain.route.pb.go",
+		},
+		{
+			// args extras produce a binder of command arguments to request
+			// fields for every scalar kind.
+			name:       "args",
+			pbFile:     "testdata/pb/args.pb",
+			protoName:  "args.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/args.route.pb.go",
+		},
+		{
+			// A buttons extra produces a keyboard builder whose buttons carry
+			// the callback data of the methods they route to.
+			name:       "keyboard",
+			pbFile:     "testdata/pb/keyboard.pb",
+			protoName:  "keyboard.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/keyboard.route.pb.go",
+		},
+		{
+			// A dispatch_oneof extra produces a handler interface with one
+			// method per oneof field and a dispatcher switching on the set
+			// field.
+			name:       "dispatch",
+			pbFile:     "testdata/pb/dispatch.pb",
+			protoName:  "dispatch.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/dispatch.route.pb.go",
+		},
+		{
+			// Methods with a true skip extra are left out, and a service
+			// with only skipped methods generates nothing.
+			name:       "skip",
+			pbFile:     "testdata/pb/skip.pb",
+			protoName:  "skip.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/skip.route.pb.go",
+		},
+		{
+			// var values replace the ${name} placeholders of extra values;
+			// $${ stays a literal ${.
+			name:       "vars",
+			pbFile:     "testdata/pb/vars.pb",
+			protoName:  "vars.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/vars.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Vars = map[string]string{"bot_prefix": "acme_", "currency": "EUR"}
+				return c
+			},
+		},
+		{
+			// exclude_tags leaves out the methods listing an excluded tag.
+			name:       "exclude_tags",
+			pbFile:     "testdata/pb/tags.pb",
+			protoName:  "tags.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/exclude_tags.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.ExcludeTags = []string{"experimental"}
+				return c
+			},
+		},
+		{
+			// include_tags keeps only the methods listing an included tag.
+			name:       "include_tags",
+			pbFile:     "testdata/pb/tags.pb",
+			protoName:  "tags.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/include_tags.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.IncludeTags = []string{"payments"}
+				return c
+			},
+		},
+		{
+			// env=staging leaves out the methods whose env extra does not
+			// list staging and keeps those without one.
+			name:       "env",
+			pbFile:     "testdata/pb/env.pb",
+			protoName:  "env.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/env.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Env = "staging"
+				return c
+			},
+		},
+		{
+			// dispatch=switch replaces the handler map with a dispatch
+			// function switching on the operation.
+			name:       "dispatch_switch",
+			pbFile:     "testdata/pb/complex.pb",
+			protoName:  "complex.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/dispatch_switch.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Dispatch = DispatchSwitch
+				return c
+			},
+		},
+		{
+			// rate_limit extras produce a table of limits and a limiter
+			// option consulted before the handlers of limited operations.
+			name:       "rate_limit",
+			pbFile:     "testdata/pb/rate_limit.pb",
+			protoName:  "rate_limit.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/rate_limit.route.pb.go",
+		},
+		{
+			// roles and permission extras produce their accessors and an
+			// authorizer option guarding the handlers, outside the limiter.
+			name:       "roles",
+			pbFile:     "testdata/pb/roles.pb",
+			protoName:  "roles.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/roles.route.pb.go",
+		},
+		{
+			// The deprecated method option and the deprecated extra mark
+			// constants and server methods deprecated and add a warning
+			// option reporting the first dispatch of their handlers.
+			name:       "deprecated",
+			pbFile:     "testdata/pb/deprecated.pb",
+			protoName:  "deprecated.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/deprecated.route.pb.go",
+		},
+		{
+			// feature_flag extras add a feature gate option consulted before
+			// registering the gated handlers.
+			name:       "feature_flag",
+			pbFile:     "testdata/pb/feature_flag.pb",
+			protoName:  "feature_flag.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/feature_flag.route.pb.go",
+		},
+		{
+			// With dispatch=switch, a dispatcher consulting the feature gate
+			// wraps the dispatch function.
+			name:       "feature_flag_switch",
+			pbFile:     "testdata/pb/feature_flag.pb",
+			protoName:  "feature_flag.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/feature_flag_switch.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Dispatch = DispatchSwitch
+				return c
+			},
+		},
+		{
+			// A gated method mounted by group, chat type and middleware goes
+			// through the options, and so the feature gate, of Register<Server>.
+			name:       "gated_groups",
+			pbFile:     "testdata/pb/gated_groups.pb",
+			protoName:  "gated_groups.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/gated_groups.route.pb.go",
+		},
+		{
+			// tracing adds a tracer option starting a span around every
+			// handler, with the extras as attributes.
+			name:       "tracing",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/tracing.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Tracing = true
+				return c
+			},
+		},
+		{
+			// metrics adds a Prometheus registerer option recording request
+			// metrics per operation, next to tracing.
+			name:       "metrics",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/metrics.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Metrics = true
+				c.Tracing = true
+				return c
+			},
+		},
+		{
+			// type_registry maps every operation to its message types,
+			// including messages imported from other packages.
+			name:       "type_registry",
+			pbFile:     "testdata/pb/cross_package.pb",
+			protoName:  "cross_package.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/type_registry.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.TypeRegistry = true
+				return c
+			},
+		},
+		{
+			// A command extra listing aliases routes each of them to the
+			// method, and a name extra renames an operation constant.
+			name:       "aliases",
+			pbFile:     "testdata/pb/aliases.pb",
+			protoName:  "aliases.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/aliases.route.pb.go",
+		},
+		{
+			// validate=true checks decoded requests through the codec, except
+			// for methods with a false validate extra.
+			name:       "validate",
+			pbFile:     "testdata/pb/validate.pb",
+			protoName:  "validate.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/validate.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.ValidateRequests = true
+				return c
+			},
+		},
+		{
+			name:       "validate_mq",
+			pbFile:     "testdata/pb/validate.pb",
+			protoName:  "validate.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/validate_mq.route.pb.go",
+			template:   "mq",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.ValidateRequests = true
+				return c
+			},
+		},
+		{
+			// Without validate=true the validate extra changes nothing.
+			name:       "validate_disabled",
+			pbFile:     "testdata/pb/validate.pb",
+			protoName:  "validate.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/validate_disabled.route.pb.go",
+		},
+		{
+			// middleware extras produce a registration function wrapping
+			// the handlers in the named middleware.
+			name:       "middleware",
+			pbFile:     "testdata/pb/middleware.pb",
+			protoName:  "middleware.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/middleware.route.pb.go",
+		},
+		{
+			// route_info emits the metadata lookup of every operation.
+			name:       "route_info",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/route_info.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.RouteInfo = true
+				return c
+			},
+		},
+		{
+			// invoker emits a client interface and an invoker calling its
+			// methods by operation.
+			name:       "invoker",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/invoker.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Invoker = true
+				return c
+			},
+		},
+		{
+			name:       "version_guard",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/version_guard.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.VersionGuard = true
+				return c
+			},
+		},
+		{
+			name:       "custom_extension",
+			pbFile:     "testdata/pb/custom_extension.pb",
+			protoName:  "custom_extension.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/custom_extension.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.OptionsExtension = "testdata.custom_extension.v1.route"
+				return c
+			},
+		},
+		{
+			// timeout and retry extras become typed route info fields.
+			name:       "timeout",
+			pbFile:     "testdata/pb/timeout.pb",
+			protoName:  "timeout.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/timeout.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.RouteInfo = true
+				return c
+			},
+		},
+		{
+			// group extras add a registration function per group.
+			name:       "groups",
+			pbFile:     "testdata/pb/groups.pb",
+			protoName:  "groups.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/groups.route.pb.go",
+		},
+		{
+			// chat_type extras key the command table on the chat type and add
+			// a registration function taking a router per chat type.
+			name:       "chat_type",
+			pbFile:     "testdata/pb/chat_type.pb",
+			protoName:  "chat_type.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/chat_type.route.pb.go",
+		},
+		{
+			// mocks emits a stub of every server interface, here with
+			// request types imported from another package.
+			name:       "mocks",
+			pbFile:     "testdata/pb/duplicate.pb",
+			protoName:  "duplicate.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/mocks.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Mocks = true
+				return c
+			},
+		},
+		{
+			// priority extras order registration and pattern matching.
+			name:       "priority",
+			pbFile:     "testdata/pb/priority.pb",
+			protoName:  "priority.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/priority.route.pb.go",
+		},
+		{
+			// license_file, build_tags and header_version customize the header.
+			name:       "header",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/header.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.License = "Copyright 2026 The go-sphere Authors.\nSPDX-License-Identifier: MIT\n"
+				c.BuildTags = "linux && !race"
+				c.HeaderVersion = true
+				c.Version = "v0.0.1"
+				return c
+			},
+		},
+		{
+			// An edition 2023 file generates like its proto3 equivalent.
+			name:       "editions",
+			pbFile:     "testdata/pb/editions.pb",
+			protoName:  "editions.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/editions.route.pb.go",
+		},
+		{
+			// generic_codec with the request and response models: the routes
+			// are generic and a DefaultCodec alias instantiates the codec.
+			name:       "generic_codec",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/generic_codec.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.GenericCodec = true
+				return c
+			},
+		},
+		{
+			// generic_codec without models, through the limiter, authorizer,
+			// error domains, groups, chat types and middleware.
+			name:       "generic_codec_features",
+			pbFile:     "testdata/pb/generic_codec.pb",
+			protoName:  "generic_codec.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/generic_codec_features.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.GenericCodec = true
+				c.RequestType, c.ResponseType = protogen.GoIdent{}, protogen.GoIdent{}
+				return c
+			},
+		},
+		{
+			// dispatch=switch cannot honor the groups and middleware of
+			// generic_codec.proto, so chat types stand in for the features.
+			name:       "generic_codec_switch",
+			pbFile:     "testdata/pb/chat_type.pb",
+			protoName:  "chat_type.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/generic_codec_switch.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.GenericCodec = true
+				c.Dispatch = DispatchSwitch
+				c.RequestType, c.ResponseType = protogen.GoIdent{}, protogen.GoIdent{}
+				return c
+			},
+		},
+		{
+			// generics builds the handlers from a typed adapter, validating the
+			// methods that opt in, and the invoker emits typed routes.
+			name:       "generics",
+			pbFile:     "testdata/pb/validate.pb",
+			protoName:  "validate.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/generics.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Generics = true
+				c.ValidateRequests = true
+				c.Invoker = true
+				return c
+			},
+		},
+		{
+			name:       "generics_switch",
+			pbFile:     "testdata/pb/validate.pb",
+			protoName:  "validate.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/generics_switch.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Generics = true
+				c.ValidateRequests = true
+				c.Dispatch = DispatchSwitch
+				return c
+			},
+		},
+		{
+			// generics with generic_codec: the adapter also takes the
+			// transport types.
+			name:       "generics_generic_codec",
+			pbFile:     "testdata/pb/basic.pb",
+			protoName:  "basic.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/generics_generic_codec.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Generics = true
+				c.GenericCodec = true
+				return c
+			},
+		},
+		{
+			name:       "foreign_fields",
+			pbFile:     "testdata/pb/callback_schema.pb",
+			protoName:  "callback_schema.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/callback_schema.route.pb.go",
+		},
+		{
+			// mocks_services stubs two services of a file declaring a method
+			// of the same name.
+			name:       "mocks_services",
+			pbFile:     "testdata/pb/complex.pb",
+			protoName:  "complex.proto",
+			wantFile:   true,
+			goldenFile: "testdata/golden/mocks_services.route.pb.go",
+			config: func() *Config {
+				c := DefaultConfig()
+				c.Mocks = true
+				return c
+			},
+		},
 		{
 			name:      "no_options",
 			pbFile:    "testdata/pb/no_options.pb",
@@ -89,6 +703,13 @@ func (tt goldenCase) generate(t *testing.T) []byte {
 	if tt.config != nil {
 		cfg = tt.config()
 	}
+	if cfg.Template == "" {
+		cfg.Template = tt.template
+	}
+	if err := UseBuiltinTemplate(tt.template); err != nil {
+		t.Fatalf("UseBuiltinTemplate(%s) failed: %v", tt.name, err)
+	}
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
 
 	genFile, err := GenerateFile(plugin, file, cfg)
 	if err != nil {
@@ -119,24 +740,7 @@ func TestGolden(t *testing.T) {
 				t.Fatal("expected a generated file, got nil")
 			}
 
-			if *updateGolden {
-				if err := os.MkdirAll(filepath.Dir(tt.goldenFile), 0o755); err != nil {
-					t.Fatal(err)
-				}
-				if err := os.WriteFile(tt.goldenFile, content, 0o644); err != nil {
-					t.Fatal(err)
-				}
-				t.Logf("updated golden file: %s", tt.goldenFile)
-				return
-			}
-
-			expected, err := os.ReadFile(tt.goldenFile)
-			if err != nil {
-				t.Fatalf("failed to read golden file (run `make update-golden` to create): %v", err)
-			}
-			if diff := firstDiff(string(expected), string(content)); diff != "" {
-				t.Errorf("generated content mismatch for %s (run `make update-golden` to refresh):\n%s", tt.name, diff)
-			}
+			compareGolden(t, tt.goldenFile, content)
 		})
 	}
 }
@@ -159,6 +763,57 @@ func TestGoldenDeterministic(t *testing.T) {
 	}
 }
 
+// TestGenerateFile_DeclarationOrder verifies that reordering services and
+// methods in the descriptor does not change the generated file.
+func TestGenerateFile_DeclarationOrder(t *testing.T) {
+	generate := func(reverse bool) string {
+		set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+		plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+		file := testutil.FileToGenerate(t, plugin)
+		if reverse {
+			slices.Reverse(file.Services)
+			for _, service := range file.Services {
+				slices.Reverse(service.Methods)
+			}
+		}
+		genFile, err := GenerateFile(plugin, file, DefaultConfig())
+		if err != nil {
+			t.Fatalf("GenerateFile failed: %v", err)
+		}
+		content, err := genFile.Content()
+		if err != nil {
+			t.Fatalf("Content failed: %v", err)
+		}
+		return string(content)
+	}
+	if declared, reversed := generate(false), generate(true); declared != reversed {
+		t.Errorf("output depends on declaration order:\n%s", firstDiff(declared, reversed))
+	}
+}
+
+// compareGolden compares content with the golden file, rewriting it when
+// -update-golden is set.
+func compareGolden(t *testing.T, goldenFile string, content []byte) {
+	t.Helper()
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(goldenFile, content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		t.Logf("updated golden file: %s", goldenFile)
+		return
+	}
+	expected, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("failed to read golden file (run `make update-golden` to create): %v", err)
+	}
+	if diff := firstDiff(string(expected), string(content)); diff != "" {
+		t.Errorf("content mismatch for %s (run `make update-golden` to refresh):\n%s", goldenFile, diff)
+	}
+}
+
 // firstDiff returns a human-readable description of the first differing line
 // between want and got, or "" when they are equal. It avoids pulling in
 // github.com/google/go-cmp as a module dependency.
@@ -175,3 +830,698 @@ func firstDiff(want, got string) string {
 	}
 	return fmt.Sprintf("line count differs: want %d lines, got %d lines", len(wl), len(gl))
 }
+
+// TestGenerateFile_MultipleKeys verifies that generating several options keys
+// in one plugin run emits one file per key, each named after its key.
+func TestGenerateFile_MultipleKeys(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	keys, err := ParseOptionsKeys("route;bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := DefaultConfig()
+	for _, key := range keys {
+		if _, err := GenerateFile(plugin, file, conf.ForKey(key)); err != nil {
+			t.Fatalf("GenerateFile(%s) failed: %v", key, err)
+		}
+	}
+
+	var names []string
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, filepath.Base(f.GetName()))
+	}
+	want := []string{"complex.route.pb.go", "complex.bot.pb.go"}
+	if strings.Join(names, ",") != strings.Join(want, ",") {
+		t.Errorf("generated files = %v, want %v", names, want)
+	}
+}
+
+// TestRun verifies the library entry point: it selects the template, walks
+// every key, and generates a file per key for the files marked for generation.
+func TestRun(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	conf := DefaultConfig()
+	conf.OptionsKey = "route;bot"
+	conf.TemplateSource = "// {{.ServiceType}}\n"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	files := plugin.Response().GetFile()
+	if len(files) != 2 {
+		t.Fatalf("generated %d files, want 2", len(files))
+	}
+	if !strings.Contains(files[0].GetContent(), "// OrderService") {
+		t.Errorf("TemplateSource was not used:\n%s", files[0].GetContent())
+	}
+}
+
+// TestRun_FileContext verifies that every service of a file sees its sibling
+// services through File, so a template renders a file-wide block once.
+func TestRun_FileContext(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	conf := DefaultConfig()
+	conf.TemplateSource = "{{if .FirstInFile}}// {{.File.Path}} {{.File.ProtoPackage}} {{.File.GoPackageName}}:" +
+		"{{range .File.Services}} {{.ServiceType}}{{end}}\n" +
+		"{{range .File.Methods}}// {{.Operation}}\n{{end}}{{end}}// {{.ServiceType}}\n"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	content := plugin.Response().GetFile()[0].GetContent()
+	want := "// complex.proto testdata.complex.v1 complexv1: OrderService UserService\n" +
+		"// OperationRouteOrderServiceCreate\n" +
+		"// OperationRouteUserServiceCreate\n" +
+		"// OrderService\n"
+	if !strings.Contains(content, want) || strings.Count(content, "complex.proto testdata") != 1 {
+		t.Errorf("output does not contain the file block once:\n%s", content)
+	}
+}
+
+// TestRun_ExtraListOrder verifies that ExtraList is sorted by key whatever
+// the order the options blocks, the service defaults and the overrides declare
+// the extras in.
+func TestRun_ExtraListOrder(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/service_defaults.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "service_defaults.proto")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	conf := DefaultConfig()
+	conf.TemplateSource = "{{range .Methods}}// {{.Name}}:{{range .ExtraList}} {{.Key}}{{end}}\n{{end}}"
+	conf.ExtrasOverrides = ExtrasOverrides{"testdata.servicedefaults.v1.GroupService.Stats": {"route": {"zone": "eu", "audit": "true"}}}
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	content := plugin.Response().GetFile()[0].GetContent()
+	for _, want := range []string{"// Ban: chat_type command group\n", "// Stats: audit chat_type command group zone\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("generated file does not contain %q:\n%s", want, content)
+		}
+	}
+}
+
+// TestRun_TemplateDelims verifies that template_delims changes the action
+// delimiters of a custom template, so it can emit {{ }} verbatim, and that the
+// next run with a built-in template uses the default ones again.
+func TestRun_TemplateDelims(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	conf := DefaultConfig()
+	conf.TemplateSource = "<<range .Methods>>// {{ .Values.<< .Name | snakeCase >> }}\n<<end>>"
+	conf.TemplateDelims = [2]string{"<<", ">>"}
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if content, want := plugin.Response().GetFile()[0].GetContent(), "// {{ .Values.update_count }}"; !strings.Contains(content, want) {
+		t.Errorf("output does not contain %q:\n%s", want, content)
+	}
+
+	plugin = testutil.MustCreatePlugin(t, set, "basic.proto")
+	if err := Run(plugin, DefaultConfig()); err != nil {
+		t.Fatalf("Run with the built-in template failed: %v", err)
+	}
+}
+
+// TestRun_Streaming verifies the streaming policies on complex.proto, whose
+// OrderService.Watch streams its replies.
+func TestRun_Streaming(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	var buf bytes.Buffer
+	stderr := warnings
+	t.Cleanup(func() { warnings = stderr })
+	warnings = &buf
+	source := "{{range .Methods}}// {{.OperationPath}} client={{.ClientStreaming}} server={{.ServerStreaming}}\n{{end}}"
+
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf := DefaultConfig()
+	conf.TemplateSource = source
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	if content := plugin.Response().GetFile()[0].GetContent(); strings.Contains(content, "/Watch") {
+		t.Errorf("streaming method was not skipped:\n%s", content)
+	}
+	if want := `testdata.complex.v1.OrderService.Watch: skipping streaming method routed under key "route"`; !strings.Contains(buf.String(), want) {
+		t.Errorf("warnings = %q, want %q", buf.String(), want)
+	}
+
+	plugin = testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf.Streaming = StreamingError
+	err := Run(plugin, conf)
+	if err == nil || !strings.Contains(err.Error(), "OrderService.Watch: streaming methods cannot be routed") {
+		t.Errorf("Run(streaming=error) = %v, want a streaming error", err)
+	}
+
+	plugin = testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf.Streaming = StreamingInclude
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run(streaming=include) failed: %v", err)
+	}
+	want := "// /testdata.complex.v1.OrderService/Watch client=false server=true"
+	if content := plugin.Response().GetFile()[0].GetContent(); !strings.Contains(content, want) {
+		t.Errorf("output does not contain %q:\n%s", want, content)
+	}
+}
+
+// TestRun_GoPackageOverride verifies that go_package_override moves the Go
+// files into another package, which imports the messages, and that the module
+// parameter strips the file names like for protoc-gen-go.
+func TestRun_GoPackageOverride(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{"basic.proto"},
+		Parameter:      proto.String("module=github.com/go-sphere/protoc-gen-route"),
+		ProtoFile:      set.File,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf := DefaultConfig()
+	conf.GoPackageOverride = GoPackage{ImportPath: "github.com/go-sphere/protoc-gen-route/internal/route", Name: "route"}
+	conf.SelfTest = true
+	conf.Manifest = ManifestJSON
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	var names []string
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, f.GetName())
+	}
+	want := []string{"internal/route/basic.route.pb.go", "internal/route/basic.route.routes.json", "internal/route/basic.route.routes_test.go"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("generated files = %v, want %v", names, want)
+	}
+	content := plugin.Response().GetFile()[0].GetContent()
+	for _, w := range []string{
+		"package route\n",
+		`basicv1 "github.com/go-sphere/protoc-gen-route/generate/route/testdata/gen/basicv1"`,
+		"GetMenu(context.Context, *basicv1.GetMenuRequest) (*basicv1.GetMenuResponse, error)",
+	} {
+		if !strings.Contains(content, w) {
+			t.Errorf("output does not contain %q:\n%s", w, content)
+		}
+	}
+}
+
+// TestGoldenTypeScript verifies the ts_out_file TypeScript routes of
+// callback_pattern.proto: its operations, command, callback query patterns
+// and callback data builders.
+func TestGoldenTypeScript(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/callback_pattern.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "callback_pattern.proto")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	conf := DefaultConfig()
+	conf.TSOutFile = "web/routes.ts"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	var routes *pluginpb.CodeGeneratorResponse_File
+	for _, f := range plugin.Response().GetFile() {
+		if f.GetName() == conf.TSOutFile {
+			routes = f
+		}
+	}
+	if routes == nil {
+		t.Fatal("no TypeScript file generated")
+	}
+	compareGolden(t, "testdata/golden/callback_pattern.routes.ts", []byte(routes.GetContent()))
+}
+
+// TestGoldenRegisterAll verifies the per-package aggregate file of register_all,
+// which wires both services of complex.proto.
+func TestGoldenRegisterAll(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	conf := DefaultConfig()
+	conf.RegisterAll = true
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	var aggregate *pluginpb.CodeGeneratorResponse_File
+	for _, f := range plugin.Response().GetFile() {
+		if strings.HasSuffix(f.GetName(), ".all.pb.go") {
+			aggregate = f
+		}
+	}
+	if aggregate == nil {
+		t.Fatal("no aggregate file generated")
+	}
+	if got, want := filepath.Base(aggregate.GetName()), "complexv1.route.all.pb.go"; got != want {
+		t.Errorf("aggregate file = %s, want %s", got, want)
+	}
+	compareGolden(t, "testdata/golden/register_all.route.all.pb.go", []byte(aggregate.GetContent()))
+
+	plugin = testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf.Dispatch = DispatchSwitch
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run with dispatch=switch failed: %v", err)
+	}
+	for _, f := range plugin.Response().GetFile() {
+		if strings.HasSuffix(f.GetName(), ".all.pb.go") {
+			compareGolden(t, "testdata/golden/register_all_switch.route.all.pb.go", []byte(f.GetContent()))
+		}
+	}
+
+	plugin = testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf.Dispatch = ""
+	conf.Template = "job"
+	if err := Run(plugin, conf); err == nil {
+		t.Error("Run() with a template lacking an aggregate = nil error, want an error")
+	}
+}
+
+// TestGoldenKeyRegions verifies that several options keys with a
+// file_pattern lacking {key} are generated into one file, a region per key in
+// key order whatever the order of options_key.
+func TestGoldenKeyRegions(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/multi_key.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	generate := func(optionsKey string) []*pluginpb.CodeGeneratorResponse_File {
+		plugin := testutil.MustCreatePlugin(t, set, "multi_key.proto")
+		conf := DefaultConfig()
+		conf.OptionsKey = optionsKey
+		conf.FilePattern = "{proto}.routes.pb.go"
+		conf.Parallel = 4
+		if err := Run(plugin, conf); err != nil {
+			t.Fatalf("Run(%q) failed: %v", optionsKey, err)
+		}
+		return plugin.Response().GetFile()
+	}
+	files := generate("route;job")
+	if len(files) != 1 || filepath.Base(files[0].GetName()) != "multi_key.routes.pb.go" {
+		var names []string
+		for _, f := range files {
+			names = append(names, f.GetName())
+		}
+		t.Fatalf("generated files = %v, want multi_key.routes.pb.go only", names)
+	}
+	compareGolden(t, "testdata/golden/key_regions.routes.pb.go", []byte(files[0].GetContent()))
+
+	if reordered := generate("job;route"); len(reordered) != 1 || reordered[0].GetContent() != files[0].GetContent() {
+		t.Error("output depends on the order of options_key")
+	}
+}
+
+// TestRun_Descriptors verifies that the protogen descriptors reach templates
+// only when enabled, and that templates can guard on them.
+func TestRun_Descriptors(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	source := "// service {{with .Descriptor}}{{.Desc.FullName}}{{else}}none{{end}}\n" +
+		"{{range .Methods}}// method {{with .Descriptor}}{{.Input.Desc.FullName}}{{else}}none{{end}}\n{{end}}"
+
+	for _, enabled := range []bool{false, true} {
+		plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+		conf := DefaultConfig()
+		conf.TemplateSource = source
+		conf.Descriptors = enabled
+		if err := Run(plugin, conf); err != nil {
+			t.Fatalf("Run(descriptors=%v) failed: %v", enabled, err)
+		}
+		content := plugin.Response().GetFile()[0].GetContent()
+		want := []string{"// service none", "// method none"}
+		if enabled {
+			want = []string{"// service testdata.basic.v1.MenuService", "// method testdata.basic.v1.GetMenuRequest"}
+		}
+		for _, w := range want {
+			if !strings.Contains(content, w) {
+				t.Errorf("descriptors=%v: output does not contain %q:\n%s", enabled, w, content)
+			}
+		}
+	}
+}
+
+// TestRun_Comments verifies that every comment position of a method reaches
+// templates as plain text.
+func TestRun_Comments(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/comments.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "comments.proto")
+	conf := DefaultConfig()
+	conf.TemplateSource = "{{range .Methods}}" +
+		"/* {{.Name}} summary={{quote .Summary}} description={{quote .Description}}" +
+		" trailing={{quote .TrailingComment}} detached={{range .DetachedComments}}{{quote .}}{{end}} */\n{{end}}"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	content := plugin.Response().GetFile()[0].GetContent()
+	want := []string{
+		`/* About summary="About shows the bot version." description="" trailing="" detached= */`,
+		`/* Help summary="Help shows the help text." description="The text lists every command\nwith its arguments.\n\nAdmins also see hidden commands."` +
+			` trailing="Help is always available." detached="Detached notes about the help layout." */`,
+	}
+	for _, w := range want {
+		if !strings.Contains(content, w) {
+			t.Errorf("output does not contain %s:\n%s", w, content)
+		}
+	}
+}
+
+// TestRun_GatedGroupRoutes verifies that a method with a feature_flag extra
+// registered through its group helper is left out while the gate is closed:
+// the helper only hands back what Register<Server> registered with its options.
+func TestRun_GatedGroupRoutes(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/gated_groups.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "gated_groups.proto")
+	if err := Run(plugin, DefaultConfig()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	content := plugin.Response().GetFile()[0].GetContent()
+	helper := content[strings.Index(content, "func RegisterReportServiceRouteAdminRoutes("):]
+	helper = helper[:strings.Index(helper, "\n}\n")]
+	for _, want := range []string{
+		"opts ...ReportServiceRouteOption)",
+		"all := RegisterReportServiceRouteServer(srv, codec, render, opts...)",
+		"if handler, ok := all[OperationRouteReportServiceExport]; ok {",
+		"handlers[OperationRouteReportServiceSummary] = all[OperationRouteReportServiceSummary]",
+	} {
+		if !strings.Contains(helper, want) {
+			t.Errorf("group helper does not contain %q:\n%s", want, helper)
+		}
+	}
+	if strings.Contains(helper, "_Handler(") {
+		t.Errorf("group helper builds handlers without the options:\n%s", helper)
+	}
+	if want := "if options.gate == nil || options.gate.Enabled(context.Background(), \"report_export\") {"; !strings.Contains(content, want) {
+		t.Errorf("Register<Server> does not gate Export, want %q", want)
+	}
+}
+
+// TestRun_MixedCaseOptionsKey verifies that a mixed-case options key keeps the
+// baseline generated names and labels the metrics with the key as configured.
+func TestRun_MixedCaseOptionsKey(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/mixed_case_key.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "mixed_case_key.proto")
+	conf := DefaultConfig()
+	conf.OptionsKey = "myBot"
+	conf.Metrics = true
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	content := plugin.Response().GetFile()[0].GetContent()
+	for _, want := range []string{
+		"OperationMybotPingServicePing",
+		"func RegisterPingServiceMybotServer(",
+		`o.metrics.requests.WithLabelValues(operation, "myBot")`,
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("output does not contain %q", want)
+		}
+	}
+}
+
+// TestRun_SkipsUnmatchedKeys verifies that a key no method of the file is
+// routed under emits no file at all.
+func TestRun_SkipsUnmatchedKeys(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	conf := DefaultConfig()
+	conf.OptionsKey = "route;job"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	var names []string
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, filepath.Base(f.GetName()))
+	}
+	if want := []string{"complex.route.pb.go"}; !slices.Equal(names, want) {
+		t.Errorf("generated files = %v, want %v", names, want)
+	}
+}
+
+// TestRun_OptionsKeyPattern verifies that options_key patterns generate a file
+// per matching key found in the files, and warn when they match none.
+func TestRun_OptionsKeyPattern(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	var buf bytes.Buffer
+	stderr := warnings
+	t.Cleanup(func() { warnings = stderr })
+	warnings = &buf
+
+	tests := []struct {
+		optionsKey string
+		want       []string
+	}{
+		{"*", []string{"complex.bot.pb.go", "complex.route.pb.go"}},
+		{"route;*", []string{"complex.route.pb.go", "complex.bot.pb.go"}},
+		{"b?t;job_*", []string{"complex.bot.pb.go"}},
+	}
+	for _, tt := range tests {
+		plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+		conf := DefaultConfig()
+		conf.OptionsKey = tt.optionsKey
+		if err := Run(plugin, conf); err != nil {
+			t.Fatalf("Run(%q) failed: %v", tt.optionsKey, err)
+		}
+		var names []string
+		for _, f := range plugin.Response().GetFile() {
+			names = append(names, filepath.Base(f.GetName()))
+		}
+		if !slices.Equal(names, tt.want) {
+			t.Errorf("Run(%q) generated files = %v, want %v", tt.optionsKey, names, tt.want)
+		}
+	}
+	if want := `options_key pattern "job_*" matches no key`; !strings.Contains(buf.String(), want) {
+		t.Errorf("warnings = %q, want %q", buf.String(), want)
+	}
+
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf := DefaultConfig()
+	conf.OptionsKey = "*"
+	conf.FilePattern = "{proto}.routes.go"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run() with a key-less pattern and a key pattern failed: %v", err)
+	}
+	files := plugin.Response().GetFile()
+	if len(files) != 1 || !strings.Contains(files[0].GetContent(), "// region bot") || !strings.Contains(files[0].GetContent(), "// region route") {
+		t.Errorf("Run() with a key-less pattern and a key pattern = %d files, want one holding a region per key", len(files))
+	}
+}
+
+// TestRun_KeyModels verifies that per-key models replace the Config-wide ones
+// for that key only.
+func TestRun_KeyModels(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf := DefaultConfig()
+	conf.OptionsKey = "route;bot"
+	conf.KeyModels = map[string]Models{
+		"bot": {RequestType: protogen.GoIdent{GoName: "Event", GoImportPath: "example.com/chat"}},
+	}
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	contents := make(map[string]string)
+	for _, f := range plugin.Response().GetFile() {
+		contents[filepath.Base(f.GetName())] = f.GetContent()
+	}
+	if bot := contents["complex.bot.pb.go"]; !strings.Contains(bot, "request *chat.Event") || !strings.Contains(bot, "*telegram.Message") {
+		t.Errorf("bot file does not use the bot request model and the shared response model:\n%s", bot)
+	}
+	if route := contents["complex.route.pb.go"]; strings.Contains(route, "example.com/chat") {
+		t.Errorf("route file uses the bot request model:\n%s", route)
+	}
+}
+
+// TestRun_FilePattern verifies that file_pattern names the generated files and
+// that several keys share the file of a pattern lacking {key}.
+func TestRun_FilePattern(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf := DefaultConfig()
+	conf.OptionsKey = "route;bot"
+	conf.FilePattern = "{proto}_{key}.route.go"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	var names []string
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, filepath.Base(f.GetName()))
+	}
+	want := []string{"complex_route.route.go", "complex_bot.route.go"}
+	if !slices.Equal(names, want) {
+		t.Errorf("generated files = %v, want %v", names, want)
+	}
+
+	plugin = testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf.FilePattern = "{proto}.routes.go"
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run() with a key-less pattern and several keys failed: %v", err)
+	}
+	names = nil
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, filepath.Base(f.GetName()))
+	}
+	if want := []string{"complex.routes.go"}; !slices.Equal(names, want) {
+		t.Errorf("generated files = %v, want %v", names, want)
+	}
+}
+
+// TestRun_SplitService verifies that split=service emits a Go file per service
+// holding only that service, named by the {service} placeholder.
+func TestRun_SplitService(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf := DefaultConfig()
+	conf.Split = SplitService
+	conf.Manifest = ManifestJSON
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	contents := make(map[string]string)
+	var names []string
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, filepath.Base(f.GetName()))
+		contents[filepath.Base(f.GetName())] = f.GetContent()
+	}
+	want := []string{"order_service.route.pb.go", "user_service.route.pb.go", "complex.route.routes.json"}
+	if !slices.Equal(names, want) {
+		t.Fatalf("generated files = %v, want %v", names, want)
+	}
+	order, user := contents["order_service.route.pb.go"], contents["user_service.route.pb.go"]
+	if !strings.Contains(order, "type OrderServiceRouteServer interface") || strings.Contains(order, "UserServiceRouteServer") {
+		t.Errorf("order_service file does not hold only OrderService:\n%s", order)
+	}
+	if !strings.Contains(user, "type UserServiceRouteServer interface") || strings.Contains(user, "OrderServiceRouteServer") {
+		t.Errorf("user_service file does not hold only UserService:\n%s", user)
+	}
+	if !strings.Contains(contents["complex.route.routes.json"], "UserService") || !strings.Contains(contents["complex.route.routes.json"], "OrderService") {
+		t.Error("manifest does not cover every service of the proto file")
+	}
+}
+
+// TestGenerateFile_InvalidCallbackQueryPattern verifies that a pattern that does
+// not compile fails generation with the method's position.
+func TestGenerateFile_InvalidCallbackQueryPattern(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/invalid_pattern.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "invalid_pattern.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	_, err := GenerateFile(plugin, file, DefaultConfig())
+	if err == nil {
+		t.Fatal("GenerateFile() = nil error, want an invalid pattern error")
+	}
+	want := `invalid_pattern.proto:10:3: testdata.invalidpattern.v1.BrokenService.Show: invalid callback_query_pattern "^item:(\\d+$"`
+	if !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("GenerateFile() error = %q, want prefix %q", err, want)
+	}
+}
+
+// TestGenerateFile_MissingTopic verifies that the mq template rejects every
+// method without a topic extra, not only the first.
+func TestGenerateFile_MissingTopic(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	file := testutil.FileToGenerate(t, plugin)
+	if err := UseBuiltinTemplate("mq"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	conf := DefaultConfig()
+	conf.Template = "mq"
+	_, err := GenerateFile(plugin, file, conf)
+	if err == nil {
+		t.Fatal("GenerateFile() = nil error, want a missing topic error")
+	}
+	want := `basic.proto:28:3: testdata.basic.v1.MenuService.GetMenu: extra "topic" is required by the selected template` + "\n" +
+		`basic.proto:13:3: testdata.basic.v1.MenuService.UpdateCount: extra "topic" is required by the selected template`
+	if err.Error() != want {
+		t.Errorf("GenerateFile() error = %q, want %q", err, want)
+	}
+}
+
+// TestGenerateFile_SwitchUnsupportedExtras verifies that dispatch=switch
+// rejects every extra whose registration it does not generate, unless a custom
+// template renders the methods.
+func TestGenerateFile_SwitchUnsupportedExtras(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/gated_groups.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	plugin := testutil.MustCreatePlugin(t, set, "gated_groups.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	conf := DefaultConfig()
+	conf.Dispatch = DispatchSwitch
+	_, err := GenerateFile(plugin, file, conf)
+	if err == nil {
+		t.Fatal("GenerateFile() = nil error, want unsupported extra errors")
+	}
+	want := `gated_groups.proto:27:3: testdata.gatedgroups.v1.ReportService.Export: extra "group" is not supported with dispatch=switch, which generates no group registration functions; use dispatch=map` + "\n" +
+		`gated_groups.proto:27:3: testdata.gatedgroups.v1.ReportService.Export: extra "middleware" is not supported with dispatch=switch, which generates no Register<Server>WithMiddleware; use dispatch=map` + "\n" +
+		`gated_groups.proto:12:3: testdata.gatedgroups.v1.ReportService.Summary: extra "group" is not supported with dispatch=switch, which generates no group registration functions; use dispatch=map`
+	if err.Error() != want {
+		t.Errorf("GenerateFile() error = %q, want %q", err, want)
+	}
+
+	conf.TemplateSource = "{{range .Methods}}// {{.Name}} {{.Group}}\n{{end}}"
+	if _, err := GenerateFile(plugin, file, conf); err != nil {
+		t.Errorf("GenerateFile() with a custom template = %v", err)
+	}
+}
+
+// TestRun_ErrorFormatJSON verifies that Run reports the errors of every file
+// and method at once, as a JSON array when error_format=json.
+func TestRun_ErrorFormatJSON(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	conf := DefaultConfig()
+	conf.Template = "mq"
+	conf.ErrorFormat = ErrorFormatJSON
+	err := Run(plugin, conf)
+	if err == nil {
+		t.Fatal("Run() = nil error, want missing topic errors")
+	}
+	want := `[{"file":"basic.proto","line":28,"column":3,"element":"testdata.basic.v1.MenuService.GetMenu","message":"extra \"topic\" is required by the selected template"},` +
+		`{"file":"basic.proto","line":13,"column":3,"element":"testdata.basic.v1.MenuService.UpdateCount","message":"extra \"topic\" is required by the selected template"}]`
+	if err.Error() != want {
+		t.Errorf("Run() error = %s, want %s", err, want)
+	}
+}
+
+// TestGenerateFile_DuplicateKeyRule verifies that two rules for the same key on
+// one method are rejected with the method's position.
+func TestGenerateFile_DuplicateKeyRule(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/duplicate_key.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "duplicate_key.proto")
+	file := testutil.FileToGenerate(t, plugin)
+
+	_, err := GenerateFile(plugin, file, DefaultConfig())
+	if err == nil {
+		t.Fatal("GenerateFile() = nil error, want a duplicate rule error")
+	}
+	want := `duplicate_key.proto:12:3: testdata.duplicatekey.v1.AmbiguousService.Start: multiple (sphere.options.options) rules for key "route"`
+	if err.Error() != want {
+		t.Errorf("GenerateFile() error = %q, want %q", err, want)
+	}
+}
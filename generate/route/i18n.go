@@ -0,0 +1,126 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Supported i18n resource formats.
+const (
+	I18nJSON = "json"
+	I18nTOML = "toml"
+)
+
+// i18nMessage is one entry of the i18n resource skeleton.
+type i18nMessage struct {
+	Const string // Go constant holding Key
+	Key   string
+	Text  string
+}
+
+// generateI18n emits the .<key>.i18n.<format> resource skeleton, seeding every
+// key with the text from the proto comments, and <proto>.<key>.i18n.go with a
+// constant per key, so translations are looked up without spelling the keys
+// by hand.
+func generateI18n(gen *protogen.Plugin, file *protogen.File, conf *Config, services []*template.ServiceDesc) error {
+	messages := buildI18nMessages(services)
+	content, err := marshalI18n(messages, conf.I18n)
+	if err != nil {
+		return err
+	}
+	key := strings.ToLower(conf.OptionsKey)
+	filename := file.GeneratedFilenamePrefix + fmt.Sprintf(".%s.i18n.%s", key, conf.I18n)
+	if _, err := gen.NewGeneratedFile(filename, "").Write(content); err != nil {
+		return err
+	}
+
+	pkg, prefix := conf.goPackage(file)
+	g := gen.NewGeneratedFile(prefix+fmt.Sprintf(".%s.i18n.go", key), pkg.ImportPath)
+	generateFileHeader(gen, file, g, conf)
+	g.P("// i18n keys of the ", conf.OptionsKey, " routes, see ", path.Base(filename), ".")
+	g.P("const (")
+	for _, m := range messages {
+		g.P(m.Const, " = ", strconv.Quote(m.Key))
+	}
+	g.P(")")
+	return nil
+}
+
+// buildI18nMessages returns the keys of every method in service order:
+// <service>.<method>.description, seeded with the method's command description,
+// and <service>.<method>.command for methods with a command, seeded with its
+// first command. <service> is the snake_case service name without its Service
+// suffix, e.g. menu.update_count.description for MenuService.UpdateCount.
+func buildI18nMessages(services []*template.ServiceDesc) []i18nMessage {
+	var messages []i18nMessage
+	for _, sd := range services {
+		service := strings.TrimSuffix(sd.ServiceType, "Service")
+		if service == "" {
+			service = sd.ServiceType
+		}
+		for _, md := range sd.Methods {
+			prefix := template.SnakeCase(service) + "." + template.SnakeCase(md.OriginalName)
+			name := sd.ServiceType + sd.OptionsKey + md.OriginalName
+			messages = append(messages, i18nMessage{Const: name + "DescriptionKey", Key: prefix + ".description", Text: md.CommandDescription()})
+			if len(md.Commands) > 0 {
+				messages = append(messages, i18nMessage{Const: name + "CommandKey", Key: prefix + ".command", Text: md.Commands[0]})
+			}
+		}
+	}
+	return messages
+}
+
+// marshalI18n renders messages as a flat mapping from key to text. JSON keys
+// are sorted; TOML keys keep the order of messages and are quoted, so the dots
+// do not nest tables.
+func marshalI18n(messages []i18nMessage, format string) ([]byte, error) {
+	switch format {
+	case I18nJSON:
+		texts := make(map[string]string, len(messages))
+		for _, m := range messages {
+			texts[m.Key] = m.Text
+		}
+		content, err := json.MarshalIndent(texts, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(content, '\n'), nil
+	case I18nTOML:
+		var b strings.Builder
+		b.WriteString("# Code generated by protoc-gen-route. DO NOT EDIT.\n")
+		for _, m := range messages {
+			fmt.Fprintf(&b, "%s = %s\n", tomlQuote(m.Key), tomlQuote(m.Text))
+		}
+		return []byte(b.String()), nil
+	}
+	return nil, fmt.Errorf("unsupported i18n format %q", format)
+}
+
+// tomlQuote returns s as a TOML basic string.
+func tomlQuote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case r < 0x20 || r == 0x7f:
+			fmt.Fprintf(&b, `\u%04X`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
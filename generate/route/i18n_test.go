@@ -0,0 +1,32 @@
+package route
+
+import "testing"
+
+func TestGoldenI18n(t *testing.T) {
+	for _, suffix := range []string{".route.i18n." + I18nJSON, ".route.i18n." + I18nTOML, ".route.i18n.go"} {
+		t.Run(suffix, func(t *testing.T) {
+			conf := DefaultConfig()
+			conf.I18n = I18nTOML
+			if suffix == ".route.i18n."+I18nJSON {
+				conf.I18n = I18nJSON
+			}
+			content := generateSidecar(t, "testdata/pb/basic.pb", "basic.proto", conf, suffix)
+			compareGolden(t, "testdata/golden/basic"+suffix, content)
+		})
+	}
+}
+
+func TestTOMLQuote(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"plain", `"plain"`},
+		{`say "hi"\`, `"say \"hi\"\\"`},
+		{"a\nb\x01", `"a\nb\u0001"`},
+	}
+	for _, tt := range tests {
+		if got := tomlQuote(tt.in); got != tt.want {
+			t.Errorf("tomlQuote(%q) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
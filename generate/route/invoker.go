@@ -0,0 +1,115 @@
+package route
+
+import (
+	"strconv"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateInvoker emits the invoker of sd, the inverse of its route table: a
+// <Service><Key>Client interface with the unary methods of sd, which the
+// server of the built-in templates implements, and a <Service><Key>Invoker
+// calling the method of an operation path with a request message, e.g. to
+// replay routes recorded in a manifest. Like the type registry it is written
+// in Go so every template gets it.
+func generateInvoker(g *protogen.GeneratedFile, sd *template.ServiceDesc) {
+	clientName := sd.ServiceType + sd.OptionsKey + "Client"
+	invokerName := sd.ServiceType + sd.OptionsKey + "Invoker"
+	ctx := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Context", GoImportPath: "context"})
+	message := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Message", GoImportPath: "google.golang.org/protobuf/proto"})
+	errorf := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Errorf", GoImportPath: "fmt"})
+
+	var methods []*template.MethodDesc
+	for _, md := range sd.Methods {
+		if !md.ClientStreaming && !md.ServerStreaming {
+			methods = append(methods, md)
+		}
+	}
+
+	g.P("// ", clientName, " holds the methods ", invokerName, " calls, those of")
+	g.P("// the route server, so a server implementation can be invoked as is.")
+	g.P("type ", clientName, " interface {")
+	seen := make(map[string]bool)
+	for _, md := range methods {
+		if !seen[md.Name] {
+			seen[md.Name] = true
+			g.P(md.Name, "(", ctx, ", *", md.Request, ") (*", md.Reply, ", error)")
+		}
+	}
+	g.P("}")
+	g.P()
+	g.P("// ", invokerName, " calls the method of client routed under an operation, the")
+	g.P("// inverse of the route table, e.g. to drive handlers from recorded routes.")
+	g.P("type ", invokerName, " struct {")
+	g.P("client ", clientName)
+	g.P("}")
+	g.P()
+	g.P("// New", invokerName, " returns an invoker calling the methods of client.")
+	g.P("func New", invokerName, "(client ", clientName, ") *", invokerName, " {")
+	g.P("return &", invokerName, "{client: client}")
+	g.P("}")
+	g.P()
+	g.P("// Invoke calls the method of operation, an operation path, with req, which")
+	g.P("// must be of its request type, and returns the reply. It fails for an operation")
+	g.P("// of another service and for a request of another type.")
+	g.P("func (i *", invokerName, ") Invoke(ctx ", ctx, ", operation string, req ", message, ") (", message, ", error) {")
+	g.P("switch operation {")
+	for _, md := range methods {
+		g.P("case ", strconv.Quote(md.OperationPath), ":")
+		g.P("in, ok := req.(*", md.Request, ")")
+		g.P("if !ok {")
+		g.P("return nil, ", errorf, "(\"%s: request is %T, want *", md.Request, "\", operation, req)")
+		g.P("}")
+		g.P("reply, err := i.client.", md.Name, "(ctx, in)")
+		g.P("if err != nil {")
+		g.P("return nil, err")
+		g.P("}")
+		g.P("return reply, nil")
+	}
+	g.P("default:")
+	g.P("return nil, ", errorf, "(\"unknown ", sd.ServiceType, " operation %q\", operation)")
+	g.P("}")
+	g.P("}")
+	g.P()
+	if sd.Package != nil && sd.Package.GenericHandlers {
+		generateTypedRoutes(g, sd, clientName, methods)
+	}
+}
+
+// generateTypedRoutes emits, for generics, a <Service><Key>TypedRoute type and a
+// <Service><Key><Method>Route of it for every method of methods: a route typed
+// by the request and reply messages of its method, calling it on a client
+// without the type assertion of Invoke.
+func generateTypedRoutes(g *protogen.GeneratedFile, sd *template.ServiceDesc, clientName string, methods []*template.MethodDesc) {
+	routeName := sd.ServiceType + sd.OptionsKey + "TypedRoute"
+	ctx := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Context", GoImportPath: "context"})
+	message := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Message", GoImportPath: "google.golang.org/protobuf/proto"})
+
+	g.P("// ", routeName, " is a method of ", clientName, " routed under")
+	g.P("// Operation, typed by its request and reply messages, so that it is called")
+	g.P("// without a type assertion.")
+	g.P("type ", routeName, "[Req, Resp ", message, "] struct {")
+	g.P("Operation string")
+	g.P("call func(", clientName, ", ", ctx, ", Req) (Resp, error)")
+	g.P("}")
+	g.P()
+	g.P("// Invoke calls the method of r on client with req and returns the reply.")
+	g.P("func (r ", routeName, "[Req, Resp]) Invoke(ctx ", ctx, ", client ", clientName, ", req Req) (Resp, error) {")
+	g.P("return r.call(client, ctx, req)")
+	g.P("}")
+	g.P()
+	seen := make(map[string]bool)
+	for _, md := range methods {
+		if seen[md.Name] {
+			continue
+		}
+		seen[md.Name] = true
+		g.P("// ", sd.ServiceType, sd.OptionsKey, md.Name, "Route is the typed route of ", md.Name, ".")
+		g.P("var ", sd.ServiceType, sd.OptionsKey, md.Name, "Route = ", routeName, "[*", md.Request, ", *", md.Reply, "]{")
+		g.P("Operation: ", strconv.Quote(md.OperationPath), ",")
+		g.P("call: ", clientName, ".", md.Name, ",")
+		g.P("}")
+		g.P()
+	}
+}
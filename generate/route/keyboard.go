@@ -0,0 +1,100 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// maxCallbackData is the limit Telegram puts on the callback data of a button,
+// in bytes.
+const maxCallbackData = 64
+
+// keyboardButton is a button of a buttons extra: a label and either the proto
+// name of the method of the service it routes to or static callback data.
+type keyboardButton struct {
+	Text   string `json:"text"`
+	Method string `json:"method"`
+	Data   string `json:"data"`
+}
+
+// parseButtons parses the buttons extra of method, a JSON array of rows of
+// buttons such as [[{"text": "Next", "method": "ShowItem"}], [{"text":
+// "Close", "data": "close"}]]. An empty extra yields no rows.
+func parseButtons(method *protogen.Method, raw string) ([][]keyboardButton, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	fail := func(reason string, args ...any) error {
+		return descriptorErrorf(method.Desc, "invalid %s: %s", ExtraButtons, fmt.Sprintf(reason, args...))
+	}
+	var rows [][]keyboardButton
+	decoder := json.NewDecoder(strings.NewReader(raw))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fail("%v", err)
+	}
+	if len(rows) == 0 {
+		return nil, fail("no rows")
+	}
+	for i, row := range rows {
+		if len(row) == 0 {
+			return nil, fail("row %d has no buttons", i+1)
+		}
+		for _, button := range row {
+			switch {
+			case button.Text == "":
+				return nil, fail("a button of row %d has no text", i+1)
+			case (button.Method == "") == (button.Data == ""):
+				return nil, fail("button %q must set one of method and data", button.Text)
+			case len(button.Data) > maxCallbackData:
+				return nil, fail("data of button %q exceeds %d bytes", button.Text, maxCallbackData)
+			}
+		}
+	}
+	return rows, nil
+}
+
+// buildKeyboard resolves the buttons of method against targets, the methods
+// of the service by proto name. A target must carry a callback_data or
+// callback_fields extra to build its callback data from. Every button
+// targeting a method gets a parameter named after the method, numbered when
+// several buttons target it.
+func buildKeyboard(method *protogen.Method, rows [][]keyboardButton, targets map[string]*template.MethodDesc) ([][]*template.ButtonDesc, error) {
+	counts := make(map[string]int)
+	for _, row := range rows {
+		for _, button := range row {
+			if button.Method != "" {
+				counts[button.Method]++
+			}
+		}
+	}
+	seen := make(map[string]int)
+	keyboard := make([][]*template.ButtonDesc, len(rows))
+	for i, row := range rows {
+		for _, button := range row {
+			desc := &template.ButtonDesc{Text: button.Text, Data: button.Data}
+			if button.Method != "" {
+				target := targets[button.Method]
+				if target == nil {
+					return nil, descriptorErrorf(method.Desc, "invalid %s: button %q targets %s, which is not a method routed under the key", ExtraButtons, button.Text, button.Method)
+				}
+				if target.CallbackDataExpr == "" && target.CallbackEncoder == "" {
+					return nil, descriptorErrorf(method.Desc, "invalid %s: button %q targets %s, which has no %s or %s extra", ExtraButtons, button.Text, button.Method, ExtraCallbackData, ExtraCallbackFields)
+				}
+				desc.Target = target
+				desc.Param = strings.ToLower(target.Name[:1]) + target.Name[1:] + "Req"
+				if counts[button.Method] > 1 {
+					seen[button.Method]++
+					desc.Param += strconv.Itoa(seen[button.Method])
+				}
+			}
+			keyboard[i] = append(keyboard[i], desc)
+		}
+	}
+	return keyboard, nil
+}
@@ -0,0 +1,91 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestParseButtons(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/keyboard.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "keyboard.proto")
+	list := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	tests := []struct {
+		raw     string
+		rows    int
+		wantErr string
+	}{
+		{"", 0, ""},
+		{`[[{"text": "Next", "method": "Page"}], [{"text": "Close", "data": "close"}]]`, 2, ""},
+		{`[{"text": "Next"}]`, 0, "cannot unmarshal"},
+		{`[[{"text": "Next", "method": "Page", "url": "x"}]]`, 0, `unknown field "url"`},
+		{`[]`, 0, "no rows"},
+		{`[[]]`, 0, "row 1 has no buttons"},
+		{`[[{"method": "Page"}]]`, 0, "a button of row 1 has no text"},
+		{`[[{"text": "Next"}]]`, 0, `button "Next" must set one of method and data`},
+		{`[[{"text": "Next", "method": "Page", "data": "next"}]]`, 0, `button "Next" must set one of method and data`},
+		{`[[{"text": "Long", "data": "` + strings.Repeat("x", 65) + `"}]]`, 0, `data of button "Long" exceeds 64 bytes`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.raw, func(t *testing.T) {
+			rows, err := parseButtons(list, tt.raw)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("parseButtons() error = %v, want it to contain %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseButtons() failed: %v", err)
+			}
+			if len(rows) != tt.rows {
+				t.Errorf("parseButtons() = %d rows, want %d", len(rows), tt.rows)
+			}
+		})
+	}
+}
+
+func TestBuildKeyboard(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/keyboard.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "keyboard.proto")
+	list := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+	targets := map[string]*template.MethodDesc{
+		"List":     {Name: "List"},
+		"Page":     {Name: "Page", CallbackEncoder: "return \"\", nil"},
+		"ShowItem": {Name: "ShowItem", CallbackDataExpr: `"item"`},
+	}
+
+	rows, err := parseButtons(list, `[[{"text": "Previous", "method": "Page"}, {"text": "Next", "method": "Page"}], [{"text": "Item", "method": "ShowItem"}, {"text": "Close", "data": "close"}]]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyboard, err := buildKeyboard(list, rows, targets)
+	if err != nil {
+		t.Fatalf("buildKeyboard() failed: %v", err)
+	}
+	var params []string
+	for _, row := range keyboard {
+		for _, button := range row {
+			params = append(params, button.Param)
+		}
+	}
+	if got, want := strings.Join(params, ","), "pageReq1,pageReq2,showItemReq,"; got != want {
+		t.Errorf("buildKeyboard() params = %s, want %s", got, want)
+	}
+
+	for raw, want := range map[string]string{
+		`[[{"text": "Go", "method": "Missing"}]]`: `button "Go" targets Missing, which is not a method routed under the key`,
+		`[[{"text": "Go", "method": "List"}]]`:    `button "Go" targets List, which has no callback_data or callback_fields extra`,
+	} {
+		rows, err := parseButtons(list, raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := buildKeyboard(list, rows, targets); err == nil || !strings.HasSuffix(err.Error(), want) {
+			t.Errorf("buildKeyboard(%s) error = %v, want suffix %q", raw, err, want)
+		}
+	}
+}
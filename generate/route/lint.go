@@ -0,0 +1,139 @@
+package route
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-sphere/options/sphere/options"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// lintProtoFile is the path of the fixture proto file LintTemplate renders.
+const lintProtoFile = "lint/v1/lint.proto"
+
+// lintMethods are the methods of the fixture service, one per kind of route,
+// with the extras the built-in templates and the plugin give meaning to.
+var lintMethods = []struct {
+	name  string
+	extra map[string]string
+}{
+	{"Start", map[string]string{ExtraCommand: "start,begin", "callback_query": "start", ExtraMiddleware: "auth,log"}},
+	{"ShowItem", map[string]string{ExtraCallbackQueryPattern: `^item:(\d+)$`, ExtraCallbackData: "item:{id}", ExtraPriority: "10"}},
+	{"Notify", map[string]string{ExtraTopic: "notify", ExtraConsumerGroup: "workers", ExtraTimeout: "30s", ExtraRetry: "3"}},
+	{"Cleanup", map[string]string{ExtraCron: "0 3 * * *", ExtraGroup: "admin"}},
+}
+
+// LintTemplate checks the custom template of conf, its TemplateFile or
+// TemplateDir, without protoc: it parses the template, which checks its field
+// references against the template data, and renders it against a fixture
+// service with one method per kind of route for every options key of conf,
+// then checks that the output is valid Go. Every problem is returned in one
+// error. Sidecars, extras checks, overrides and the cache are disabled, and
+// the models default to the telegram example models when conf has none.
+func LintTemplate(conf *Config) error {
+	if conf.TemplateFile == "" && conf.TemplateDir == "" {
+		return fmt.Errorf("lint needs a template file or directory")
+	}
+	keys, err := ParseOptionsKeys(conf.OptionsKey)
+	if err != nil {
+		return err
+	}
+	// Patterns have no keys to expand to without proto files.
+	var lintKeys []string
+	for _, key := range keys {
+		if IsOptionsKeyPattern(key) {
+			key = DefaultOptionsKey
+		}
+		if !slices.Contains(lintKeys, key) {
+			lintKeys = append(lintKeys, key)
+		}
+	}
+	lintConf := *conf
+	lintConf.OptionsKey = strings.Join(lintKeys, ";")
+	lintConf.Manifest, lintConf.Docs, lintConf.I18n = "", "", ""
+	lintConf.TelegramCommands, lintConf.DiscordCommands, lintConf.CallbackSchema, lintConf.SelfTest = false, false, false, false
+	lintConf.ExtrasSchema, lintConf.ExtrasOverrides, lintConf.UniqueExtras = nil, nil, nil
+	lintConf.CacheDir, lintConf.Stats, lintConf.StatsOut = "", false, ""
+	lintConf.IncludeTags, lintConf.ExcludeTags, lintConf.Env = nil, nil, ""
+	lintConf.OptionsExtension, lintConf.OptionsExtensionNumber = "", 0
+	if lintConf.RequestType.GoName == "" && lintConf.ResponseType.GoName == "" && len(lintConf.KeyModels) == 0 {
+		example := DefaultConfig()
+		lintConf.RequestType, lintConf.ResponseType = example.RequestType, example.ResponseType
+		lintConf.ExtraType, lintConf.ExtraConstructor = example.ExtraType, example.ExtraConstructor
+	}
+
+	req, err := NewDescriptorSetRequest(lintDescriptorSet(lintKeys), []string{lintProtoFile})
+	if err != nil {
+		return err
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		return err
+	}
+	DeclareSupport(gen)
+	if err := Run(gen, &lintConf); err != nil {
+		return err
+	}
+	// Response formats the generated Go files and reports output that does
+	// not parse.
+	if resp := gen.Response(); resp.Error != nil {
+		return fmt.Errorf("%s", resp.GetError())
+	}
+	return nil
+}
+
+// lintDescriptorSet returns the fixture file, routing every method of its
+// LintService under each of keys, with the options proto it imports.
+func lintDescriptorSet(keys []string) *descriptorpb.FileDescriptorSet {
+	message := func(name string, fields ...*descriptorpb.FieldDescriptorProto) *descriptorpb.DescriptorProto {
+		return &descriptorpb.DescriptorProto{Name: proto.String(name), Field: fields}
+	}
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type) *descriptorpb.FieldDescriptorProto {
+		return &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Label:    descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL.Enum(),
+			Type:     typ.Enum(),
+		}
+	}
+	file := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String(lintProtoFile),
+		Package:    proto.String("lint.v1"),
+		Dependency: []string{options.File_sphere_options_options_proto.Path()},
+		Syntax:     proto.String("proto3"),
+		Options:    &descriptorpb.FileOptions{GoPackage: proto.String("example.com/lint/v1;lintv1")},
+		Service:    []*descriptorpb.ServiceDescriptorProto{{Name: proto.String("LintService")}},
+	}
+	service := file.Service[0]
+	for _, m := range lintMethods {
+		file.MessageType = append(file.MessageType,
+			message(m.name+"Request",
+				field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64),
+				field("text", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING),
+			),
+			message(m.name+"Response"),
+		)
+		rules := make([]*options.KeyValuePair, len(keys))
+		for i, key := range keys {
+			rules[i] = &options.KeyValuePair{Key: key, Extra: m.extra}
+		}
+		opts := &descriptorpb.MethodOptions{}
+		proto.SetExtension(opts, options.E_Options, rules)
+		service.Method = append(service.Method, &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(m.name),
+			InputType:  proto.String(".lint.v1." + m.name + "Request"),
+			OutputType: proto.String(".lint.v1." + m.name + "Response"),
+			Options:    opts,
+		})
+	}
+	return &descriptorpb.FileDescriptorSet{File: []*descriptorpb.FileDescriptorProto{
+		protodesc.ToFileDescriptorProto(descriptorpb.File_google_protobuf_descriptor_proto),
+		protodesc.ToFileDescriptorProto(options.File_sphere_options_options_proto),
+		file,
+	}}
+}
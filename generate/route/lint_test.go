@@ -0,0 +1,48 @@
+package route
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+)
+
+func TestLintTemplate(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	bot, err := template.BuiltinTemplate("bot")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name    string
+		source  string
+		keys    string
+		wantErr string
+	}{
+		{"built-in bot template", bot, "bot;job", ""},
+		{"key pattern", "// {{.ServiceType}}\n", "*", ""},
+		{"unknown field", "// {{range .Methods}}{{.Commment}}{{end}}\n", "route", "Commment"},
+		{"invalid Go", "func {{.ServiceType}}( {\n", "route", "rendered invalid Go"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "route.tmpl")
+			if err := os.WriteFile(path, []byte(tt.source), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			conf := &Config{OptionsKey: tt.keys, TemplateFile: path}
+			err := LintTemplate(conf)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("LintTemplate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("LintTemplate() = %v, want an error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
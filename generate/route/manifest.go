@@ -0,0 +1,149 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// Supported route manifest formats.
+const (
+	ManifestJSON = "json"
+	ManifestYAML = "yaml"
+)
+
+// manifest is the machine-readable description of the routes generated for a
+// single proto file and options key.
+type manifest struct {
+	Source     string            `json:"source"`
+	OptionsKey string            `json:"options_key"`
+	Services   []manifestService `json:"services"`
+}
+
+type manifestService struct {
+	Name    string           `json:"name"`
+	Type    string           `json:"type"`
+	Methods []manifestMethod `json:"methods"`
+}
+
+type manifestMethod struct {
+	// Name is the proto method name and RouteName the name the generated
+	// code uses for it, replaced by its name extra, if any.
+	Name          string            `json:"name"`
+	RouteName     string            `json:"route_name"`
+	Operation     string            `json:"operation"`
+	OperationPath string            `json:"operation_path"`
+	Request       string            `json:"request"`
+	Reply         string            `json:"reply"`
+	Comment       string            `json:"comment,omitempty"`
+	Extra         map[string]string `json:"extra,omitempty"`
+}
+
+// generateManifest emits the .<key>.routes.<format> sidecar for the services
+// rendered into the Go file, in the directory of the Go file.
+func generateManifest(gen *protogen.Plugin, file *protogen.File, conf *Config, services []*template.ServiceDesc) error {
+	m := buildManifest(file.Desc.Path(), conf.OptionsKey, services)
+	content, err := marshalManifest(m, conf.Manifest)
+	if err != nil {
+		return err
+	}
+	_, prefix := conf.goPackage(file)
+	filename := prefix + fmt.Sprintf(".%s.routes.%s", strings.ToLower(conf.OptionsKey), conf.Manifest)
+	g := gen.NewGeneratedFile(filename, "")
+	_, err = g.Write(content)
+	return err
+}
+
+func buildManifest(source, optionsKey string, services []*template.ServiceDesc) *manifest {
+	m := &manifest{
+		Source:     source,
+		OptionsKey: optionsKey,
+		Services:   make([]manifestService, 0, len(services)),
+	}
+	for _, sd := range services {
+		ms := manifestService{
+			Name:    sd.ServiceName,
+			Type:    sd.ServiceType,
+			Methods: make([]manifestMethod, 0, len(sd.Methods)),
+		}
+		for _, md := range sd.Methods {
+			ms.Methods = append(ms.Methods, manifestMethod{
+				Name:          md.ProtoName,
+				RouteName:     md.OriginalName,
+				Operation:     md.Operation,
+				OperationPath: md.OperationPath,
+				Request:       md.RequestMessage,
+				Reply:         md.ReplyMessage,
+				Comment:       md.LeadingComment,
+				Extra:         md.Extra,
+			})
+		}
+		m.Services = append(m.Services, ms)
+	}
+	return m
+}
+
+func marshalManifest(m *manifest, format string) ([]byte, error) {
+	switch format {
+	case ManifestJSON:
+		content, err := json.MarshalIndent(m, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(content, '\n'), nil
+	case ManifestYAML:
+		return marshalManifestYAML(m), nil
+	default:
+		return nil, fmt.Errorf("invalid manifest format %q", format)
+	}
+}
+
+// marshalManifestYAML renders m as YAML. Scalars are written as JSON strings,
+// which are valid YAML double-quoted scalars, so no YAML library is needed.
+func marshalManifestYAML(m *manifest) []byte {
+	var b strings.Builder
+	q := func(s string) string {
+		raw, _ := json.Marshal(s)
+		return string(raw)
+	}
+	fmt.Fprintf(&b, "source: %s\n", q(m.Source))
+	fmt.Fprintf(&b, "options_key: %s\n", q(m.OptionsKey))
+	if len(m.Services) == 0 {
+		b.WriteString("services: []\n")
+		return []byte(b.String())
+	}
+	b.WriteString("services:\n")
+	for _, ms := range m.Services {
+		fmt.Fprintf(&b, "  - name: %s\n", q(ms.Name))
+		fmt.Fprintf(&b, "    type: %s\n", q(ms.Type))
+		b.WriteString("    methods:\n")
+		for _, mm := range ms.Methods {
+			fmt.Fprintf(&b, "      - name: %s\n", q(mm.Name))
+			fmt.Fprintf(&b, "        route_name: %s\n", q(mm.RouteName))
+			fmt.Fprintf(&b, "        operation: %s\n", q(mm.Operation))
+			fmt.Fprintf(&b, "        operation_path: %s\n", q(mm.OperationPath))
+			fmt.Fprintf(&b, "        request: %s\n", q(mm.Request))
+			fmt.Fprintf(&b, "        reply: %s\n", q(mm.Reply))
+			if mm.Comment != "" {
+				fmt.Fprintf(&b, "        comment: %s\n", q(mm.Comment))
+			}
+			if len(mm.Extra) == 0 {
+				continue
+			}
+			b.WriteString("        extra:\n")
+			keys := make([]string, 0, len(mm.Extra))
+			for key := range mm.Extra {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			for _, key := range keys {
+				fmt.Fprintf(&b, "          %s: %s\n", q(key), q(mm.Extra[key]))
+			}
+		}
+	}
+	return []byte(b.String())
+}
@@ -0,0 +1,81 @@
+package route
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+// generateSidecar runs GenerateFile with conf and returns the content of the
+// generated file whose name ends with suffix.
+func generateSidecar(t *testing.T, pbFile, protoName string, conf *Config, suffix string) []byte {
+	t.Helper()
+	set := testutil.LoadDescriptorSet(t, pbFile)
+	plugin := testutil.MustCreatePlugin(t, set, protoName)
+	file := testutil.FileToGenerate(t, plugin)
+	if _, err := GenerateFile(plugin, file, conf); err != nil {
+		t.Fatalf("GenerateFile failed: %v", err)
+	}
+	for _, f := range plugin.Response().GetFile() {
+		if strings.HasSuffix(f.GetName(), suffix) {
+			return []byte(f.GetContent())
+		}
+	}
+	t.Fatalf("no generated file with suffix %q", suffix)
+	return nil
+}
+
+func TestGoldenManifest(t *testing.T) {
+	for _, format := range []string{ManifestJSON, ManifestYAML} {
+		t.Run(format, func(t *testing.T) {
+			conf := DefaultConfig()
+			conf.Manifest = format
+			content := generateSidecar(t, "testdata/pb/basic.pb", "basic.proto", conf, ".route.routes."+format)
+			compareGolden(t, "testdata/golden/basic.route.routes."+format, content)
+		})
+	}
+}
+
+func TestManifestJSONRoundTrip(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Manifest = ManifestJSON
+	content := generateSidecar(t, "testdata/pb/complex.pb", "complex.proto", conf, ".route.routes.json")
+
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	if len(m.Services) != 2 {
+		t.Fatalf("services = %d, want 2", len(m.Services))
+	}
+	user := m.Services[1]
+	if user.Name != "testdata.complex.v1.UserService" || len(user.Methods) != 1 {
+		t.Fatalf("unexpected UserService entry: %+v", user)
+	}
+	if got := user.Methods[0].Extra["scope"]; got != "user" {
+		t.Errorf("UserService.Create extra scope = %q, want %q", got, "user")
+	}
+}
+
+// TestManifest_NameExtra verifies that the manifest reports both the proto
+// method name and the name extra replacing it.
+func TestManifest_NameExtra(t *testing.T) {
+	conf := DefaultConfig()
+	conf.Manifest = ManifestJSON
+	content := generateSidecar(t, "testdata/pb/aliases.pb", "aliases.proto", conf, ".route.routes.json")
+
+	var m manifest
+	if err := json.Unmarshal(content, &m); err != nil {
+		t.Fatalf("manifest is not valid JSON: %v", err)
+	}
+	got := make(map[string]string)
+	for _, mm := range m.Services[0].Methods {
+		got[mm.Name] = mm.RouteName
+	}
+	if want := map[string]string{"Help": "ShowHelp", "Start": "Start"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("manifest names = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,61 @@
+package route
+
+import (
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// generateMock emits Mock<Server>, a stub implementation of the server
+// interface of sd for unit tests: every method calls the func field named
+// after it and records the call, and returns an error when the field is nil.
+// Only the methods routed under the options key are part of the interface, so
+// no gRPC mock is needed. Like the type registry it is written in Go so custom
+// templates get it too, provided they declare the ServerName interface as the
+// built-in templates do.
+func generateMock(g *protogen.GeneratedFile, sd *template.ServiceDesc) {
+	context := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Context", GoImportPath: "context"})
+	mutex := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Mutex", GoImportPath: "sync"})
+	newError := g.QualifiedGoIdent(protogen.GoIdent{GoName: "New", GoImportPath: "errors"})
+	name := "Mock" + sd.ServerName
+
+	// The interface has one method per Go name, as in the built-in templates;
+	// Num numbers the methods sharing one across the services of the file.
+	var methods []*template.MethodDesc
+	for _, goName := range sortedKeys(sd.MethodSets) {
+		methods = append(methods, sd.MethodSets[goName])
+	}
+
+	g.P("// ", name, " implements ", sd.ServerName, " for tests. Every method")
+	g.P("// records the call and calls the func field named after it, failing when")
+	g.P("// the field is nil.")
+	g.P("type ", name, " struct {")
+	for _, md := range methods {
+		g.P(md.Name, "Func func(ctx ", context, ", req *", md.Request, ") (*", md.Reply, ", error)")
+	}
+	g.P()
+	g.P("mu    ", mutex)
+	g.P("calls []string")
+	g.P("}")
+	g.P()
+	g.P("var _ ", sd.ServerName, " = (*", name, ")(nil)")
+	g.P()
+	for _, md := range methods {
+		g.P("func (m *", name, ") ", md.Name, "(ctx ", context, ", req *", md.Request, ") (*", md.Reply, ", error) {")
+		g.P("m.mu.Lock()")
+		g.P("m.calls = append(m.calls, ", `"`, md.Name, `"`, ")")
+		g.P("m.mu.Unlock()")
+		g.P("if m.", md.Name, "Func == nil {")
+		g.P("return nil, ", newError, `("`, name, ": ", md.Name, ` is not stubbed")`)
+		g.P("}")
+		g.P("return m.", md.Name, "Func(ctx, req)")
+		g.P("}")
+		g.P()
+	}
+	g.P("// Calls returns the names of the methods called so far, in call order.")
+	g.P("func (m *", name, ") Calls() []string {")
+	g.P("m.mu.Lock()")
+	g.P("defer m.mu.Unlock()")
+	g.P("return append([]string(nil), m.calls...)")
+	g.P("}")
+	g.P()
+}
@@ -0,0 +1,435 @@
+package route
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-sphere/options/sphere/options"
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// hasOptionsRule reports whether any method carries a rule of ext for key.
+// Invalid rules count as present so that generation runs and reports them.
+func hasOptionsRule(services []*protogen.Service, key string, ext ruleExtension) bool {
+	for _, service := range services {
+		for _, method := range service.Methods {
+			rule, err := extractOptionsRule(method, key, ext)
+			if rule != nil || err != nil {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expandOptionsKeys replaces every pattern of keys, see IsOptionsKeyPattern, by
+// the sorted keys of the method rules of the files to generate it matches, and
+// drops keys listed earlier. A pattern matching no key is dropped with a
+// warning.
+func expandOptionsKeys(gen *protogen.Plugin, keys []string, ext ruleExtension) []string {
+	var found []string
+	for _, key := range keys {
+		if IsOptionsKeyPattern(key) {
+			found = methodOptionsKeys(gen, ext)
+			break
+		}
+	}
+	var expanded []string
+	for _, key := range keys {
+		if !IsOptionsKeyPattern(key) {
+			if !slices.Contains(expanded, key) {
+				expanded = append(expanded, key)
+			}
+			continue
+		}
+		matched := false
+		for _, k := range found {
+			if ok, _ := path.Match(key, k); ok {
+				matched = true
+				if !slices.Contains(expanded, k) {
+					expanded = append(expanded, k)
+				}
+			}
+		}
+		if !matched {
+			fmt.Fprintf(warnings, "protoc-gen-route: warning: options_key pattern %q matches no key of the files to generate\n", key)
+		}
+	}
+	return expanded
+}
+
+// methodOptionsKeys returns the sorted, distinct keys of the method rules of
+// ext of the files to generate.
+func methodOptionsKeys(gen *protogen.Plugin, ext ruleExtension) []string {
+	seen := make(map[string]bool)
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				rules, _ := readRules(method.Desc.Options(), ext)
+				for _, rule := range rules {
+					seen[rule.GetKey()] = true
+				}
+			}
+		}
+	}
+	return sortedKeys(seen)
+}
+
+// checkUnmatchedKeys reports the methods of the files to generate carrying
+// rules, all of them for keys other than keys: a misspelt key would otherwise
+// generate nothing without a word. A rule key close to one of keys is pointed
+// out.
+// They are warnings, or errors returned together when strict is set.
+func checkUnmatchedKeys(gen *protogen.Plugin, keys []string, ext ruleExtension, strict bool) error {
+	var errs []error
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				rules, _ := readRules(method.Desc.Options(), ext)
+				var ruleKeys []string
+				for _, rule := range rules {
+					if !slices.Contains(ruleKeys, rule.GetKey()) {
+						ruleKeys = append(ruleKeys, rule.GetKey())
+					}
+				}
+				if len(ruleKeys) == 0 || slices.ContainsFunc(ruleKeys, func(key string) bool { return slices.Contains(keys, key) }) {
+					continue
+				}
+				quoted := make([]string, len(ruleKeys))
+				for i, key := range ruleKeys {
+					quoted[i] = strconv.Quote(key)
+				}
+				message := fmt.Sprintf("routed only under key %s, which options_key %q does not select", strings.Join(quoted, ", "), strings.Join(keys, ";"))
+				if ruleKey, key := closestKey(ruleKeys, keys); key != "" {
+					message += fmt.Sprintf("; %q is close to %q", ruleKey, key)
+				}
+				if strict {
+					errs = append(errs, descriptorErrorf(method.Desc, "%s", message))
+				} else {
+					warnf(warnings, method.Desc, "%s", message)
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// closestKey returns the closest pair of a rule key and a key of keys within
+// two edits of each other, or empty strings when there is none.
+func closestKey(ruleKeys, keys []string) (ruleKey, key string) {
+	bestDistance := 3
+	for _, r := range ruleKeys {
+		for _, k := range keys {
+			if d := editDistance(r, k); d < bestDistance {
+				ruleKey, key, bestDistance = r, k, d
+			}
+		}
+	}
+	return ruleKey, key
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(prev[j]+1, current[j-1]+1, prev[j-1]+cost)
+		}
+		prev = current
+	}
+	return prev[len(b)]
+}
+
+// extractOptionsRule returns the method's rule of ext for key, or nil when it
+// has none. A method may carry rules for several keys, one (sphere.options.options)
+// block each, and is generated independently for each of them; two blocks for
+// the same key are ambiguous and rejected.
+func extractOptionsRule(method *protogen.Method, key string, ext ruleExtension) (*options.KeyValuePair, error) {
+	rules, err := readRules(method.Desc.Options(), ext)
+	if err != nil {
+		return nil, descriptorErrorf(method.Desc, "%v", err)
+	}
+	var found *options.KeyValuePair
+	for _, rule := range rules {
+		if rule.GetKey() != key {
+			continue
+		}
+		if found != nil {
+			return nil, descriptorErrorf(method.Desc, "multiple (%s) rules for key %q", ext.name, key)
+		}
+		found = rule
+	}
+	return found, nil
+}
+
+// extractServiceRule returns the service-level rule for key, or nil when the
+// service carries none.
+//
+// sphere.options only extends MethodOptions, so service rules are declared by
+// the user as a ServiceOptions extension of type repeated KeyValuePair that
+// reuses the field number of ext. That extension is not registered in the Go
+// type registry, so readRules finds it in the unknown fields of the service
+// options.
+func extractServiceRule(service *protogen.Service, key string, ext ruleExtension) (*options.KeyValuePair, error) {
+	rules, err := readRules(service.Desc.Options(), ext)
+	if err != nil {
+		return nil, fmt.Errorf("service %s: %w", service.Desc.FullName(), err)
+	}
+	for _, rule := range rules {
+		if rule.GetKey() == key {
+			return rule, nil
+		}
+	}
+	return nil, nil
+}
+
+// extractMethodExtras returns the extras a method is generated with for key:
+// its own rule's extras merged over the service defaults, with overrides laid
+// over them and their placeholders replaced by vars. ok is false when
+// the method carries no rule for key, is excluded by a skip extra or by tags,
+// or is a streaming method and includeStreaming is false.
+func extractMethodExtras(service *protogen.Service, method *protogen.Method, key string, ext ruleExtension, includeStreaming bool, overrides ExtrasOverrides, vars map[string]string, tags tagFilter) (extra map[string]string, ok bool, err error) {
+	if isStreaming(method) && !includeStreaming {
+		return nil, false, nil
+	}
+	rule, err := extractOptionsRule(method, key, ext)
+	if err != nil || rule == nil {
+		return nil, false, err
+	}
+	serviceRule, err := extractServiceRule(service, key, ext)
+	if err != nil {
+		return nil, false, err
+	}
+	extra, err = interpolateExtras(method.Desc, overrides.apply(method, key, mergeExtras(serviceRule.GetExtra(), rule.GetExtra())), vars)
+	if err != nil {
+		return nil, false, err
+	}
+	skip, err := isSkipped(method, extra)
+	if err != nil || skip || tags.excludes(extra) {
+		return nil, false, err
+	}
+	return extra, true, nil
+}
+
+// isStreaming reports whether method is client or server streaming.
+func isStreaming(method *protogen.Method) bool {
+	return method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer()
+}
+
+// isSkipped reports whether the extras exclude the method from generation
+// through a true skip extra. A service-level skip can be overridden by a
+// method-level "false".
+func isSkipped(method *protogen.Method, extra map[string]string) (bool, error) {
+	raw, ok := extra[ExtraSkip]
+	if !ok {
+		return false, nil
+	}
+	skip, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, descriptorErrorf(method.Desc, "extra %q is not a bool: %q", ExtraSkip, raw)
+	}
+	return skip, nil
+}
+
+// tagFilter selects methods by their tags and env extras, see
+// Config.IncludeTags, Config.ExcludeTags and Config.Env.
+type tagFilter struct {
+	include []string
+	exclude []string
+	env     string
+}
+
+// tagFilter returns the tag filter of c.
+func (c *Config) tagFilter() tagFilter {
+	return tagFilter{include: c.IncludeTags, exclude: c.ExcludeTags, env: c.Env}
+}
+
+// excludes reports whether f leaves out a method with extra: one listing an
+// excluded tag, one whose env extra does not list the environment of f, or,
+// when f includes tags, one listing none of them.
+func (f tagFilter) excludes(extra map[string]string) bool {
+	return f.excludeReason(extra) != ""
+}
+
+// excludeReason returns why f leaves out a method with extra, or "" when f
+// keeps it; see excludes.
+func (f tagFilter) excludeReason(extra map[string]string) string {
+	if envs, ok := extra[ExtraEnv]; ok && f.env != "" && !slices.Contains(splitExtraList(envs), f.env) {
+		return fmt.Sprintf("%s extra %q does not list %q", ExtraEnv, envs, f.env)
+	}
+	tags := splitExtraList(extra[ExtraTags])
+	for _, tag := range tags {
+		if slices.Contains(f.exclude, tag) {
+			return fmt.Sprintf("tag %q is excluded", tag)
+		}
+	}
+	if len(f.include) == 0 {
+		return ""
+	}
+	for _, tag := range tags {
+		if slices.Contains(f.include, tag) {
+			return ""
+		}
+	}
+	return "no tag is included"
+}
+
+// validatesRequest reports whether the method's decoded request is validated:
+// enabled is the invocation-wide setting, which a false validate extra turns
+// off for the method.
+func validatesRequest(method *protogen.Method, extra map[string]string, enabled bool) (bool, error) {
+	raw, ok := extra[ExtraValidate]
+	if !ok {
+		return enabled, nil
+	}
+	validate, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, descriptorErrorf(method.Desc, "extra %q is not a bool: %q", ExtraValidate, raw)
+	}
+	return enabled && validate, nil
+}
+
+// routeTimeout parses the timeout extra, a positive time.ParseDuration value
+// such as "30s". A missing extra yields 0, no timeout.
+func routeTimeout(method *protogen.Method, extra map[string]string) (time.Duration, error) {
+	raw, ok := extra[ExtraTimeout]
+	if !ok {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(raw)
+	if err != nil || timeout <= 0 {
+		return 0, descriptorErrorf(method.Desc, "extra %q is not a positive duration: %q", ExtraTimeout, raw)
+	}
+	return timeout, nil
+}
+
+// rateLimitUnits are the period names of a rate_limit extra; any other period
+// is parsed as a Go duration.
+var rateLimitUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "second": time.Second,
+	"m": time.Minute, "min": time.Minute, "minute": time.Minute,
+	"h": time.Hour, "hour": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour,
+}
+
+// routeRateLimit parses the rate_limit extra, a positive count of requests per
+// period such as "10/min", "100/hour" or "3/30s". A missing extra yields nil.
+func routeRateLimit(method *protogen.Method, extra map[string]string) (*template.RateLimitDesc, error) {
+	raw, ok := extra[ExtraRateLimit]
+	if !ok {
+		return nil, nil
+	}
+	fail := func() error {
+		return descriptorErrorf(method.Desc, "extra %q is not a positive count per period such as \"10/min\": %q", ExtraRateLimit, raw)
+	}
+	rawCount, rawPeriod, ok := strings.Cut(raw, "/")
+	if !ok {
+		return nil, fail()
+	}
+	count, err := strconv.Atoi(strings.TrimSpace(rawCount))
+	if err != nil || count <= 0 {
+		return nil, fail()
+	}
+	rawPeriod = strings.TrimSpace(rawPeriod)
+	period, ok := rateLimitUnits[rawPeriod]
+	if !ok {
+		if period, err = time.ParseDuration(rawPeriod); err != nil || period <= 0 {
+			return nil, fail()
+		}
+	}
+	return &template.RateLimitDesc{Count: count, Period: period}, nil
+}
+
+// routeRetries parses the retry extra, the number of times a failed call is
+// retried. A missing extra yields 0.
+func routeRetries(method *protogen.Method, extra map[string]string) (int, error) {
+	raw, ok := extra[ExtraRetry]
+	if !ok {
+		return 0, nil
+	}
+	retries, err := strconv.Atoi(raw)
+	if err != nil || retries < 0 {
+		return 0, descriptorErrorf(method.Desc, "extra %q is not a non-negative integer: %q", ExtraRetry, raw)
+	}
+	return retries, nil
+}
+
+// routePriority parses the priority extra, an integer. A missing extra yields
+// 0.
+func routePriority(method *protogen.Method, extra map[string]string) (int, error) {
+	raw, ok := extra[ExtraPriority]
+	if !ok {
+		return 0, nil
+	}
+	priority, err := strconv.Atoi(raw)
+	if err != nil {
+		return 0, descriptorErrorf(method.Desc, "extra %q is not an integer: %q", ExtraPriority, raw)
+	}
+	return priority, nil
+}
+
+// methodDeprecation reports whether method is deprecated, by its deprecated
+// option or its deprecated extra, and the migration notice of the extra: a
+// value other than "true" or "false".
+func methodDeprecation(method *protogen.Method, extra map[string]string) (bool, string) {
+	deprecated := method.Desc.Options().(*descriptorpb.MethodOptions).GetDeprecated()
+	raw, ok := extra[ExtraDeprecated]
+	if !ok {
+		return deprecated, ""
+	}
+	if marked, err := strconv.ParseBool(raw); err == nil {
+		return deprecated || marked, ""
+	}
+	return true, strings.TrimSpace(raw)
+}
+
+// splitExtraList splits a comma-separated extra value such as "start,begin"
+// into its trimmed, non-empty elements, like MethodDesc.ExtraStrings.
+func splitExtraList(raw string) []string {
+	var list []string
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// mergeExtras returns the service defaults overlaid with the method extras, so
+// method values win. The inputs are not modified; nil is returned when both
+// are empty.
+func mergeExtras(defaults, extra map[string]string) map[string]string {
+	if len(defaults) == 0 {
+		return extra
+	}
+	merged := make(map[string]string, len(defaults)+len(extra))
+	for key, value := range defaults {
+		merged[key] = value
+	}
+	for key, value := range extra {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,349 @@
+package route
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestMergeExtras(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults map[string]string
+		extra    map[string]string
+		want     map[string]string
+	}{
+		{"both empty", nil, nil, nil},
+		{"no defaults", nil, map[string]string{"command": "start"}, map[string]string{"command": "start"}},
+		{"defaults only", map[string]string{"group": "admin"}, nil, map[string]string{"group": "admin"}},
+		{
+			"method wins",
+			map[string]string{"group": "admin", "chat_type": "group"},
+			map[string]string{"group": "public", "command": "stats"},
+			map[string]string{"group": "public", "chat_type": "group", "command": "stats"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mergeExtras(tt.defaults, tt.extra); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("mergeExtras() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	defaults := map[string]string{"group": "admin"}
+	mergeExtras(defaults, map[string]string{"group": "public"})
+	if defaults["group"] != "admin" {
+		t.Error("mergeExtras modified the defaults map")
+	}
+}
+
+// TestExtractMethodExtras_RepeatedBlocks verifies that the rule blocks of one
+// method are read per key: each key sees only the extras of its own block.
+func TestExtractMethodExtras_RepeatedBlocks(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/multi_key.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "multi_key.proto"))
+	service := file.Services[0]
+	daily := service.Methods[0]
+	want := map[string]map[string]string{
+		"route": {"command": "daily"},
+		"job":   {"cron": "0 9 * * *"},
+	}
+	for key, extra := range want {
+		got, ok, err := extractMethodExtras(service, daily, key, sphereRules, false, nil, nil, tagFilter{})
+		if err != nil || !ok {
+			t.Fatalf("extractMethodExtras(%q) = %v, %v, %v", key, got, ok, err)
+		}
+		if !reflect.DeepEqual(got, extra) {
+			t.Errorf("extractMethodExtras(%q) = %v, want %v", key, got, extra)
+		}
+	}
+	if _, ok, err := extractMethodExtras(service, service.Methods[1], "job", sphereRules, false, nil, nil, tagFilter{}); ok || err != nil {
+		t.Errorf("Weekly has no job block, got ok = %v, err = %v", ok, err)
+	}
+}
+
+func TestIsSkipped(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/skip.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "skip.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	tests := []struct {
+		extra   map[string]string
+		want    bool
+		wantErr bool
+	}{
+		{nil, false, false},
+		{map[string]string{"skip": "true"}, true, false},
+		{map[string]string{"skip": "false"}, false, false},
+		{map[string]string{"skip": "maybe"}, false, true},
+	}
+	for _, tt := range tests {
+		got, err := isSkipped(method, tt.extra)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("isSkipped(%v) = %v, %v; want %v, error %v", tt.extra, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestValidatesRequest(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/validate.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "validate.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	tests := []struct {
+		extra   map[string]string
+		enabled bool
+		want    bool
+		wantErr bool
+	}{
+		{nil, false, false, false},
+		{nil, true, true, false},
+		{map[string]string{"validate": "false"}, true, false, false},
+		{map[string]string{"validate": "true"}, false, false, false},
+		{map[string]string{"validate": "sometimes"}, true, false, true},
+	}
+	for _, tt := range tests {
+		got, err := validatesRequest(method, tt.extra, tt.enabled)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("validatesRequest(%v, %v) = %v, %v; want %v, error %v", tt.extra, tt.enabled, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRouteTimeoutAndRetries(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/timeout.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "timeout.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	timeouts := []struct {
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"30s", 30 * time.Second, false},
+		{"1h15m", 75 * time.Minute, false},
+		{"0s", 0, true},
+		{"-1s", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range timeouts {
+		got, err := routeTimeout(method, map[string]string{ExtraTimeout: tt.raw})
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("routeTimeout(%q) = %v, %v; want %v, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+	if got, err := routeTimeout(method, nil); got != 0 || err != nil {
+		t.Errorf("routeTimeout(nil) = %v, %v", got, err)
+	}
+
+	retries := []struct {
+		raw     string
+		want    int
+		wantErr bool
+	}{
+		{"0", 0, false},
+		{"5", 5, false},
+		{"-1", 0, true},
+		{"twice", 0, true},
+	}
+	for _, tt := range retries {
+		got, err := routeRetries(method, map[string]string{ExtraRetry: tt.raw})
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("routeRetries(%q) = %v, %v; want %v, error %v", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestRouteRateLimit(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/rate_limit.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "rate_limit.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	tests := []struct {
+		raw        string
+		wantCount  int
+		wantPeriod time.Duration
+		wantErr    bool
+	}{
+		{"10/min", 10, time.Minute, false},
+		{"100 / hour", 100, time.Hour, false},
+		{"5/s", 5, time.Second, false},
+		{"1000/day", 1000, 24 * time.Hour, false},
+		{"3/90s", 3, 90 * time.Second, false},
+		{"10", 0, 0, true},
+		{"0/min", 0, 0, true},
+		{"ten/min", 0, 0, true},
+		{"10/fortnight", 0, 0, true},
+		{"10/-1s", 0, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := routeRateLimit(method, map[string]string{ExtraRateLimit: tt.raw})
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("routeRateLimit(%q) = %+v, want an error", tt.raw, got)
+			}
+			continue
+		}
+		if err != nil || got.Count != tt.wantCount || got.Period != tt.wantPeriod {
+			t.Errorf("routeRateLimit(%q) = %+v, %v; want %d per %v", tt.raw, got, err, tt.wantCount, tt.wantPeriod)
+		}
+	}
+	if got, err := routeRateLimit(method, nil); got != nil || err != nil {
+		t.Errorf("routeRateLimit(nil) = %v, %v", got, err)
+	}
+}
+
+func TestRoutePriority(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/priority.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "priority.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	tests := []struct {
+		extra   map[string]string
+		want    int
+		wantErr bool
+	}{
+		{nil, 0, false},
+		{map[string]string{ExtraPriority: "10"}, 10, false},
+		{map[string]string{ExtraPriority: "-3"}, -3, false},
+		{map[string]string{ExtraPriority: "high"}, 0, true},
+	}
+	for _, tt := range tests {
+		got, err := routePriority(method, tt.extra)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("routePriority(%v) = %v, %v; want %v, error %v", tt.extra, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMethodDeprecation(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/deprecated.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "deprecated.proto")
+	methods := testutil.FileToGenerate(t, plugin).Services[0].Methods
+	orders, list := methods[0], methods[1]
+
+	tests := []struct {
+		method     *protogen.Method
+		extra      map[string]string
+		want       bool
+		wantNotice string
+	}{
+		{orders, nil, false, ""},
+		{orders, map[string]string{ExtraDeprecated: "true"}, true, ""},
+		{orders, map[string]string{ExtraDeprecated: "false"}, false, ""},
+		{orders, map[string]string{ExtraDeprecated: " use /list instead "}, true, "use /list instead"},
+		{list, nil, true, ""},
+		{list, map[string]string{ExtraDeprecated: "false"}, true, ""},
+	}
+	for _, tt := range tests {
+		got, notice := methodDeprecation(tt.method, tt.extra)
+		if got != tt.want || notice != tt.wantNotice {
+			t.Errorf("methodDeprecation(%s, %v) = %v, %q; want %v, %q", tt.method.Desc.Name(), tt.extra, got, notice, tt.want, tt.wantNotice)
+		}
+	}
+}
+
+func TestCheckUnmatchedKeys(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/multi_key.pb")
+	var buf bytes.Buffer
+	stderr := warnings
+	t.Cleanup(func() { warnings = stderr })
+	warnings = &buf
+
+	plugin := testutil.MustCreatePlugin(t, set, "multi_key.proto")
+	if err := checkUnmatchedKeys(plugin, []string{"route", "job"}, sphereRules, true); err != nil {
+		t.Errorf("checkUnmatchedKeys() with every key selected = %v, want nil", err)
+	}
+	if err := checkUnmatchedKeys(plugin, []string{"job"}, sphereRules, false); err != nil {
+		t.Errorf("checkUnmatchedKeys() = %v, want warnings only", err)
+	}
+	if got, want := buf.String(), `ReportService.Weekly: routed only under key "route", which options_key "job" does not select`; !strings.Contains(got, want) {
+		t.Errorf("warnings = %q, want %q", got, want)
+	}
+	if strings.Contains(buf.String(), "Daily") {
+		t.Errorf("a method with a rule for a selected key was reported: %q", buf.String())
+	}
+
+	buf.Reset()
+	err := checkUnmatchedKeys(plugin, []string{"rout"}, sphereRules, true)
+	if err == nil {
+		t.Fatal("checkUnmatchedKeys() with strict keys = nil, want errors")
+	}
+	for _, want := range []string{`ReportService.Daily: routed only under key "route", "job"`, `"route" is close to "rout"`, "ReportService.Weekly"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("checkUnmatchedKeys() = %v, want %q", err, want)
+		}
+	}
+	if buf.Len() != 0 {
+		t.Errorf("strict keys also warned: %q", buf.String())
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	for _, tt := range []struct {
+		a, b string
+		want int
+	}{
+		{"bot", "bot", 0},
+		{"bott", "bot", 1},
+		{"bot", "job", 2},
+		{"", "mq", 2},
+		{"route", "rout", 1},
+	} {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestTagFilterExcludes(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter tagFilter
+		tags   string
+		want   bool
+	}{
+		{"no filter", tagFilter{}, "experimental", false},
+		{"untagged without include", tagFilter{exclude: []string{"experimental"}}, "", false},
+		{"excluded", tagFilter{exclude: []string{"experimental"}}, "admin, experimental", true},
+		{"included", tagFilter{include: []string{"admin"}}, "admin,payments", false},
+		{"not included", tagFilter{include: []string{"admin"}}, "payments", true},
+		{"untagged with include", tagFilter{include: []string{"admin"}}, "", true},
+		{"exclude wins", tagFilter{include: []string{"admin"}, exclude: []string{"experimental"}}, "admin,experimental", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.excludes(map[string]string{ExtraTags: tt.tags}); got != tt.want {
+				t.Errorf("excludes(%q) = %v, want %v", tt.tags, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTagFilterExcludesEnv(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter tagFilter
+		extra  map[string]string
+		want   bool
+	}{
+		{"no env", tagFilter{}, map[string]string{ExtraEnv: "dev"}, false},
+		{"every env", tagFilter{env: "prod"}, nil, false},
+		{"listed", tagFilter{env: "staging"}, map[string]string{ExtraEnv: "dev, staging"}, false},
+		{"not listed", tagFilter{env: "prod"}, map[string]string{ExtraEnv: "dev,staging"}, true},
+		{"excluded tag", tagFilter{env: "dev", exclude: []string{"experimental"}}, map[string]string{ExtraEnv: "dev", ExtraTags: "experimental"}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.excludes(tt.extra); got != tt.want {
+				t.Errorf("excludes(%v) = %v, want %v", tt.extra, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+package route
+
+import (
+	"regexp/syntax"
+	"strings"
+	"unicode"
+)
+
+// patternsOverlap reports whether a string exists that both regular
+// expressions match, the way regexp.MatchString matches: anywhere in the
+// string unless anchored. It returns such a string as an example. Word
+// boundaries, and line anchors in multi-line mode, are assumed to hold, so
+// two patterns differing only in those are reported as overlapping.
+func patternsOverlap(a, b string) (string, bool, error) {
+	progA, err := compileUnanchored(a)
+	if err != nil {
+		return "", false, err
+	}
+	progB, err := compileUnanchored(b)
+	if err != nil {
+		return "", false, err
+	}
+	example, ok := intersect(progA, progB)
+	return example, ok, nil
+}
+
+// compileUnanchored compiles pattern to match anywhere in a string: preceded
+// and followed by any text.
+func compileUnanchored(pattern string) (*syntax.Prog, error) {
+	if _, err := syntax.Parse(pattern, syntax.Perl); err != nil {
+		return nil, err
+	}
+	re, err := syntax.Parse(`(?s:.*)(?:`+pattern+`)(?s:.*)`, syntax.Perl)
+	if err != nil {
+		return nil, err
+	}
+	return syntax.Compile(re.Simplify())
+}
+
+// progState is a thread of a program: the instruction it waits at, a rune
+// instruction or the match, and whether an end-of-text anchor requires the
+// input to end there.
+type progState struct {
+	pc    uint32
+	atEnd bool
+}
+
+// pairState is a state of the product of two programs.
+type pairState struct {
+	a, b progState
+}
+
+// intersect searches the product of the programs breadth first for a string
+// both match, and returns the shortest one found.
+func intersect(a, b *syntax.Prog) (string, bool) {
+	type step struct {
+		prev int
+		r    rune
+	}
+	var (
+		queue []pairState
+		steps []step
+		seen  = make(map[pairState]bool)
+	)
+	push := func(prev int, r rune, threadsA, threadsB []progState) {
+		for _, ta := range threadsA {
+			for _, tb := range threadsB {
+				s := pairState{ta, tb}
+				if !seen[s] {
+					seen[s] = true
+					queue = append(queue, s)
+					steps = append(steps, step{prev, r})
+				}
+			}
+		}
+	}
+	push(-1, 0, closure(a, uint32(a.Start), true), closure(b, uint32(b.Start), true))
+	for i := 0; i < len(queue); i++ {
+		s := queue[i]
+		instA, instB := &a.Inst[s.a.pc], &b.Inst[s.b.pc]
+		if instA.Op == syntax.InstMatch && instB.Op == syntax.InstMatch {
+			var example []rune
+			for j := i; steps[j].prev >= 0; j = steps[j].prev {
+				example = append(example, steps[j].r)
+			}
+			var sb strings.Builder
+			for k := len(example) - 1; k >= 0; k-- {
+				sb.WriteRune(example[k])
+			}
+			return sb.String(), true
+		}
+		if s.a.atEnd || s.b.atEnd || instA.Op == syntax.InstMatch || instB.Op == syntax.InstMatch {
+			continue
+		}
+		if r, ok := commonRune(instA, instB); ok {
+			push(i, r, closure(a, instA.Out, false), closure(b, instB.Out, false))
+		}
+	}
+	return "", false
+}
+
+// closure returns the rune and match instructions reachable from pc without
+// consuming input; atStart reports whether no input was consumed yet.
+func closure(prog *syntax.Prog, pc uint32, atStart bool) []progState {
+	var (
+		states []progState
+		seen   = make(map[progState]bool)
+		walk   func(pc uint32, atEnd bool)
+	)
+	walk = func(pc uint32, atEnd bool) {
+		s := progState{pc, atEnd}
+		if seen[s] {
+			return
+		}
+		seen[s] = true
+		inst := &prog.Inst[pc]
+		switch inst.Op {
+		case syntax.InstAlt, syntax.InstAltMatch:
+			walk(inst.Out, atEnd)
+			walk(inst.Arg, atEnd)
+		case syntax.InstCapture, syntax.InstNop:
+			walk(inst.Out, atEnd)
+		case syntax.InstEmptyWidth:
+			op := syntax.EmptyOp(inst.Arg)
+			if op&syntax.EmptyBeginText != 0 && !atStart {
+				return
+			}
+			walk(inst.Out, atEnd || op&syntax.EmptyEndText != 0)
+		case syntax.InstRune, syntax.InstRune1, syntax.InstRuneAny, syntax.InstRuneAnyNotNL, syntax.InstMatch:
+			states = append(states, s)
+		}
+	}
+	walk(pc, false)
+	return states
+}
+
+// commonRune returns a rune both rune instructions match. A rune in the
+// intersection of two ranges is the larger of their lower bounds, so the
+// bounds of the ranges of a and b, and their case folds, are the candidates.
+func commonRune(a, b *syntax.Inst) (rune, bool) {
+	candidates := []rune{'a', '0', ' '}
+	for _, inst := range []*syntax.Inst{a, b} {
+		if inst.Op != syntax.InstRune && inst.Op != syntax.InstRune1 {
+			continue
+		}
+		for _, r := range inst.Rune {
+			candidates = append(candidates, r)
+			for f := unicode.SimpleFold(r); f != r; f = unicode.SimpleFold(f) {
+				candidates = append(candidates, f)
+			}
+		}
+	}
+	for _, r := range candidates {
+		if matchesRune(a, r) && matchesRune(b, r) {
+			return r, true
+		}
+	}
+	return 0, false
+}
+
+// matchesRune reports whether the rune instruction inst matches r.
+func matchesRune(inst *syntax.Inst, r rune) bool {
+	switch inst.Op {
+	case syntax.InstRuneAny:
+		return true
+	case syntax.InstRuneAnyNotNL:
+		return r != '\n'
+	default:
+		return inst.MatchRune(r)
+	}
+}
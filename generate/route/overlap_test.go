@@ -0,0 +1,39 @@
+package route
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestPatternsOverlap(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{`^item:`, `^item:delete:`, true},
+		{`^item:(\d+)$`, `^item:delete:`, false},
+		{`^buy:(\d+):(\d+)$`, `^buy:\d+$`, false},
+		{`^a$`, `^A$`, false},
+		{`(?i)^a$`, `^A$`, true},
+		{`^[a-c]+$`, `^[c-z]+$`, true},
+		{`^.$`, `^\n$`, false},
+		// Unanchored patterns match anywhere, so a value can hold both.
+		{`order`, `item`, true},
+	}
+	for _, tt := range tests {
+		example, got, err := patternsOverlap(tt.a, tt.b)
+		if err != nil {
+			t.Fatalf("patternsOverlap(%q, %q) failed: %v", tt.a, tt.b, err)
+		}
+		if got != tt.want {
+			t.Errorf("patternsOverlap(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			continue
+		}
+		if got && (!regexp.MustCompile(tt.a).MatchString(example) || !regexp.MustCompile(tt.b).MatchString(example)) {
+			t.Errorf("patternsOverlap(%q, %q) example %q does not match both", tt.a, tt.b, example)
+		}
+	}
+	if _, _, err := patternsOverlap(`^item:(\d+$`, `^item:`); err == nil {
+		t.Error("patternsOverlap() with an invalid pattern = nil error")
+	}
+}
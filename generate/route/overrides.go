@@ -0,0 +1,101 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"sigs.k8s.io/yaml"
+)
+
+// ExtrasOverrides adds or replaces extras per method without editing the proto
+// files, e.g. for a deployment: a mapping from a method's full name to options
+// key to extra key to value. An override applies to a method routed under the
+// key, over its own extras and the service defaults, before anything reads
+// them, so templates, checks and sidecars such as the manifest all see it.
+type ExtrasOverrides map[string]map[string]map[string]string
+
+// ReadExtrasOverrides reads ExtrasOverrides from a YAML or JSON file, e.g.
+//
+//	bot.v1.MenuService.UpdateCount:
+//	  bot:
+//	    timeout: 10s
+//	    group: staging
+//
+// Values may be strings, numbers or booleans; they are used as written.
+func ReadExtrasOverrides(path string) (ExtrasOverrides, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading extras_overrides: %w", err)
+	}
+	overrides, err := ParseExtrasOverrides(raw)
+	if err != nil {
+		return nil, fmt.Errorf("extras_overrides %s: %w", path, err)
+	}
+	return overrides, nil
+}
+
+// ParseExtrasOverrides parses the content of an extras overrides file, see
+// ReadExtrasOverrides.
+func ParseExtrasOverrides(raw []byte) (ExtrasOverrides, error) {
+	raw, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]map[string]map[string]any
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+	overrides := make(ExtrasOverrides, len(doc))
+	for method, keys := range doc {
+		overrides[method] = make(map[string]map[string]string, len(keys))
+		for key, extras := range keys {
+			overrides[method][key] = make(map[string]string, len(extras))
+			for extra, value := range extras {
+				switch value.(type) {
+				case string, bool, json.Number:
+					overrides[method][key][extra] = fmt.Sprint(value)
+				default:
+					return nil, fmt.Errorf("%s.%s.%s: value must be a scalar", method, key, extra)
+				}
+			}
+		}
+	}
+	return overrides, nil
+}
+
+// apply returns extra, the extras of method for key, with the overrides of
+// method for key laid over them.
+func (o ExtrasOverrides) apply(method *protogen.Method, key string, extra map[string]string) map[string]string {
+	override := o[string(method.Desc.FullName())][key]
+	if len(override) == 0 {
+		return extra
+	}
+	return mergeExtras(extra, override)
+}
+
+// warnUnknownOverrides warns about the methods of overrides that no file of gen
+// declares, which are most likely typos. Methods of files outside the request
+// cannot be told from typos, so this is not an error.
+func warnUnknownOverrides(gen *protogen.Plugin, overrides ExtrasOverrides) {
+	if len(overrides) == 0 {
+		return
+	}
+	known := make(map[string]bool)
+	for _, file := range gen.Files {
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				known[string(method.Desc.FullName())] = true
+			}
+		}
+	}
+	for _, name := range sortedKeys(overrides) {
+		if !known[name] {
+			fmt.Fprintf(warnings, "protoc-gen-route: warning: extras_overrides: no method %s\n", name)
+		}
+	}
+}
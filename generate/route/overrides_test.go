@@ -0,0 +1,97 @@
+package route
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestParseExtrasOverrides(t *testing.T) {
+	raw := `{"testdata.basic.v1.MenuService.GetMenu": {"route": {"command": "menu", "priority": 10, "validate": false}}}`
+	want := ExtrasOverrides{"testdata.basic.v1.MenuService.GetMenu": {"route": {"command": "menu", "priority": "10", "validate": "false"}}}
+	got, err := ParseExtrasOverrides([]byte(raw))
+	if err != nil {
+		t.Fatalf("ParseExtrasOverrides failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExtrasOverrides = %v, want %v", got, want)
+	}
+
+	if _, err := ParseExtrasOverrides([]byte(`{"a.B.C": {"route": {"command": ["a"]}}}`)); err == nil || !strings.Contains(err.Error(), "a.B.C.route.command: value must be a scalar") {
+		t.Errorf("ParseExtrasOverrides() with a list = %v", err)
+	}
+	if _, err := ParseExtrasOverrides([]byte(`{"a.B.C": {"route": "menu"}}`)); err == nil {
+		t.Error("ParseExtrasOverrides() with a scalar options key = nil error")
+	}
+	if _, err := ParseExtrasOverrides([]byte("a.B.C:\n  route: [menu\n")); err == nil {
+		t.Error("ParseExtrasOverrides() with invalid YAML = nil error")
+	}
+}
+
+func TestReadExtrasOverrides(t *testing.T) {
+	want := ExtrasOverrides{
+		"testdata.basic.v1.MenuService.GetMenu": {
+			"route": {"command": "menu", "priority": "10"},
+			"bot":   {"command": "start"},
+		},
+		"testdata.basic.v1.MenuService.UpdateCount": {
+			"route": {"validate": "false", "timeout": "10s"},
+		},
+	}
+	got, err := ReadExtrasOverrides("testdata/overrides.yaml")
+	if err != nil {
+		t.Fatalf("ReadExtrasOverrides failed: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ReadExtrasOverrides = %v, want %v", got, want)
+	}
+}
+
+// TestRun_ExtrasOverrides verifies that overrides reach the generated code and
+// the manifest, and that overrides of unknown methods are reported.
+func TestRun_ExtrasOverrides(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	var buf bytes.Buffer
+	stderr := warnings
+	t.Cleanup(func() { warnings = stderr })
+	warnings = &buf
+
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	conf := DefaultConfig()
+	conf.Manifest = ManifestJSON
+	conf.ExtrasOverrides = ExtrasOverrides{
+		"testdata.basic.v1.MenuService.GetMenu":     {"route": {"command": "menu"}, "bot": {"command": "ignored"}},
+		"testdata.basic.v1.MenuService.UpdateCount": {"route": {"command": "count"}},
+		"testdata.basic.v1.MenuService.Missing":     {"route": {"command": "missing"}},
+	}
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run() failed: %v", err)
+	}
+	var code, manifest string
+	for _, f := range plugin.Response().GetFile() {
+		switch {
+		case strings.HasSuffix(f.GetName(), ".pb.go"):
+			code = f.GetContent()
+		case strings.HasSuffix(f.GetName(), ".json"):
+			manifest = f.GetContent()
+		}
+	}
+	for _, want := range []string{`"menu":  OperationRouteMenuServiceGetMenu`, `"count": OperationRouteMenuServiceUpdateCount`} {
+		if !strings.Contains(code, want) {
+			t.Errorf("generated code does not contain %q", want)
+		}
+	}
+	if strings.Contains(code, "ignored") {
+		t.Error("an override of another options key was applied")
+	}
+	if !strings.Contains(manifest, `"command": "count"`) {
+		t.Errorf("manifest does not reflect the override:\n%s", manifest)
+	}
+	if want := "extras_overrides: no method testdata.basic.v1.MenuService.Missing"; !strings.Contains(buf.String(), want) {
+		t.Errorf("warnings = %q, want %q", buf.String(), want)
+	}
+}
@@ -0,0 +1,122 @@
+package route
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// renderedFile is the output of generating one proto file for one options key
+// apart from the plugin: the files generated for it, formatted, and the
+// warnings printed meanwhile. It is also the content of a cache entry.
+type renderedFile struct {
+	Files    []outputFile `json:"files"`
+	Warnings string       `json:"warnings,omitempty"`
+}
+
+// outputFile is a generated file of a renderedFile.
+type outputFile struct {
+	Name    string `json:"name"`
+	Content string `json:"content"`
+}
+
+// emit prints the warnings of out and adds its files to gen.
+func (out *renderedFile) emit(gen *protogen.Plugin) error {
+	fmt.Fprint(warnings, out.Warnings)
+	for _, f := range out.Files {
+		if _, err := gen.NewGeneratedFile(f.Name, "").Write([]byte(f.Content)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileResult is the result of rendering a proto file in renderFiles. services
+// is nil for an output served by the cache. elapsed is the time the file took,
+// looking up the cache included.
+type fileResult struct {
+	output   *renderedFile
+	services []*template.ServiceDesc
+	err      error
+	elapsed  time.Duration
+}
+
+// renderFiles renders files with conf on up to workers goroutines and returns
+// their results in the order of files, so that emitting them in turn yields
+// the same response as generating the files one after another. The outputs
+// go through cache unless it is nil or conf registers aggregates or emits a
+// TypeScript file, which need every rendered service.
+func renderFiles(gen *protogen.Plugin, files []*protogen.File, conf *Config, cache *generationCache, workers int) []fileResult {
+	results := make([]fileResult, len(files))
+	render := func(i int) {
+		start := time.Now()
+		defer func() { results[i].elapsed = time.Since(start) }()
+		if cache == nil || conf.RegisterAll || conf.TSOutFile != "" {
+			results[i].output, results[i].services, results[i].err = renderFile(gen, files[i], conf)
+			return
+		}
+		key, out, err := cache.lookup(files[i], conf)
+		if err == nil && out == nil {
+			out, _, err = renderFile(gen, files[i], conf)
+			if err == nil {
+				if sErr := cache.store(key, out); sErr != nil {
+					// Not stored with the entry: it concerns this run only.
+					stored := *out
+					stored.Warnings += fmt.Sprintf("protoc-gen-route: warning: cache_dir: %v\n", sErr)
+					out = &stored
+				}
+			}
+		}
+		results[i].output, results[i].err = out, err
+	}
+
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for range min(workers, len(files)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				render(i)
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+	return results
+}
+
+// renderFile generates file with conf into a plugin of its own, so that
+// several proto files can be generated at once, and returns its output with
+// the services it rendered.
+func renderFile(gen *protogen.Plugin, file *protogen.File, conf *Config) (*renderedFile, []*template.ServiceDesc, error) {
+	scratch, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{CompilerVersion: gen.Request.GetCompilerVersion()})
+	if err != nil {
+		return nil, nil, err
+	}
+	var printed bytes.Buffer
+	fileConf := *conf
+	fileConf.warnings = &printed
+	_, services, err := generateFile(scratch, file, &fileConf)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp := scratch.Response()
+	if resp.Error != nil {
+		return nil, nil, errors.New(resp.GetError())
+	}
+	out := &renderedFile{Warnings: printed.String()}
+	for _, f := range resp.GetFile() {
+		out.Files = append(out.Files, outputFile{Name: f.GetName(), Content: f.GetContent()})
+	}
+	return out, services, nil
+}
@@ -0,0 +1,36 @@
+package route
+
+import (
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestRun_Parallel(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/duplicate.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	generate := func(parallel int) *pluginpb.CodeGeneratorResponse {
+		t.Helper()
+		plugin := testutil.MustCreatePluginForFiles(t, set, "basic.proto", "duplicate.proto")
+		conf := DefaultConfig()
+		conf.Manifest = ManifestJSON
+		conf.RegisterAll = true
+		conf.Parallel = parallel
+		if err := Run(plugin, conf); err != nil {
+			t.Fatalf("Run() with parallel=%d failed: %v", parallel, err)
+		}
+		return plugin.Response()
+	}
+	serial := generate(1)
+	if len(serial.GetFile()) < 4 {
+		t.Fatalf("serial run generated %d files, want the Go files and manifests of two proto files and an aggregate", len(serial.GetFile()))
+	}
+	for _, parallel := range []int{0, 2, 8} {
+		if got := generate(parallel); !proto.Equal(got, serial) {
+			t.Errorf("parallel=%d generated a different response from a serial run", parallel)
+		}
+	}
+}
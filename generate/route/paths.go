@@ -0,0 +1,87 @@
+package route
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// outputPaths is how the request places generated files: the paths and
+// module parameters protoc-gen-go defines, which protogen applies to the
+// files it derives from a proto file but does not expose.
+type outputPaths struct {
+	// sourceRelative is set by paths=source_relative, which places the files
+	// of a proto file next to it instead of in the directory of its import
+	// path.
+	sourceRelative bool
+	// module is the module= prefix stripped from the generated file names.
+	module string
+}
+
+// parseOutputPaths reads the paths and module parameters from parameter, the
+// comma-separated parameter of a CodeGeneratorRequest.
+func parseOutputPaths(parameter string) outputPaths {
+	var paths outputPaths
+	for _, param := range strings.Split(parameter, ",") {
+		key, value, _ := strings.Cut(param, "=")
+		switch key {
+		case "paths":
+			paths.sourceRelative = value == "source_relative"
+		case "module":
+			paths.module = value
+		}
+	}
+	return paths
+}
+
+// overridePrefix returns the filename prefix of the files of file generated
+// into the GoPackageOverride package. In import mode it is the import path of
+// the package. With paths=source_relative the package is placed relative to
+// the directory of file like the import paths of the two packages are, so
+// api/bot/v1/bot.proto of example.com/api/bot/v1 generates into
+// internal/route/ for example.com/internal/route.
+func (c *Config) overridePrefix(file *protogen.File) string {
+	base := path.Base(file.GeneratedFilenamePrefix)
+	if !c.paths.sourceRelative {
+		return path.Join(string(c.GoPackageOverride.ImportPath), base)
+	}
+	from := strings.Split(string(file.GoImportPath), "/")
+	to := strings.Split(string(c.GoPackageOverride.ImportPath), "/")
+	common := 0
+	for common < len(from) && common < len(to) && from[common] == to[common] {
+		common++
+	}
+	elems := []string{path.Dir(file.GeneratedFilenamePrefix)}
+	for range from[common:] {
+		elems = append(elems, "..")
+	}
+	elems = append(elems, to[common:]...)
+	return path.Join(append(elems, base)...)
+}
+
+// checkOutputPaths reports a GoPackageOverride the output paths cannot place:
+// one outside the module= prefix, or one that paths=source_relative would
+// place above the output directory of a file to generate.
+func checkOutputPaths(gen *protogen.Plugin, conf *Config) error {
+	override := string(conf.GoPackageOverride.ImportPath)
+	if override == "" {
+		return nil
+	}
+	if module := conf.paths.module; module != "" && override != module && !strings.HasPrefix(override, module+"/") {
+		return fmt.Errorf("go_package_override %s is outside module %s", override, module)
+	}
+	if !conf.paths.sourceRelative {
+		return nil
+	}
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		if prefix := conf.overridePrefix(file); prefix == ".." || strings.HasPrefix(prefix, "../") {
+			return fmt.Errorf("go_package_override %s cannot be placed relative to %s with paths=source_relative: it would be generated outside the output directory; use module= instead", override, file.Desc.Path())
+		}
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package route
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestParseOutputPaths(t *testing.T) {
+	tests := []struct {
+		parameter string
+		want      outputPaths
+	}{
+		{"", outputPaths{}},
+		{"paths=import", outputPaths{}},
+		{"options_key=bot,paths=source_relative", outputPaths{sourceRelative: true}},
+		{"module=example.com/api,options_key=bot", outputPaths{module: "example.com/api"}},
+	}
+	for _, tt := range tests {
+		if got := parseOutputPaths(tt.parameter); got != tt.want {
+			t.Errorf("parseOutputPaths(%q) = %+v, want %+v", tt.parameter, got, tt.want)
+		}
+	}
+}
+
+// TestRun_GoPackageOverrideSourceRelative verifies that paths=source_relative
+// places the go_package_override package relative to the proto file, and
+// rejects a package it would place outside the output directory.
+func TestRun_GoPackageOverrideSourceRelative(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	run := func(parameter string, override protogen.GoImportPath) (*protogen.Plugin, error) {
+		plugin, err := protogen.Options{}.New(&pluginpb.CodeGeneratorRequest{
+			FileToGenerate: []string{"basic.proto"},
+			Parameter:      proto.String(parameter),
+			ProtoFile:      set.File,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		conf := DefaultConfig()
+		conf.GoPackageOverride = GoPackage{ImportPath: override, Name: "route"}
+		return plugin, Run(plugin, conf)
+	}
+
+	plugin, err := run("paths=source_relative", "github.com/go-sphere/protoc-gen-route/generate/route/testdata/gen/basicv1/route")
+	if err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	var names []string
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, f.GetName())
+	}
+	if want := []string{"route/basic.route.pb.go"}; !slices.Equal(names, want) {
+		t.Errorf("generated files = %v, want %v", names, want)
+	}
+
+	_, err = run("paths=source_relative", "github.com/go-sphere/protoc-gen-route/internal/route")
+	if err == nil || !strings.Contains(err.Error(), "outside the output directory") {
+		t.Errorf("Run(escaping override) = %v, want an output directory error", err)
+	}
+
+	_, err = run("module=github.com/acme/bot", "github.com/go-sphere/protoc-gen-route/internal/route")
+	if err == nil || !strings.Contains(err.Error(), "is outside module github.com/acme/bot") {
+		t.Errorf("Run(override outside module) = %v, want a module error", err)
+	}
+}
@@ -0,0 +1,68 @@
+package route
+
+import (
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// keyRegions holds the Go files of a run that generates several options keys
+// into one file per proto file: the key generated first creates the file,
+// and every key, in key order, appends its services to it in a region of its
+// own, delimited by "// region <key>" and "// endregion <key>" lines.
+type keyRegions struct {
+	files map[string]*regionFile
+}
+
+// regionFile is a file of keyRegions. It is skipped until a region renders a
+// service into it.
+type regionFile struct {
+	g        *protogen.GeneratedFile
+	rendered bool
+}
+
+func newKeyRegions() *keyRegions {
+	return &keyRegions{files: make(map[string]*regionFile)}
+}
+
+// file returns the file named filename, created with newFile by the first
+// key generated into it.
+func (r *keyRegions) file(filename string, newFile func() *protogen.GeneratedFile) *regionFile {
+	f, ok := r.files[filename]
+	if !ok {
+		f = &regionFile{g: newFile()}
+		r.files[filename] = f
+	}
+	return f
+}
+
+// mergesKeys reports whether Run generates keys into one Go file per proto
+// file: when there are several and conf.FilePattern leaves out {key}.
+func mergesKeys(conf *Config, keys []string) bool {
+	return len(keys) > 1 && conf.FilePattern != "" && !strings.Contains(conf.FilePattern, "{key}")
+}
+
+// generateRegion is generateGoFile for a run with regions: it renders
+// services into the region of conf.OptionsKey of the file named filename. It
+// returns nil when the region renders no service.
+func generateRegion(file *protogen.File, services []*protogen.Service, filename string, newFile func() *protogen.GeneratedFile, conf *Config) (*protogen.GeneratedFile, []*template.ServiceDesc, error) {
+	f := conf.regions.file(filename, newFile)
+	f.g.P("// region ", conf.OptionsKey)
+	f.g.P()
+	rendered, err := generateFileContent(file, services, f.g, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	f.g.P("// endregion ", conf.OptionsKey)
+	f.g.P()
+	if len(rendered) == 0 {
+		if !f.rendered {
+			f.g.Skip()
+		}
+		return nil, nil, nil
+	}
+	f.rendered = true
+	f.g.Unskip()
+	return f.g, rendered, nil
+}
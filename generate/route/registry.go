@@ -0,0 +1,38 @@
+package route
+
+import (
+	"strconv"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+const protoreflectPackage = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoreflect")
+
+// typeRegistryName builds the name of a service's operation type registry,
+// e.g. "MenuServiceBotOperationTypes". optionsKey is expected in PascalCase.
+func typeRegistryName(serviceType, optionsKey string) string {
+	return serviceType + optionsKey + "OperationTypes"
+}
+
+// generateTypeRegistry emits the operation type registry of sd: a map from
+// each operation path to the message types of its request and reply, so
+// middleware can decode payloads by operation without hand-maintained maps.
+// It is written in Go rather than in the templates so custom templates get it
+// too; the keys are the operation paths, which equal the operation constants
+// of the built-in templates.
+func generateTypeRegistry(g *protogen.GeneratedFile, sd *template.ServiceDesc) {
+	messageType := g.QualifiedGoIdent(protoreflectPackage.Ident("MessageType"))
+	name := typeRegistryName(sd.ServiceType, sd.OptionsKey)
+	g.P("// ", name, " maps every ", sd.OptionsKey, " operation of ", sd.ServiceType, " to the")
+	g.P("// message types of its request and reply.")
+	g.P("var ", name, " = map[string]struct{ Request, Reply ", messageType, " }{")
+	for _, md := range sd.Methods {
+		g.P(strconv.Quote(md.OperationPath), ": {")
+		g.P("Request: (*", md.Request, ")(nil).ProtoReflect().Type(),")
+		g.P("Reply: (*", md.Reply, ")(nil).ProtoReflect().Type(),")
+		g.P("},")
+	}
+	g.P("}")
+	g.P()
+}
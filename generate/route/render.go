@@ -0,0 +1,128 @@
+package route
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+)
+
+// Render is a template file and the pattern naming the files it renders, one
+// per proto file, see Config.Renders.
+type Render struct {
+	TemplateFile string
+	// Output is a file pattern like Config.FilePattern, in which %s also
+	// stands for {proto}, e.g. "%s_route.pb.go". Files not ending in .go are
+	// rendered as written, without a Go header and imports.
+	Output string
+
+	// source is the text of TemplateFile and generator its parsed template,
+	// once withRenders loaded them.
+	source    string
+	generator *template.Generator
+}
+
+// ParseRender parses a render value such as "route.tmpl:%s_route.pb.go": the
+// template file and the output pattern separated by the last ':'.
+func ParseRender(raw string) (Render, error) {
+	i := strings.LastIndex(raw, ":")
+	if i <= 0 || i == len(raw)-1 {
+		return Render{}, fmt.Errorf("invalid render %q, expected 'template:output' such as 'route.tmpl:%%s_route.pb.go'", raw)
+	}
+	return Render{TemplateFile: raw[:i], Output: raw[i+1:]}, nil
+}
+
+func (r Render) String() string {
+	return r.TemplateFile + ":" + r.Output
+}
+
+// filePattern returns Output with %s spelled {proto}.
+func (r Render) filePattern() string {
+	return strings.ReplaceAll(r.Output, "%s", "{proto}")
+}
+
+// isGo reports whether the files of r are Go files.
+func (r Render) isGo() bool {
+	return path.Ext(r.Output) == ".go"
+}
+
+// validate checks the output pattern of r like file_pattern is checked,
+// against the split mode split.
+func (r Render) validate(split string) error {
+	pattern := r.filePattern()
+	if r.TemplateFile == "" || r.Output == "" {
+		return fmt.Errorf("invalid render %q, expected 'template:output'", r)
+	}
+	if err := validateFilePattern(pattern); err != nil {
+		return fmt.Errorf("render %s: %w", r, err)
+	}
+	if hasService := strings.Contains(pattern, "{service}"); hasService != (split == SplitService) {
+		return fmt.Errorf("render %s: output must contain {service} exactly with split=%s", r, SplitService)
+	}
+	return nil
+}
+
+// withRenders returns a copy of conf whose Renders hold their parsed template
+// files.
+func withRenders(conf *Config) (*Config, error) {
+	if len(conf.Renders) == 0 {
+		return conf, nil
+	}
+	resolved := *conf
+	resolved.Renders = make([]Render, len(conf.Renders))
+	for i, r := range conf.Renders {
+		raw, err := os.ReadFile(r.TemplateFile)
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", r, err)
+		}
+		r.source = string(raw)
+		r.generator, err = template.NewSelectionGenerator(r.TemplateFile, r.source)
+		if err != nil {
+			return nil, fmt.Errorf("render %s: %w", r, err)
+		}
+		resolved.Renders[i] = r
+	}
+	return &resolved, nil
+}
+
+// renderConfigs returns the configuration of every file rendered per proto
+// file: conf itself, or one copy per render, generating with its template
+// into the files its output pattern names.
+func (c *Config) renderConfigs() []*Config {
+	if len(c.Renders) == 0 {
+		return []*Config{c}
+	}
+	confs := make([]*Config, len(c.Renders))
+	for i := range c.Renders {
+		renderConf := *c
+		renderConf.FilePattern = c.Renders[i].filePattern()
+		renderConf.render = &c.Renders[i]
+		confs[i] = &renderConf
+	}
+	return confs
+}
+
+// renderFlag collects the values of the repeatable render parameter.
+type renderFlag []Render
+
+func (f *renderFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	values := make([]string, len(*f))
+	for i, r := range *f {
+		values[i] = r.String()
+	}
+	return strings.Join(values, ",")
+}
+
+func (f *renderFlag) Set(value string) error {
+	r, err := ParseRender(value)
+	if err != nil {
+		return err
+	}
+	*f = append(*f, r)
+	return nil
+}
@@ -0,0 +1,77 @@
+package route
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestParseRender(t *testing.T) {
+	r, err := ParseRender(`C:\templates\route.tmpl:%s_route.pb.go`)
+	if err != nil {
+		t.Fatalf("ParseRender failed: %v", err)
+	}
+	if r.TemplateFile != `C:\templates\route.tmpl` || r.Output != "%s_route.pb.go" || r.filePattern() != "{proto}_route.pb.go" {
+		t.Errorf("ParseRender = %+v", r)
+	}
+	for _, raw := range []string{"route.tmpl", ":%s.go", "route.tmpl:"} {
+		if _, err := ParseRender(raw); err == nil {
+			t.Errorf("ParseRender(%q) succeeded, want an error", raw)
+		}
+	}
+}
+
+// TestRun_Renders verifies that render renders every proto file with each
+// template into the file its output names: a Go file with the usual header
+// and imports, and a text file as the template wrote it.
+func TestRun_Renders(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	code := write("route.tmpl", "{{range .MethodSets}}const {{.Operation}} = {{quote .OperationPath}}\n{{end}}")
+	docs := write("docs.tmpl", "# {{.ServiceName}}\n{{range .MethodSets}}- {{.OriginalName}}\n{{end}}")
+
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	conf := DefaultConfig()
+	conf.Renders = []Render{{TemplateFile: code, Output: "%s_route.pb.go"}, {TemplateFile: docs, Output: "%s_routes.md"}}
+	if err := conf.Validate(); err != nil {
+		t.Fatalf("Validate failed: %v", err)
+	}
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	files := make(map[string]string)
+	var names []string
+	for _, f := range plugin.Response().GetFile() {
+		names = append(names, filepath.Base(f.GetName()))
+		files[filepath.Base(f.GetName())] = f.GetContent()
+	}
+	if want := []string{"basic_route.pb.go", "basic_routes.md"}; !slices.Equal(names, want) {
+		t.Fatalf("generated files = %v, want %v", names, want)
+	}
+	for _, want := range []string{"// Code generated by protoc-gen-route. DO NOT EDIT.", "package basicv1", `OperationRouteMenuServiceGetMenu = "/testdata.basic.v1.MenuService/GetMenu"`} {
+		if !strings.Contains(files["basic_route.pb.go"], want) {
+			t.Errorf("Go render does not contain %q:\n%s", want, files["basic_route.pb.go"])
+		}
+	}
+	if got := files["basic_routes.md"]; !strings.HasPrefix(got, "# testdata.basic.v1.MenuService\n- GetMenu\n") {
+		t.Errorf("text render = %q", got)
+	}
+
+	plugin = testutil.MustCreatePlugin(t, set, "basic.proto")
+	conf.OptionsKey = "route;bot"
+	if err := Run(plugin, conf); err == nil || !strings.Contains(err.Error(), "must contain {key}") {
+		t.Errorf("Run(several keys) = %v, want a {key} error", err)
+	}
+}
@@ -2,17 +2,26 @@
 // file's service methods carrying a (sphere.options.options) rule into a
 // .<key>.pb.go file containing operation constants, extra-data lookups, and the
 // server/codec scaffolding produced from the template.
+//
+// The package doubles as a library for plugins that embed route generation:
+// bind the parameters with BindFlags (or build a Config directly) and call Run,
+// or call GenerateFile per file for finer control.
 package route
 
 import (
+	"cmp"
+	"errors"
 	"fmt"
+	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/go-sphere/options/sphere/options"
 	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
 	"google.golang.org/protobuf/compiler/protogen"
-	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
 )
 
 const (
@@ -31,60 +40,447 @@ func ReplaceTemplateIfNeed(path string) error {
 	return template.ReplaceTemplateIfNeed(path)
 }
 
+// UseBuiltinTemplate selects one of the embedded templates ("bot", "job", "mq",
+// "minimal", "data") by name; an empty name selects the default "bot" template. Like
+// ReplaceTemplateIfNeed it must be called before GenerateFile, and a template
+// file passed to ReplaceTemplateIfNeed afterwards takes precedence.
+func UseBuiltinTemplate(name string) error {
+	return template.UseBuiltinTemplate(name)
+}
+
+// UseTemplateDir parses every *.tmpl file in dir so templates can share
+// partials via {{template "name" .}}. The directory's route.tmpl, if present,
+// becomes the entry template; a template file selected afterwards replaces
+// only the entry and can still use the partials. An empty dir is a no-op.
+func UseTemplateDir(dir string) error {
+	return template.UseTemplateDir(dir)
+}
+
+// UseTemplate selects source as the template text, overriding any built-in or
+// file template selected before.
+func UseTemplate(source string) {
+	template.UseTemplate(source)
+}
+
+// UseTemplateDelims sets the action delimiters of the custom template selected
+// before, e.g. "<<" and ">>"; empty delimiters select {{ and }}.
+func UseTemplateDelims(left, right string) {
+	template.UseDelims(left, right)
+}
+
+// DeclareSupport advertises the proto features the generator handles on gen:
+// proto3 optional fields and editions from proto2 through 2024. protoc rejects
+// edition files for plugins that do not declare them.
+func DeclareSupport(gen *protogen.Plugin) {
+	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL |
+		pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+	gen.SupportedEditionsMinimum = descriptorpb.Edition_EDITION_PROTO2
+	gen.SupportedEditionsMaximum = descriptorpb.Edition_EDITION_2024
+}
+
+// Run is the whole plugin run behind protoc-gen-route: it selects the template
+// named by conf, then, for every options key in conf.OptionsKey, validates the
+// unique extras and generates every file marked for generation. It does not
+// stop at the first invalid method: every error is reported in one failure,
+// formatted as conf.ErrorFormat. Plugins that embed the generator and need
+// finer control can call the steps themselves.
+func Run(gen *protogen.Plugin, conf *Config) error {
+	err := UseBuiltinTemplate(conf.Template)
+	if err != nil {
+		return err
+	}
+	err = UseTemplateDir(conf.TemplateDir)
+	if err != nil {
+		return err
+	}
+	err = ReplaceTemplateIfNeed(conf.TemplateFile)
+	if err != nil {
+		return err
+	}
+	if conf.TemplateSource != "" {
+		UseTemplate(conf.TemplateSource)
+	}
+	if conf.TemplateDelims != [2]string{} {
+		UseTemplateDelims(conf.TemplateDelims[0], conf.TemplateDelims[1])
+	}
+	keys, err := ParseOptionsKeys(conf.OptionsKey)
+	if err != nil {
+		return err
+	}
+	conf, err = withRuleExtension(gen, conf)
+	if err != nil {
+		return err
+	}
+	if err := checkOutputPaths(gen, conf); err != nil {
+		return err
+	}
+	conf, err = withRenders(conf)
+	if err != nil {
+		return err
+	}
+	for _, r := range conf.Renders {
+		if (len(keys) > 1 || slices.ContainsFunc(keys, IsOptionsKeyPattern)) && !strings.Contains(r.filePattern(), "{key}") {
+			return fmt.Errorf("render %s: output must contain {key} when generating several options keys", r)
+		}
+	}
+	rules := conf.ruleExtension()
+	keys = expandOptionsKeys(gen, keys, rules)
+	if mergesKeys(conf, keys) {
+		// Every key appends a region to the files of the keys before it, so
+		// the keys are generated in order, one file after another.
+		slices.Sort(keys)
+		regionConf := *conf
+		regionConf.regions = newKeyRegions()
+		regionConf.Parallel, regionConf.CacheDir = 1, ""
+		conf = &regionConf
+	}
+	warnUnknownOverrides(gen, conf.ExtrasOverrides)
+	var errs []error
+	if err := checkUnmatchedKeys(gen, keys, rules, conf.StrictKeys); err != nil {
+		errs = append(errs, err)
+	}
+	cache, err := newGenerationCache(gen, conf)
+	if err != nil {
+		return err
+	}
+	workers := conf.Parallel
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	var typeScript typeScriptRoutes
+	stats := newRunStats(gen, conf)
+	for _, key := range keys {
+		keyConf := conf.ForKey(key)
+		stats.addKey(keyConf)
+		if err := CheckUniqueExtras(gen, keyConf); err != nil {
+			errs = append(errs, err)
+		}
+		var packages packageGroups
+		failed := len(errs)
+		var files []*protogen.File
+		for _, f := range gen.Files {
+			if f.Generate {
+				files = append(files, f)
+			}
+		}
+		if workers == 1 && cache == nil {
+			for _, f := range files {
+				start := time.Now()
+				_, services, gErr := generateFile(gen, f, keyConf)
+				stats.addRender(f, time.Since(start))
+				if gErr != nil {
+					errs = append(errs, gErr)
+					continue
+				}
+				packages.add(keyConf, f, services)
+				typeScript.add(f, services)
+			}
+		} else {
+			for i, result := range renderFiles(gen, files, keyConf, cache, workers) {
+				stats.addRender(files[i], result.elapsed)
+				if result.err == nil {
+					result.err = result.output.emit(gen)
+				}
+				if result.err != nil {
+					errs = append(errs, result.err)
+					continue
+				}
+				packages.add(keyConf, files[i], result.services)
+				typeScript.add(files[i], result.services)
+			}
+		}
+		if keyConf.RegisterAll && len(errs) == failed && !keyConf.checkOnly {
+			if err := generateAggregates(gen, keyConf, packages); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	if conf.TSOutFile != "" && len(errs) == 0 && !conf.checkOnly {
+		if err := generateTypeScript(gen, conf, &typeScript); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := stats.report(conf); err != nil {
+		errs = append(errs, err)
+	}
+	return formatError(conf.ErrorFormat, joinErrors(errs))
+}
+
+// joinErrors joins errs like errors.Join, dropping errors whose message was
+// already reported: the unique extras check and generation read the same
+// rules.
+func joinErrors(errs []error) error {
+	seen := make(map[string]bool)
+	var unique []error
+	for _, err := range flattenErrors(errors.Join(errs...)) {
+		if msg := err.Error(); !seen[msg] {
+			seen[msg] = true
+			unique = append(unique, err)
+		}
+	}
+	return errors.Join(unique...)
+}
+
 // GenerateFile generates the .<key>.pb.go file for a single proto file. It
-// returns (nil, nil) when the file has no service method carrying a matching
-// options rule.
+// returns (nil, nil), and emits nothing, when no service method of the file
+// produces route code for the options key.
 func GenerateFile(gen *protogen.Plugin, file *protogen.File, conf *Config) (*protogen.GeneratedFile, error) {
-	if len(file.Services) == 0 || !hasOptionsRule(file.Services, conf.OptionsKey) {
-		return nil, nil
+	conf, err := withRuleExtension(gen, conf)
+	if err != nil {
+		return nil, err
 	}
-	filename := file.GeneratedFilenamePrefix + fmt.Sprintf(".%s.pb.go", strings.ToLower(conf.OptionsKey))
-	g := gen.NewGeneratedFile(filename, file.GoImportPath)
-	generateFileHeader(gen, file, g)
-	err := generateFileContent(file, g, conf)
+	conf, err = withRenders(conf)
 	if err != nil {
 		return nil, err
 	}
-	return g, nil
+	g, _, err := generateFile(gen, file, conf)
+	return g, err
 }
 
-func generateFileHeader(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile) {
-	lines := formatFileHeader(
-		formatProtocVersion(gen.Request.GetCompilerVersion()),
-		file.Desc.Path(),
-		string(file.GoPackageName),
-		file.Proto.GetOptions().GetDeprecated(),
-	)
+// generateFile is GenerateFile that also returns the rendered services. With
+// split=service it emits a Go file per service and returns the first one; the
+// sidecars still cover the whole proto file.
+func generateFile(gen *protogen.Plugin, file *protogen.File, conf *Config) (*protogen.GeneratedFile, []*template.ServiceDesc, error) {
+	if len(file.Services) == 0 || !hasOptionsRule(file.Services, conf.OptionsKey, conf.ruleExtension()) {
+		return nil, nil, nil
+	}
+	groups := [][]*protogen.Service{file.Services}
+	if conf.Split == SplitService {
+		groups = nil
+		for _, service := range sortedServices(file.Services) {
+			if hasOptionsRule([]*protogen.Service{service}, conf.OptionsKey, conf.ruleExtension()) {
+				groups = append(groups, []*protogen.Service{service})
+			}
+		}
+	}
+	var first *protogen.GeneratedFile
+	var services []*template.ServiceDesc
+	var errs []error
+	for _, group := range groups {
+		for i, renderConf := range conf.renderConfigs() {
+			g, rendered, err := generateGoFile(gen, file, group, renderConf)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if i > 0 {
+				// The sidecars describe the services rendered by the first
+				// render; the others render the same services.
+				continue
+			}
+			if first == nil {
+				first = g
+			}
+			services = append(services, rendered...)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, nil, errors.Join(errs...)
+	}
+	if len(services) == 0 || conf.checkOnly {
+		return first, services, nil
+	}
+	if conf.Manifest != "" {
+		if err := generateManifest(gen, file, conf, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if conf.Docs != "" {
+		if err := generateDocs(gen, file, conf, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if conf.I18n != "" {
+		if err := generateI18n(gen, file, conf, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if conf.TelegramCommands {
+		if err := generateTelegramCommands(gen, file, conf, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if conf.DiscordCommands {
+		if err := generateDiscordCommands(gen, file, conf, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if conf.CallbackSchema {
+		if err := generateCallbackSchema(gen, file, conf, services); err != nil {
+			return nil, nil, err
+		}
+	}
+	if conf.SelfTest {
+		generateSelfTest(gen, file, conf, services)
+	}
+	return first, services, nil
+}
+
+// generateGoFile renders services of file into one Go file, or into one text
+// file for a render whose output is not Go. It returns nil, and emits
+// nothing, when none of them produces output.
+func generateGoFile(gen *protogen.Plugin, file *protogen.File, services []*protogen.Service, conf *Config) (*protogen.GeneratedFile, []*template.ServiceDesc, error) {
+	service := ""
+	if conf.Split == SplitService {
+		service = services[0].GoName
+	}
+	pkg, prefix := conf.goPackage(file)
+	filename := formatFilename(conf.FilePattern, prefix, string(pkg.Name), conf.OptionsKey, service)
+	newFile := func() *protogen.GeneratedFile {
+		g := gen.NewGeneratedFile(filename, pkg.ImportPath)
+		if conf.render == nil || conf.render.isGo() {
+			generateFileHeader(gen, file, g, conf)
+		}
+		return g
+	}
+	if conf.regions != nil {
+		return generateRegion(file, services, filename, newFile, conf)
+	}
+	g := newFile()
+	rendered, err := generateFileContent(file, services, g, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(rendered) == 0 {
+		// Every matching rule was dropped while rendering; do not emit a file
+		// holding only the header and import keep-alives.
+		g.Skip()
+		return nil, nil, nil
+	}
+	return g, rendered, nil
+}
+
+func generateFileHeader(gen *protogen.Plugin, file *protogen.File, g *protogen.GeneratedFile, conf *Config) {
+	pkg, _ := conf.goPackage(file)
+	header := conf.fileHeader(formatProtocVersion(gen.Request.GetCompilerVersion()), file.Desc.Path(), string(pkg.Name))
+	header.Deprecated = file.Proto.GetOptions().GetDeprecated()
+	lines := formatFileHeader(header)
 	for _, line := range lines {
 		g.P(line)
 	}
 }
 
-func generateFileContent(file *protogen.File, g *protogen.GeneratedFile, conf *Config) error {
-	if len(file.Services) == 0 {
-		return nil
+// generateFileContent renders services of file into g and returns the
+// descriptions of the services that produced output.
+func generateFileContent(file *protogen.File, services []*protogen.Service, g *protogen.GeneratedFile, conf *Config) ([]*template.ServiceDesc, error) {
+	if len(services) == 0 {
+		return nil, nil
 	}
-	generateGoImport(g, conf)
-	packageDesc := &template.PackageDesc{
-		RequestType:  g.QualifiedGoIdent(conf.RequestType),
-		ResponseType: g.QualifiedGoIdent(conf.ResponseType),
+	text := conf.render != nil && !conf.render.isGo()
+	if !text {
+		generateGoImport(g, conf)
 	}
-	if conf.ExtraType.GoName != "" {
-		packageDesc.ExtraDataType = g.QualifiedGoIdent(conf.ExtraType)
-		packageDesc.NewExtraDataFunc = g.QualifiedGoIdent(conf.ExtraConstructor)
+	generator, err := fileGenerator(g, conf)
+	if err != nil {
+		return nil, err
 	}
 	genConf := &genConfig{
 		optionsKey:  conf.OptionsKey,
-		packageDesc: packageDesc,
-		methodSets:  make(map[string]int),
+		rules:       conf.ruleExtension(),
+		packageDesc: buildPackageDesc(g, conf),
+		generator:   generator,
+		methodNums:  methodNums(file.Services),
+		text:        text,
+
+		requiredExtras:    conf.requiredExtras(),
+		unsupportedExtras: conf.unsupportedExtras(),
+		descriptors:       conf.Descriptors,
+		typeRegistry:      conf.TypeRegistry,
+		invoker:           conf.Invoker,
+		routeInfo:         conf.RouteInfo,
+		mocks:             conf.Mocks,
+		streaming:         conf.Streaming,
+		extrasSchema:      conf.ExtrasSchema[conf.OptionsKey],
+
+		validateRequests: conf.ValidateRequests,
+		extrasOverrides:  conf.ExtrasOverrides,
+		vars:             conf.Vars,
+		tags:             conf.tagFilter(),
+		warnings:         conf.warningsWriter(),
+		checkOnly:        conf.checkOnly,
 	}
-	for _, service := range file.Services {
-		err := generateService(g, service, genConf)
+	pkg, _ := conf.goPackage(file)
+	fileDesc := &template.FileDesc{
+		OptionsKey:    template.PascalCase(conf.OptionsKey),
+		Path:          file.Desc.Path(),
+		ProtoPackage:  string(file.Desc.Package()),
+		GoPackageName: string(pkg.Name),
+		GoImportPath:  string(pkg.ImportPath),
+		Package:       genConf.packageDesc,
+	}
+	var errs []error
+	sorted := sortedServices(services)
+	descs := make([]*template.ServiceDesc, len(sorted))
+	for i, service := range sorted {
+		sd, err := buildService(g, service, genConf)
 		if err != nil {
-			return err
+			errs = append(errs, err)
+			continue
 		}
+		sd.File = fileDesc
+		if len(sd.Methods) != 0 {
+			fileDesc.Services = append(fileDesc.Services, sd)
+		}
+		descs[i] = sd
 	}
-	return nil
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	if genConf.checkOnly {
+		return fileDesc.Services, nil
+	}
+	// Every service is built before the first one renders, so the template
+	// sees all of them through File.
+	for i, service := range sorted {
+		if err := generateService(g, service, descs[i], genConf); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return genConf.services, nil
+}
+
+// buildPackageDesc qualifies the configured models against g.
+func buildPackageDesc(g *protogen.GeneratedFile, conf *Config) *template.PackageDesc {
+	packageDesc := &template.PackageDesc{Dispatch: conf.Dispatch, Tracing: conf.Tracing, Metrics: conf.Metrics, GenericHandlers: conf.Generics}
+	if guard := conf.versionGuard(); guard.GoName != "" {
+		packageDesc.VersionGuard = g.QualifiedGoIdent(guard)
+	}
+	if conf.RequestType.GoName != "" {
+		packageDesc.RequestType = g.QualifiedGoIdent(conf.RequestType)
+	}
+	if conf.ResponseType.GoName != "" {
+		packageDesc.ResponseType = g.QualifiedGoIdent(conf.ResponseType)
+	}
+	if conf.GenericCodec {
+		packageDesc.Generic = true
+		packageDesc.DefaultRequestType, packageDesc.RequestType = packageDesc.RequestType, "TReq"
+		packageDesc.DefaultResponseType, packageDesc.ResponseType = packageDesc.ResponseType, "TResp"
+	}
+	if conf.ExtraType.GoName != "" {
+		packageDesc.ExtraDataType = g.QualifiedGoIdent(conf.ExtraType)
+		packageDesc.NewExtraDataFunc = g.QualifiedGoIdent(conf.ExtraConstructor)
+	}
+	return packageDesc
+}
+
+// fileGenerator returns the Generator of the render of conf, or else of the
+// selected template, with qualify bound to g.
+func fileGenerator(g *protogen.GeneratedFile, conf *Config) (*template.Generator, error) {
+	if conf.render != nil {
+		return conf.render.generator.WithQualifier(func(importPath, name string) string {
+			return g.QualifiedGoIdent(protogen.GoImportPath(importPath).Ident(name))
+		})
+	}
+	generator, err := template.DefaultGenerator()
+	if err != nil {
+		return nil, err
+	}
+	return generator.WithQualifier(func(importPath, name string) string {
+		return g.QualifiedGoIdent(protogen.GoImportPath(importPath).Ident(name))
+	})
 }
 
 func generateGoImport(g *protogen.GeneratedFile, conf *Config) {
@@ -97,73 +493,310 @@ func generateGoImport(g *protogen.GeneratedFile, conf *Config) {
 		g.P("var _ = ", ident)
 	}
 	g.P()
+	if guard := conf.versionGuard(); guard.GoName != "" {
+		g.P("// This is a compile-time assertion that the runtime package is")
+		g.P("// compatible with this generated file.")
+		g.P("const _ = ", guard)
+		g.P()
+	}
 }
 
-func generateService(g *protogen.GeneratedFile, service *protogen.Service, genConf *genConfig) error {
-	if service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() {
-		g.P("//")
-		g.P(deprecationComment)
-	}
+// buildService describes the routed methods of service, failing with every
+// invalid method.
+func buildService(g *protogen.GeneratedFile, service *protogen.Service, genConf *genConfig) (*template.ServiceDesc, error) {
 	sd := &template.ServiceDesc{
-		OptionsKey:  pascalCase(genConf.optionsKey),
-		ServiceType: service.GoName,
-		ServiceName: string(service.Desc.FullName()),
-		Package:     genConf.packageDesc,
+		OptionsKey:    template.PascalCase(genConf.optionsKey),
+		RawOptionsKey: genConf.optionsKey,
+		ServiceType:   service.GoName,
+		ServiceName:   string(service.Desc.FullName()),
+		Package:       genConf.packageDesc,
+	}
+	if genConf.descriptors {
+		sd.Descriptor = service
+	}
+	sd.ServerName = serverName(sd.ServiceType, sd.OptionsKey)
+	sd.UnimplementedServerName = "Unimplemented" + sd.ServerName
+	serviceRule, err := extractServiceRule(service, genConf.optionsKey, genConf.rules)
+	if err != nil {
+		return nil, err
+	}
+	sd.Extra, err = interpolateExtras(service.Desc, serviceRule.GetExtra(), genConf.vars)
+	if err != nil {
+		return nil, err
 	}
 
-	for _, method := range service.Methods {
-		rule := extractOptionsRule(method, genConf.optionsKey)
+	var errs []error
+	commands := make(map[string]*protogen.Method)
+	names := make(map[string]*protogen.Method)
+	// targets holds the generated methods by proto name, which the buttons of
+	// keyboards resolve once every method is built.
+	targets := make(map[string]*template.MethodDesc)
+	type keyboard struct {
+		method *protogen.Method
+		md     *template.MethodDesc
+		rows   [][]keyboardButton
+	}
+	var keyboards []keyboard
+	for _, method := range sortedMethods(service.Methods) {
+		rule, err := extractOptionsRule(method, genConf.optionsKey, genConf.rules)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
 		if rule == nil {
 			continue
 		}
-		sd.Methods = append(sd.Methods, &template.MethodDesc{
+		extra, err := interpolateExtras(method.Desc, genConf.extrasOverrides.apply(method, genConf.optionsKey, mergeExtras(serviceRule.GetExtra(), rule.GetExtra())), genConf.vars)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		skip, err := isSkipped(method, extra)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if skip || genConf.tags.excludes(extra) {
+			continue
+		}
+		if isStreaming(method) {
+			switch genConf.streaming {
+			case StreamingError:
+				errs = append(errs, descriptorErrorf(method.Desc, "streaming methods cannot be routed under key %q; use streaming=%s with a custom template", genConf.optionsKey, StreamingInclude))
+				continue
+			case StreamingInclude:
+			default:
+				warnf(genConf.warnings, method.Desc, "skipping streaming method routed under key %q", genConf.optionsKey)
+				continue
+			}
+		}
+		callbackData, cbErr := callbackDataExpr(g, method, extra[ExtraCallbackData])
+		callbackFields := splitExtraList(extra[ExtraCallbackFields])
+		callbackEncoder, callbackDecoder, ccErr := callbackCodec(g, method, callbackFields)
+		args := splitExtraList(extra[ExtraArgs])
+		argsBinder, aErr := argsBinder(g, method, args)
+		oneof, oErr := dispatchOneof(g, method, extra[ExtraDispatchOneof])
+		buttons, bErr := parseButtons(method, extra[ExtraButtons])
+		validate, vErr := validatesRequest(method, extra, genConf.validateRequests)
+		timeout, tErr := routeTimeout(method, extra)
+		retries, rErr := routeRetries(method, extra)
+		rateLimit, rlErr := routeRateLimit(method, extra)
+		priority, pErr := routePriority(method, extra)
+		var schemaErr error
+		if genConf.extrasSchema != nil {
+			schemaErr = checkExtrasSchema(method, genConf.extrasSchema, extra)
+		}
+		err = errors.Join(
+			validateMethodExtras(method, extra),
+			schemaErr,
+			checkRequiredExtras(method, extra, genConf.requiredExtras),
+			checkUnsupportedExtras(method, extra, genConf.unsupportedExtras),
+			cbErr,
+			ccErr,
+			aErr,
+			oErr,
+			bErr,
+			vErr,
+			tErr,
+			rErr,
+			rlErr,
+			pErr,
+		)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		name := string(method.Desc.Name())
+		if override := extra[ExtraName]; override != "" {
+			name = override
+		}
+		num := genConf.methodNums[method]
+		md := &template.MethodDesc{
 			Name:         method.GoName,
-			OriginalName: string(method.Desc.Name()),
-			Num:          genConf.methodSets[method.GoName],
-			Request:      g.QualifiedGoIdent(method.Input.GoIdent),
-			Reply:        g.QualifiedGoIdent(method.Output.GoIdent),
-			Comment:      formatMethodComment(string(method.Desc.Name()), string(method.Comments.Leading)),
-			Extra:        rule.Extra,
-		})
-		genConf.methodSets[method.GoName]++
+			OriginalName: name,
+			ProtoName:    string(method.Desc.Name()),
+			Num:          num.num,
+			UniqueName:   num.uniqueName,
+
+			Operation:     operationName(sd.OptionsKey, sd.ServiceType, name),
+			OperationPath: operationPath(sd.ServiceName, string(method.Desc.Name())),
+
+			Request:       g.QualifiedGoIdent(method.Input.GoIdent),
+			Reply:         g.QualifiedGoIdent(method.Output.GoIdent),
+			RequestFields: buildFieldDescs(g, method.Input),
+
+			RequestImportPath: string(method.Input.GoIdent.GoImportPath),
+			ReplyImportPath:   string(method.Output.GoIdent.GoImportPath),
+
+			RequestMessage: string(method.Input.Desc.FullName()),
+			ReplyMessage:   string(method.Output.Desc.FullName()),
+
+			Comment:        formatMethodComment(string(method.Desc.Name()), string(method.Comments.Leading)),
+			LeadingComment: plainComment(string(method.Comments.Leading)),
+
+			TrailingComment:  plainComment(string(method.Comments.Trailing)),
+			DetachedComments: plainComments(method.Comments.LeadingDetached),
+
+			Extra:      extra,
+			ExtraList:  template.SortedExtraList(extra),
+			ExtraTyped: template.TypedExtras(extra),
+
+			CallbackQueryPattern: extra[ExtraCallbackQueryPattern],
+			CallbackData:         extra[ExtraCallbackData],
+			CallbackDataExpr:     callbackData,
+			CallbackFields:       callbackFields,
+			CallbackEncoder:      callbackEncoder,
+			CallbackDecoder:      callbackDecoder,
+			Args:                 args,
+			ArgsBinder:           argsBinder,
+			DispatchOneof:        oneof,
+			Cron:                 extra[ExtraCron],
+			Validate:             validate,
+			Timeout:              timeout,
+			MaxRetries:           retries,
+			RateLimit:            rateLimit,
+			Group:                extra[ExtraGroup],
+			ChatType:             extra[ExtraChatType],
+			ErrorDomain:          extra[ExtraErrorDomain],
+			Permission:           extra[ExtraPermission],
+			FeatureFlag:          extra[ExtraFeatureFlag],
+			Priority:             priority,
+			ClientStreaming:      method.Desc.IsStreamingClient(),
+			ServerStreaming:      method.Desc.IsStreamingServer(),
+		}
+		md.Summary, md.Description = splitComment(string(method.Comments.Leading))
+		md.Commands = splitExtraList(extra[ExtraCommand])
+		md.Middleware = splitExtraList(extra[ExtraMiddleware])
+		md.Tags = splitExtraList(extra[ExtraTags])
+		md.Roles = splitExtraList(extra[ExtraRoles])
+		md.Deprecated, md.DeprecationNotice = methodDeprecation(method, extra)
+		if err := checkCommands(method, md.Commands, md.ChatType, commands); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := checkMethodName(method, name, names); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if genConf.descriptors {
+			md.Descriptor = method
+		}
+		sd.Methods = append(sd.Methods, md)
+		targets[string(method.Desc.Name())] = md
+		if buttons != nil {
+			keyboards = append(keyboards, keyboard{method: method, md: md, rows: buttons})
+		}
+	}
+	for _, k := range keyboards {
+		rows, err := buildKeyboard(k.method, k.rows, targets)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		k.md.Keyboard = rows
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+	return sd, nil
+}
+
+// generateService renders sd, the description of service, into g unless it
+// has no methods.
+func generateService(g *protogen.GeneratedFile, service *protogen.Service, sd *template.ServiceDesc, genConf *genConfig) error {
+	if service.Desc.Options().(*descriptorpb.ServiceOptions).GetDeprecated() && !genConf.text {
+		g.P("//")
+		g.P(deprecationComment)
 	}
 	if len(sd.Methods) != 0 {
-		content, err := sd.Execute()
+		content, err := genConf.generator.Execute(sd)
+		if err != nil {
+			return err
+		}
+		if genConf.text {
+			g.P(content)
+			genConf.services = append(genConf.services, sd)
+			return nil
+		}
+		content, err = formatRendered(genConf.generator.Name(), sd.ServiceName, content)
 		if err != nil {
 			return err
 		}
 		g.P(content)
 		g.P("\n\n")
+		if genConf.typeRegistry {
+			generateTypeRegistry(g, sd)
+		}
+		if genConf.routeInfo {
+			generateRouteInfo(g, sd)
+		}
+		if genConf.invoker {
+			generateInvoker(g, sd)
+		}
+		if genConf.mocks {
+			generateMock(g, sd)
+		}
+		genConf.services = append(genConf.services, sd)
 	}
 	return nil
 }
 
-func hasOptionsRule(services []*protogen.Service, key string) bool {
-	for _, service := range services {
-		for _, method := range service.Methods {
-			if extractOptionsRule(method, key) != nil {
-				return true
-			}
-		}
-	}
-	return false
+// sortedServices returns services ordered by full name, so the generated file
+// does not depend on the order services are declared or decoded in.
+func sortedServices(services []*protogen.Service) []*protogen.Service {
+	sorted := slices.Clone(services)
+	slices.SortStableFunc(sorted, func(a, b *protogen.Service) int {
+		return strings.Compare(string(a.Desc.FullName()), string(b.Desc.FullName()))
+	})
+	return sorted
 }
 
-func extractOptionsRule(method *protogen.Method, key string) *options.KeyValuePair {
-	if method.Desc.IsStreamingClient() || method.Desc.IsStreamingServer() {
-		return nil
-	}
-	if !proto.HasExtension(method.Desc.Options(), options.E_Options) {
-		return nil
+// methodNum is the MethodDesc.Num and MethodDesc.UniqueName of a method.
+type methodNum struct {
+	num        int
+	uniqueName string
+}
+
+// methodNums numbers the methods of services sharing a Go name in sorted order:
+// by service full name, then by Go name and proto name within a service, the
+// first gets 0, the next 1, and so on. Every method counts, whether or not it
+// is routed, so reordering the services or methods of the proto file, adding
+// a rule, skipping a method or splitting the output per service leaves the
+// numbers, and the identifiers built from them, unchanged. The unique name is
+// the Go name, followed by the number only when the Go name is shared, and
+// then by "_" as long as it would clash with the Go name of another method.
+func methodNums(services []*protogen.Service) map[*protogen.Method]methodNum {
+	var methods []*protogen.Method
+	for _, service := range sortedServices(services) {
+		methods = append(methods, sortedMethods(service.Methods)...)
 	}
-	rules, ok := proto.GetExtension(method.Desc.Options(), options.E_Options).([]*options.KeyValuePair)
-	if rules == nil || !ok {
-		return nil
+	goNames := make(map[string]int)
+	for _, method := range methods {
+		goNames[method.GoName]++
 	}
-	for _, rule := range rules {
-		if rule.GetKey() == key {
-			return rule
+	nums := make(map[*protogen.Method]methodNum, len(methods))
+	seen := make(map[string]int)
+	for _, method := range methods {
+		num := seen[method.GoName]
+		seen[method.GoName]++
+		uniqueName := method.GoName
+		if goNames[method.GoName] > 1 {
+			uniqueName += strconv.Itoa(num)
+			for goNames[uniqueName] > 0 {
+				uniqueName += "_"
+			}
 		}
+		nums[method] = methodNum{num: num, uniqueName: uniqueName}
 	}
-	return nil
+	return nums
+}
+
+// sortedMethods returns methods ordered by Go name, the key of
+// ServiceDesc.MethodSets, and then by proto name.
+func sortedMethods(methods []*protogen.Method) []*protogen.Method {
+	sorted := slices.Clone(methods)
+	slices.SortStableFunc(sorted, func(a, b *protogen.Method) int {
+		return cmp.Or(strings.Compare(a.GoName, b.GoName), strings.Compare(string(a.Desc.Name()), string(b.Desc.Name())))
+	})
+	return sorted
 }
@@ -0,0 +1,113 @@
+package route
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// routeInfoTypeName builds the name of a service's route metadata struct,
+// e.g. "MenuServiceBotRouteInfo". optionsKey is expected in PascalCase.
+func routeInfoTypeName(serviceType, optionsKey string) string {
+	return serviceType + optionsKey + "RouteInfo"
+}
+
+// generateRouteInfo emits the route metadata of sd: a struct describing one
+// operation, a table of them keyed by operation path, and a Get<Type> lookup,
+// so middleware and logging can read a route's command, comment and extras at
+// run time. Every entry carries the logging fields of ServiceDesc.LogFields. Like the type registry it is written in Go so every template gets
+// it.
+func generateRouteInfo(g *protogen.GeneratedFile, sd *template.ServiceDesc) {
+	typeName := routeInfoTypeName(sd.ServiceType, sd.OptionsKey)
+	tableName := "_" + sd.ServiceType + "_" + sd.OptionsKey + "_RouteInfos"
+	duration := g.QualifiedGoIdent(protogen.GoIdent{GoName: "Duration", GoImportPath: "time"})
+
+	g.P("// ", typeName, " describes a ", sd.OptionsKey, " operation of ", sd.ServiceType, ".")
+	g.P("type ", typeName, " struct {")
+	g.P("Operation     string")
+	g.P("Command       string")
+	g.P("CallbackQuery string")
+	g.P("Comment       string")
+	g.P("Timeout       ", duration)
+	g.P("MaxRetries    int")
+	g.P("Extra         map[string]string")
+	g.P()
+	g.P("logFields []any")
+	g.P("}")
+	g.P()
+	g.P("var ", tableName, " = map[string]", typeName, "{")
+	for _, md := range sd.Methods {
+		g.P(strconv.Quote(md.OperationPath), ": {")
+		g.P("Operation: ", strconv.Quote(md.OperationPath), ",")
+		if command := md.ExtraValue(ExtraCommand); command != "" {
+			g.P("Command: ", strconv.Quote(command), ",")
+		}
+		if query := md.ExtraValue("callback_query"); query != "" {
+			g.P("CallbackQuery: ", strconv.Quote(query), ",")
+		}
+		if md.LeadingComment != "" {
+			g.P("Comment: ", strconv.Quote(md.LeadingComment), ",")
+		}
+		if md.Timeout > 0 {
+			g.P("Timeout: ", durationExpr(g, md.Timeout), ",")
+		}
+		if md.MaxRetries > 0 {
+			g.P("MaxRetries: ", md.MaxRetries, ",")
+		}
+		if len(md.ExtraList) > 0 {
+			g.P("Extra: map[string]string{")
+			for _, kv := range md.ExtraList {
+				g.P(strconv.Quote(kv.Key), ": ", strconv.Quote(kv.Value), ",")
+			}
+			g.P("},")
+		}
+		g.P("logFields: []any{")
+		for _, field := range sd.LogFields(md) {
+			g.P(strconv.Quote(field.Key), ", ", strconv.Quote(field.Value), ",")
+		}
+		g.P("},")
+		g.P("},")
+	}
+	g.P("}")
+	g.P()
+	g.P("// LogFields returns the structured logging fields of the operation as")
+	g.P("// alternating keys and values: service, method, operation and, when it has")
+	g.P("// one, command. It suits slog.Logger.With and zap.SugaredLogger.With, so")
+	g.P("// every handler logs the route under the same keys. The slice is shared and")
+	g.P("// must not be modified.")
+	g.P("func (i ", typeName, ") LogFields() []any {")
+	g.P("return i.logFields")
+	g.P("}")
+	g.P()
+	g.P("// Get", typeName, " returns the metadata of operation, and false when it is")
+	g.P("// not an operation of ", sd.ServiceType, ". Its Extra map is shared and must not be modified.")
+	g.P("func Get", typeName, "(operation string) (", typeName, ", bool) {")
+	g.P("info, ok := ", tableName, "[operation]")
+	g.P("return info, ok")
+	g.P("}")
+	g.P()
+}
+
+// durationExpr returns the Go expression of d in the largest unit dividing it,
+// e.g. "30 * time.Second" rather than a count of nanoseconds.
+func durationExpr(g *protogen.GeneratedFile, d time.Duration) string {
+	units := []struct {
+		name string
+		size time.Duration
+	}{
+		{"Hour", time.Hour},
+		{"Minute", time.Minute},
+		{"Second", time.Second},
+		{"Millisecond", time.Millisecond},
+		{"Microsecond", time.Microsecond},
+	}
+	for _, unit := range units {
+		if d%unit.size == 0 {
+			return fmt.Sprintf("%d * %s", d/unit.size, g.QualifiedGoIdent(protogen.GoIdent{GoName: unit.name, GoImportPath: "time"}))
+		}
+	}
+	return fmt.Sprintf("%s(%d)", g.QualifiedGoIdent(protogen.GoIdent{GoName: "Duration", GoImportPath: "time"}), int64(d))
+}
@@ -0,0 +1,173 @@
+// Package routetest renders protoc-gen-route templates against hand-built
+// fixtures, so teams maintaining custom templates can unit-test them without
+// running protoc:
+//
+//	svc := routetest.NewService("bot", "bot.v1.MenuService",
+//		routetest.NewMethod("UpdateCount", map[string]string{"command": "start"}),
+//	)
+//	got, err := routetest.RenderFile("route.tmpl", svc)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	routetest.CompareGolden(t, "testdata/menu.golden", got, *update)
+package routetest
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+)
+
+// The template data model, re-exported for building fixtures.
+type (
+	ServiceDesc = template.ServiceDesc
+	MethodDesc  = template.MethodDesc
+	PackageDesc = template.PackageDesc
+	FieldDesc   = template.FieldDesc
+	ExtraKV     = template.ExtraKV
+)
+
+// NewPackage returns a PackageDesc with the given request and response model
+// types, e.g. "telegram.Update" and "telegram.Message", and no extra-data type.
+func NewPackage(requestType, responseType string) *PackageDesc {
+	return &PackageDesc{RequestType: requestType, ResponseType: responseType}
+}
+
+// NewMethod returns a MethodDesc named name with request and reply types
+// <name>Request and <name>Response and the given extras, without a comment.
+// The operation names are filled in by NewService.
+func NewMethod(name string, extra map[string]string) *MethodDesc {
+	return &MethodDesc{
+		Name:         name,
+		OriginalName: name,
+		ProtoName:    name,
+		Request:      name + "Request",
+		Reply:        name + "Response",
+		Extra:        extra,
+		ExtraList:    template.SortedExtraList(extra),
+		ExtraTyped:   template.TypedExtras(extra),
+
+		CallbackQueryPattern: extra["callback_query_pattern"],
+		CallbackData:         extra["callback_data"],
+		Cron:                 extra["cron"],
+	}
+}
+
+// NewService returns the ServiceDesc the generator would build for the
+// service fullName, e.g. "bot.v1.MenuService", routed under optionsKey. It
+// completes the methods' Num, UniqueName, Operation and OperationPath and uses
+// NewPackage("Request", "Response") as the package models.
+func NewService(optionsKey, fullName string, methods ...*MethodDesc) *ServiceDesc {
+	key := template.PascalCase(optionsKey)
+	serviceType := fullName[strings.LastIndex(fullName, ".")+1:]
+	s := &ServiceDesc{
+		OptionsKey:    key,
+		RawOptionsKey: optionsKey,
+		ServiceType:   serviceType,
+		ServiceName:   fullName,
+		ServerName:    serviceType + key + "Server",
+		Methods:       methods,
+		Package:       NewPackage("Request", "Response"),
+	}
+	s.UnimplementedServerName = "Unimplemented" + s.ServerName
+	names := make(map[string]int)
+	for _, m := range methods {
+		names[m.Name]++
+	}
+	seen := make(map[string]int)
+	for _, m := range methods {
+		m.Num = seen[m.Name]
+		seen[m.Name]++
+		m.UniqueName = m.Name
+		if names[m.Name] > 1 {
+			m.UniqueName += strconv.Itoa(m.Num)
+		}
+		m.Operation = "Operation" + key + serviceType + m.Name
+		m.OperationPath = "/" + fullName + "/" + m.ProtoName
+	}
+	return s
+}
+
+// Render executes source against s with the built-in functions and returns
+// the gofmt-formatted output. {{qualify "path" "Name"}} renders as
+// <base of path>.Name since there is no generated file to import into.
+func Render(source string, s *ServiceDesc) ([]byte, error) {
+	gen, err := template.NewGenerator(source)
+	if err != nil {
+		return nil, err
+	}
+	gen, err = gen.WithQualifier(func(importPath, name string) string {
+		return path.Base(importPath) + "." + name
+	})
+	if err != nil {
+		return nil, err
+	}
+	out, err := gen.Execute(s)
+	if err != nil {
+		return nil, err
+	}
+	formatted, err := format.Source([]byte(strings.TrimLeft(out, "\n")))
+	if err != nil {
+		return nil, fmt.Errorf("rendered output is not valid Go: %v\n%s", err, out)
+	}
+	return formatted, nil
+}
+
+// RenderFile is Render with the template read from file.
+func RenderFile(file string, s *ServiceDesc) ([]byte, error) {
+	raw, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	return Render(string(raw), s)
+}
+
+// RenderBuiltin is Render with one of the embedded templates ("bot", "job",
+// "mq", "minimal").
+func RenderBuiltin(name string, s *ServiceDesc) ([]byte, error) {
+	source, err := template.BuiltinTemplate(name)
+	if err != nil {
+		return nil, err
+	}
+	return Render(source, s)
+}
+
+// CompareGolden fails t when got differs from the content of goldenFile,
+// reporting the first differing line. With update set it rewrites goldenFile
+// instead, creating its directory if needed.
+func CompareGolden(t testing.TB, goldenFile string, got []byte, update bool) {
+	t.Helper()
+	if update {
+		if err := os.MkdirAll(filepath.Dir(goldenFile), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(goldenFile, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return
+	}
+	want, err := os.ReadFile(goldenFile)
+	if err != nil {
+		t.Fatalf("read golden file: %v", err)
+	}
+	if bytes.Equal(want, got) {
+		return
+	}
+	wantLines := strings.Split(string(want), "\n")
+	gotLines := strings.Split(string(got), "\n")
+	for i := 0; i < len(wantLines) && i < len(gotLines); i++ {
+		if wantLines[i] != gotLines[i] {
+			t.Errorf("%s differs at line %d:\nwant: %s\ngot:  %s", goldenFile, i+1, wantLines[i], gotLines[i])
+			return
+		}
+	}
+	t.Errorf("%s differs: want %d lines, got %d lines", goldenFile, len(wantLines), len(gotLines))
+}
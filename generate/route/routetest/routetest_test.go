@@ -0,0 +1,53 @@
+package routetest
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+var update = flag.Bool("update-golden", false, "update golden files")
+
+func TestNewService(t *testing.T) {
+	s := NewService("callback_query", "bot.v1.MenuService",
+		NewMethod("Create", nil),
+		NewMethod("Create", map[string]string{"command": "start"}),
+	)
+	if s.ServerName != "MenuServiceCallbackQueryServer" || s.UnimplementedServerName != "UnimplementedMenuServiceCallbackQueryServer" {
+		t.Errorf("server names = %q, %q", s.ServerName, s.UnimplementedServerName)
+	}
+	if m := s.Methods[1]; m.Num != 1 || m.Operation != "OperationCallbackQueryMenuServiceCreate" || m.OperationPath != "/bot.v1.MenuService/Create" {
+		t.Errorf("second method = %+v", m)
+	}
+	if got := s.Methods[1].ExtraList; len(got) != 1 || got[0] != (ExtraKV{Key: "command", Value: "start"}) {
+		t.Errorf("ExtraList = %v", got)
+	}
+}
+
+func TestRender(t *testing.T) {
+	s := NewService("bot", "bot.v1.MenuService", NewMethod("Start", nil))
+	got, err := Render(`var _ = {{qualify "errors" "New"}}({{quote .ServerName}})`, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "var _ = errors.New(\"MenuServiceBotServer\")"; string(got) != want {
+		t.Errorf("Render() = %q, want %q", got, want)
+	}
+
+	_, err = Render(`func {`, s)
+	if err == nil || !strings.Contains(err.Error(), "not valid Go") {
+		t.Errorf("Render() of invalid Go = %v, want a format error", err)
+	}
+}
+
+func TestGoldenRenderBuiltin(t *testing.T) {
+	s := NewService("bot", "bot.v1.MenuService",
+		NewMethod("UpdateCount", map[string]string{"command": "start"}),
+		NewMethod("GetMenu", nil),
+	)
+	got, err := RenderBuiltin("minimal", s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	CompareGolden(t, "testdata/minimal.golden", got, *update)
+}
@@ -0,0 +1,103 @@
+package route
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"sigs.k8s.io/yaml"
+)
+
+// ExtrasSchema declares, per options key, the extras methods routed under that
+// key may carry. Methods of a key listed in the schema are rejected when they
+// carry an extra the schema does not list, lack a required extra, or carry a
+// value not matching its pattern, so a typo such as "comand" fails generation
+// instead of producing a dead route. Keys not listed are not checked.
+type ExtrasSchema map[string]map[string]*ExtraRule
+
+// ExtraRule constrains one extra of an ExtrasSchema.
+type ExtraRule struct {
+	Required bool   `json:"required"`
+	Pattern  string `json:"pattern"`
+
+	pattern *regexp.Regexp
+}
+
+// ReadExtrasSchema reads an ExtrasSchema from a YAML or JSON file: a mapping
+// from options key to extra key to rule, e.g.
+//
+//	bot:
+//	  command:
+//	    required: true
+//	    pattern: "^[a-z0-9_]+(,[a-z0-9_]+)*$"
+//	  callback_query: {}
+func ReadExtrasSchema(path string) (ExtrasSchema, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading extras_schema: %w", err)
+	}
+	schema, err := ParseExtrasSchema(raw)
+	if err != nil {
+		return nil, fmt.Errorf("extras_schema %s: %w", path, err)
+	}
+	return schema, nil
+}
+
+// ParseExtrasSchema parses the content of an extras schema file, see
+// ReadExtrasSchema.
+func ParseExtrasSchema(raw []byte) (ExtrasSchema, error) {
+	raw, err := yaml.YAMLToJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+	var schema ExtrasSchema
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&schema); err != nil {
+		return nil, err
+	}
+	for key, rules := range schema {
+		for extra, rule := range rules {
+			if rule == nil {
+				rule = &ExtraRule{}
+				rules[extra] = rule
+			}
+			if rule.Pattern == "" {
+				continue
+			}
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("%s.%s: invalid pattern %q: %v", key, extra, rule.Pattern, err)
+			}
+			rule.pattern = pattern
+		}
+	}
+	return schema, nil
+}
+
+// checkExtrasSchema reports every extra of method that breaks rules, the
+// schema of its options key.
+func checkExtrasSchema(method *protogen.Method, rules map[string]*ExtraRule, extra map[string]string) error {
+	var errs []error
+	for _, key := range sortedKeys(extra) {
+		rule, ok := rules[key]
+		if !ok {
+			errs = append(errs, descriptorErrorf(method.Desc, "extra %q is not declared by the extras schema, declared: %s", key, strings.Join(sortedKeys(rules), ", ")))
+			continue
+		}
+		if rule.pattern != nil && !rule.pattern.MatchString(extra[key]) {
+			errs = append(errs, descriptorErrorf(method.Desc, "extra %q value %q does not match the extras schema pattern %q", key, extra[key], rule.Pattern))
+		}
+	}
+	for _, key := range sortedKeys(rules) {
+		if _, ok := extra[key]; rules[key].Required && !ok {
+			errs = append(errs, descriptorErrorf(method.Desc, "extra %q is required by the extras schema", key))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,106 @@
+package route
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestParseExtrasSchema(t *testing.T) {
+	raw := `{"bot": {"command": {"required": true, "pattern": "^[a-z_]+$"}, "callback_query": {}, "middleware": null},
+		"job": {"cron": {"pattern": "^@(daily|hourly)$"}}}`
+	want := map[string]map[string]ExtraRule{
+		"bot": {
+			"command":        {Required: true, Pattern: "^[a-z_]+$"},
+			"callback_query": {},
+			"middleware":     {},
+		},
+		"job": {"cron": {Pattern: "^@(daily|hourly)$"}},
+	}
+	schema, err := ParseExtrasSchema([]byte(raw))
+	if err != nil {
+		t.Fatalf("ParseExtrasSchema failed: %v", err)
+	}
+	got := make(map[string]map[string]ExtraRule)
+	for key, rules := range schema {
+		got[key] = make(map[string]ExtraRule)
+		for extra, rule := range rules {
+			got[key][extra] = ExtraRule{Required: rule.Required, Pattern: rule.Pattern}
+		}
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseExtrasSchema = %v, want %v", got, want)
+	}
+}
+
+func TestParseExtrasSchema_YAML(t *testing.T) {
+	raw := "bot:\n  command:\n    required: true\n    pattern: \"^[a-z_]+$\"\n  callback_query: {}\n  middleware:\n"
+	schema, err := ParseExtrasSchema([]byte(raw))
+	if err != nil {
+		t.Fatalf("ParseExtrasSchema failed: %v", err)
+	}
+	if rule := schema["bot"]["command"]; !rule.Required || rule.Pattern != "^[a-z_]+$" || !rule.pattern.MatchString("start") {
+		t.Errorf("ParseExtrasSchema bot.command = %+v", rule)
+	}
+	for _, extra := range []string{"callback_query", "middleware"} {
+		if rule := schema["bot"][extra]; rule == nil || rule.Required || rule.Pattern != "" {
+			t.Errorf("ParseExtrasSchema bot.%s = %+v, want an empty rule", extra, rule)
+		}
+	}
+}
+
+func TestParseExtrasSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"unknown field", `{"bot": {"command": {"requried": true}}}`, `unknown field "requried"`},
+		{"invalid pattern", `{"bot": {"command": {"pattern": "("}}}`, `bot.command: invalid pattern "("`},
+		{"not a bool", `{"bot": {"command": {"required": "yes"}}}`, "cannot unmarshal string"},
+		{"yaml unknown field", "bot:\n  command:\n    requried: true\n", `unknown field "requried"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseExtrasSchema([]byte(tt.raw))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ParseExtrasSchema() = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestRun_ExtrasSchema verifies that every method is checked against the
+// schema of its options key and that each violation carries its position.
+func TestRun_ExtrasSchema(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	schema, err := ParseExtrasSchema([]byte(`{"route": {"command": {"required": true, "pattern": "^[a-z]+$"}}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+	conf := DefaultConfig()
+	conf.ExtrasSchema = schema
+	err = Run(plugin, conf)
+	if err == nil {
+		t.Fatal("Run() = nil, want schema errors")
+	}
+	for _, want := range []string{
+		`testdata.basic.v1.MenuService.GetMenu: extra "command" is required by the extras schema`,
+		`testdata.basic.v1.MenuService.UpdateCount: extra "callback_query" is not declared by the extras schema, declared: command`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Run() error does not contain %q:\n%v", want, err)
+		}
+	}
+
+	plugin = testutil.MustCreatePlugin(t, set, "basic.proto")
+	conf.OptionsKey = "bot"
+	if err := Run(plugin, conf); err != nil {
+		t.Errorf("Run() for a key the schema does not list = %v", err)
+	}
+}
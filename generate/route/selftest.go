@@ -0,0 +1,131 @@
+package route
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// selfTestUniqueExtras are the extras whose values the self-test requires to
+// be unique within a service, besides every command alias and the keys of
+// Config.UniqueExtras.
+var selfTestUniqueExtras = []string{"callback_query", ExtraCallbackQueryPattern}
+
+// generateSelfTest writes <proto>.<key>.routes_test.go, a test in the package
+// of the generated code with one Test<Service><Key>Routes function per service.
+// It checks the routes as generated: no two routes of a service share a
+// command alias in the same chat type, a callback value or unique extra, and every extra still
+// satisfies the constraints the plugin enforces. Custom templates and
+// hand-edited route tables are then caught by go test, not at run time.
+func generateSelfTest(gen *protogen.Plugin, file *protogen.File, conf *Config, services []*template.ServiceDesc) {
+	pkg, prefix := conf.goPackage(file)
+	filename := prefix + fmt.Sprintf(".%s.routes_test.go", strings.ToLower(conf.OptionsKey))
+	g := gen.NewGeneratedFile(filename, pkg.ImportPath)
+	generateFileHeader(gen, file, g, conf)
+
+	unique := slices.Clone(selfTestUniqueExtras)
+	for _, key := range conf.UniqueExtras {
+		if key != ExtraCommand && !slices.Contains(unique, key) {
+			unique = append(unique, key)
+		}
+	}
+	ident := func(path, name string) string {
+		return g.QualifiedGoIdent(protogen.GoIdent{GoName: name, GoImportPath: protogen.GoImportPath(path)})
+	}
+	for _, sd := range services {
+		name := "Test" + sd.ServiceType + sd.OptionsKey + "Routes"
+		g.P("// ", name, " checks the ", sd.OptionsKey, " routes of ", sd.ServiceType, ": no two")
+		g.P("// routes share a command alias or callback value, and every extra is valid.")
+		g.P("func ", name, "(t *", ident("testing", "T"), ") {")
+		g.P("routes := []struct {")
+		g.P("Operation string")
+		g.P("Extra     map[string]string")
+		g.P("}{")
+		for _, md := range sd.Methods {
+			g.P("{")
+			g.P("Operation: ", strconv.Quote(md.OperationPath), ",")
+			if len(md.ExtraList) > 0 {
+				g.P("Extra: map[string]string{")
+				for _, kv := range md.ExtraList {
+					g.P(strconv.Quote(kv.Key), ": ", strconv.Quote(kv.Value), ",")
+				}
+				g.P("},")
+			}
+			g.P("},")
+		}
+		g.P("}")
+		if required := conf.requiredExtras(); len(required) > 0 {
+			g.P("required := ", goStringSlice(required))
+		}
+		g.P("unique := ", goStringSlice(unique))
+		g.P("group := ", ident("regexp", "MustCompile"), "(", strconv.Quote(groupPattern.String()), ")")
+		g.P("routed := make(map[string]string)")
+		g.P("for _, route := range routes {")
+		g.P("var commands []string")
+		g.P("for _, command := range ", ident("strings", "Split"), "(route.Extra[", strconv.Quote(ExtraCommand), "], \",\") {")
+		g.P("if command = ", ident("strings", "TrimSpace"), "(command); command != \"\" {")
+		g.P("commands = append(commands, command)")
+		g.P("}")
+		g.P("}")
+		g.P("chatType := route.Extra[", strconv.Quote(ExtraChatType), "]")
+		g.P("for i, command := range commands {")
+		g.P("if ", ident("slices", "Contains"), "(commands[:i], command) {")
+		g.P("t.Errorf(\"%s: ", ExtraCommand, " alias %q is listed twice\", route.Operation, command)")
+		g.P("} else if other, ok := routed[", strconv.Quote(ExtraCommand+"\x00"), "+chatType+\"\\x00\"+command]; ok {")
+		g.P("t.Errorf(\"%s: ", ExtraCommand, " alias %q is already routed to %s\", route.Operation, command, other)")
+		g.P("}")
+		g.P("routed[", strconv.Quote(ExtraCommand+"\x00"), "+chatType+\"\\x00\"+command] = route.Operation")
+		g.P("}")
+		g.P("for _, key := range unique {")
+		g.P("value, ok := route.Extra[key]")
+		g.P("if !ok {")
+		g.P("continue")
+		g.P("}")
+		g.P("if other, ok := routed[key+\"\\x00\"+value]; ok {")
+		g.P("t.Errorf(\"%s: %s %q is already routed to %s\", route.Operation, key, value, other)")
+		g.P("}")
+		g.P("routed[key+\"\\x00\"+value] = route.Operation")
+		g.P("}")
+		if len(conf.requiredExtras()) > 0 {
+			g.P("for _, key := range required {")
+			g.P("if route.Extra[key] == \"\" {")
+			g.P("t.Errorf(\"%s: extra %q is required by the template\", route.Operation, key)")
+			g.P("}")
+			g.P("}")
+		}
+		g.P("if pattern, ok := route.Extra[", strconv.Quote(ExtraCallbackQueryPattern), "]; ok {")
+		g.P("if _, err := ", ident("regexp", "Compile"), "(pattern); err != nil {")
+		g.P("t.Errorf(\"%s: invalid ", ExtraCallbackQueryPattern, " %q: %v\", route.Operation, pattern, err)")
+		g.P("}")
+		g.P("}")
+		g.P("if value, ok := route.Extra[", strconv.Quote(ExtraGroup), "]; ok && !group.MatchString(value) {")
+		g.P("t.Errorf(\"%s: invalid ", ExtraGroup, " %q\", route.Operation, value)")
+		g.P("}")
+		g.P("if value, ok := route.Extra[", strconv.Quote(ExtraTimeout), "]; ok {")
+		g.P("if d, err := ", ident("time", "ParseDuration"), "(value); err != nil || d <= 0 {")
+		g.P("t.Errorf(\"%s: invalid ", ExtraTimeout, " %q: must be a positive duration\", route.Operation, value)")
+		g.P("}")
+		g.P("}")
+		g.P("if value, ok := route.Extra[", strconv.Quote(ExtraRetry), "]; ok {")
+		g.P("if n, err := ", ident("strconv", "Atoi"), "(value); err != nil || n < 0 {")
+		g.P("t.Errorf(\"%s: invalid ", ExtraRetry, " %q: must be a non-negative integer\", route.Operation, value)")
+		g.P("}")
+		g.P("}")
+		g.P("}")
+		g.P("}")
+		g.P()
+	}
+}
+
+// goStringSlice returns the Go literal of a []string.
+func goStringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
@@ -0,0 +1,11 @@
+package route
+
+import "testing"
+
+func TestGoldenSelfTest(t *testing.T) {
+	conf := DefaultConfig()
+	conf.SelfTest = true
+	conf.UniqueExtras = []string{"command", "menu"}
+	content := generateSidecar(t, "testdata/pb/basic.pb", "basic.proto", conf, ".route.routes_test.go")
+	compareGolden(t, "testdata/golden/basic.route.routes_test.go", content)
+}
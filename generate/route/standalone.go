@@ -0,0 +1,94 @@
+package route
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// ReadDescriptorSet reads a serialized FileDescriptorSet, e.g. one written by
+// `buf build -o api.binpb` or `protoc --descriptor_set_out --include_imports`.
+func ReadDescriptorSet(path string) (*descriptorpb.FileDescriptorSet, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %s: %v", path, err)
+	}
+	return &set, nil
+}
+
+// NewDescriptorSetRequest builds the CodeGeneratorRequest protoc would send for
+// set, so the generator can run without protoc or buf. The set must include
+// every dependency of the files to generate. files lists the proto paths to
+// generate; when empty, every file of the set declaring a service is generated.
+func NewDescriptorSetRequest(set *descriptorpb.FileDescriptorSet, files []string) (*pluginpb.CodeGeneratorRequest, error) {
+	known := make(map[string]bool, len(set.GetFile()))
+	for _, fd := range set.GetFile() {
+		known[fd.GetName()] = true
+	}
+	for _, name := range files {
+		if !known[name] {
+			return nil, fmt.Errorf("file %q is not in the descriptor set", name)
+		}
+	}
+	if len(files) == 0 {
+		for _, fd := range set.GetFile() {
+			if len(fd.GetService()) > 0 {
+				files = append(files, fd.GetName())
+			}
+		}
+	}
+	return &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: files,
+		ProtoFile:      set.GetFile(),
+	}, nil
+}
+
+// WriteResponse writes the files of a CodeGeneratorResponse below outDir,
+// creating directories as needed, the way protoc does for --route_out. An error
+// reported by the generator is returned as is. Files whose content is already
+// on disk are not rewritten. Insertion points are not supported.
+func WriteResponse(resp *pluginpb.CodeGeneratorResponse, outDir string) error {
+	if resp.Error != nil {
+		return fmt.Errorf("%s", resp.GetError())
+	}
+	for _, file := range resp.GetFile() {
+		if file.GetInsertionPoint() != "" {
+			return fmt.Errorf("%s: insertion points are not supported", file.GetName())
+		}
+		name := filepath.FromSlash(file.GetName())
+		if !filepath.IsLocal(name) {
+			return fmt.Errorf("%s: generated file name escapes the output directory", file.GetName())
+		}
+		path := filepath.Join(outDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return err
+		}
+		if existing, err := os.ReadFile(path); err == nil && string(existing) == file.GetContent() {
+			// Leave unchanged outputs untouched, so build tools keyed on
+			// modification times do not rebuild them.
+			continue
+		}
+		if err := os.WriteFile(path, []byte(file.GetContent()), 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ResponseFiles returns the content of every file of resp keyed by its name,
+// such as for comparing two generations with DiffOutputs.
+func ResponseFiles(resp *pluginpb.CodeGeneratorResponse) map[string]string {
+	files := make(map[string]string, len(resp.GetFile()))
+	for _, file := range resp.GetFile() {
+		files[file.GetName()] = file.GetContent()
+	}
+	return files
+}
@@ -0,0 +1,117 @@
+package route
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+func TestNewDescriptorSetRequest(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+
+	req, err := NewDescriptorSetRequest(set, nil)
+	if err != nil {
+		t.Fatalf("NewDescriptorSetRequest failed: %v", err)
+	}
+	if got := strings.Join(req.GetFileToGenerate(), ";"); got != "basic.proto" {
+		t.Errorf("default FileToGenerate = %q, want basic.proto", got)
+	}
+
+	req, err = NewDescriptorSetRequest(set, []string{"sphere/options/options.proto"})
+	if err != nil {
+		t.Fatalf("NewDescriptorSetRequest failed: %v", err)
+	}
+	if got := strings.Join(req.GetFileToGenerate(), ";"); got != "sphere/options/options.proto" {
+		t.Errorf("explicit FileToGenerate = %q", got)
+	}
+
+	if _, err := NewDescriptorSetRequest(set, []string{"missing.proto"}); err == nil {
+		t.Error("expected an error for a file outside the set")
+	}
+}
+
+// TestDescriptorSetRun runs the whole standalone pipeline against a fixture
+// and checks the written file matches the protoc-driven golden apart from the
+// compiler version, which a descriptor set does not carry.
+func TestDescriptorSetRun(t *testing.T) {
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	req, err := NewDescriptorSetRequest(set, nil)
+	if err != nil {
+		t.Fatalf("NewDescriptorSetRequest failed: %v", err)
+	}
+	gen, err := protogen.Options{}.New(req)
+	if err != nil {
+		t.Fatalf("protogen.New failed: %v", err)
+	}
+	if err := Run(gen, DefaultConfig()); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	out := t.TempDir()
+	if err := WriteResponse(gen.Response(), out); err != nil {
+		t.Fatalf("WriteResponse failed: %v", err)
+	}
+
+	got, err := os.ReadFile(filepath.Join(out, "github.com/go-sphere/protoc-gen-route/generate/route/testdata/gen/basicv1/basic.route.pb.go"))
+	if err != nil {
+		t.Fatalf("read generated file: %v", err)
+	}
+	want, err := os.ReadFile("testdata/golden/basic.route.pb.go")
+	if err != nil {
+		t.Fatalf("read golden: %v", err)
+	}
+	if string(got) != strings.Replace(string(want), "v5.29.0", "(unknown)", 1) {
+		t.Errorf("standalone output differs from golden:\n%s", got)
+	}
+}
+
+func TestWriteResponse(t *testing.T) {
+	out := t.TempDir()
+	resp := &pluginpb.CodeGeneratorResponse{File: []*pluginpb.CodeGeneratorResponse_File{
+		{Name: proto.String("a/b/c.route.pb.go"), Content: proto.String("package b\n")},
+	}}
+	if err := WriteResponse(resp, out); err != nil {
+		t.Fatalf("WriteResponse failed: %v", err)
+	}
+	if got, err := os.ReadFile(filepath.Join(out, "a", "b", "c.route.pb.go")); err != nil || string(got) != "package b\n" {
+		t.Errorf("written file = %q, %v", got, err)
+	}
+
+	// An unchanged file is not rewritten.
+	written := filepath.Join(out, "a", "b", "c.route.pb.go")
+	old := time.Unix(1_000_000, 0)
+	if err := os.Chtimes(written, old, old); err != nil {
+		t.Fatal(err)
+	}
+	if err := WriteResponse(resp, out); err != nil {
+		t.Fatalf("WriteResponse failed: %v", err)
+	}
+	info, err := os.Stat(written)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !info.ModTime().Equal(old) {
+		t.Errorf("unchanged file was rewritten at %v", info.ModTime())
+	}
+
+	for name, resp := range map[string]*pluginpb.CodeGeneratorResponse{
+		"generator error": {Error: proto.String("boom")},
+		"escaping name": {File: []*pluginpb.CodeGeneratorResponse_File{
+			{Name: proto.String("../x.go"), Content: proto.String("")},
+		}},
+		"insertion point": {File: []*pluginpb.CodeGeneratorResponse_File{
+			{Name: proto.String("x.go"), InsertionPoint: proto.String("imports"), Content: proto.String("")},
+		}},
+	} {
+		if err := WriteResponse(resp, out); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
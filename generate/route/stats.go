@@ -0,0 +1,149 @@
+package route
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// runStats is the summary of a run Config.Stats reports: the files to
+// generate, what every options key routed and left out, and how long every
+// file took to render. Its methods do nothing on a nil runStats, the run
+// without stats.
+type runStats struct {
+	files   []*protogen.File
+	routed  map[*protogen.File]bool
+	keys    []keyStats
+	elapsed map[*protogen.File]time.Duration
+}
+
+// keyStats is the part of runStats of one options key.
+type keyStats struct {
+	key      string
+	services int
+	methods  int
+	skipped  []skippedMethod
+}
+
+// skippedMethod is a method with a rule of the options key that is not
+// generated, and why.
+type skippedMethod struct {
+	method string
+	reason string
+}
+
+// newRunStats returns the stats of a run of gen with conf, or nil when conf
+// reports none.
+func newRunStats(gen *protogen.Plugin, conf *Config) *runStats {
+	if !conf.Stats && conf.StatsOut == "" {
+		return nil
+	}
+	s := &runStats{
+		routed:  make(map[*protogen.File]bool),
+		elapsed: make(map[*protogen.File]time.Duration),
+	}
+	for _, file := range gen.Files {
+		if file.Generate {
+			s.files = append(s.files, file)
+		}
+	}
+	return s
+}
+
+// addKey counts the services and methods conf routes in the files to
+// generate, and the methods with a rule it leaves out: through a true skip
+// extra, the tags and env extras, or as streaming methods. Methods whose rule
+// or extras fail are left to the errors of generation.
+func (s *runStats) addKey(conf *Config) {
+	if s == nil {
+		return
+	}
+	ks := keyStats{key: conf.OptionsKey}
+	for _, file := range s.files {
+		for _, service := range file.Services {
+			var methods int
+			for _, method := range service.Methods {
+				rule, err := extractOptionsRule(method, conf.OptionsKey, conf.ruleExtension())
+				if err != nil || rule == nil {
+					continue
+				}
+				extra, ok, err := extractMethodExtras(service, method, conf.OptionsKey, conf.ruleExtension(), true, conf.ExtrasOverrides, conf.Vars, tagFilter{})
+				if err != nil {
+					continue
+				}
+				reason := conf.tagFilter().excludeReason(extra)
+				switch {
+				case !ok:
+					reason = fmt.Sprintf("%s extra is true", ExtraSkip)
+				case reason != "":
+				case isStreaming(method) && conf.Streaming != StreamingInclude:
+					reason = "streaming method"
+				default:
+					methods++
+					continue
+				}
+				ks.skipped = append(ks.skipped, skippedMethod{method: string(method.Desc.FullName()), reason: reason})
+			}
+			if methods > 0 {
+				ks.services++
+				ks.methods += methods
+				s.routed[file] = true
+			}
+		}
+	}
+	s.keys = append(s.keys, ks)
+}
+
+// addRender adds d to the render time of file.
+func (s *runStats) addRender(file *protogen.File, d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.elapsed[file] += d
+}
+
+// write prints s as text, the files in the order of the request.
+func (s *runStats) write(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "protoc-gen-route: stats: %d files processed, %d with routes\n", len(s.files), len(s.routed))
+	for _, ks := range s.keys {
+		fmt.Fprintf(&b, "  key %s: %d services, %d methods routed, %d skipped\n", ks.key, ks.services, ks.methods, len(ks.skipped))
+		for _, m := range ks.skipped {
+			fmt.Fprintf(&b, "    skipped %s: %s\n", m.method, m.reason)
+		}
+	}
+	if len(s.files) > 0 {
+		b.WriteString("  render time per file:\n")
+		for _, file := range s.files {
+			fmt.Fprintf(&b, "    %s: %v\n", file.Desc.Path(), s.elapsed[file].Round(time.Microsecond))
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// report writes s to conf.StatsOut, or with the warnings when it is empty.
+func (s *runStats) report(conf *Config) error {
+	if s == nil {
+		return nil
+	}
+	if conf.StatsOut == "" {
+		return s.write(conf.warningsWriter())
+	}
+	f, err := os.Create(conf.StatsOut)
+	if err != nil {
+		return fmt.Errorf("stats_out: %w", err)
+	}
+	if err := s.write(f); err != nil {
+		f.Close()
+		return fmt.Errorf("stats_out: %w", err)
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("stats_out: %w", err)
+	}
+	return nil
+}
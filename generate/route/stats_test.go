@@ -0,0 +1,71 @@
+package route
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+// renderTimePattern matches the render times of a stats report, which vary
+// from run to run.
+var renderTimePattern = regexp.MustCompile(`(?m)^(    \S+\.proto): \S+$`)
+
+func TestRunStats(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/complex.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+	var buf bytes.Buffer
+	stderr := warnings
+	t.Cleanup(func() { warnings = stderr })
+	warnings = &buf
+
+	plugin := testutil.MustCreatePlugin(t, set, "complex.proto")
+	conf := DefaultConfig()
+	conf.OptionsKey = "route;bot"
+	conf.Stats = true
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	want := `protoc-gen-route: warning: complex.proto:19:3: testdata.complex.v1.OrderService.Watch: skipping streaming method routed under key "route"
+protoc-gen-route: stats: 1 files processed, 1 with routes
+  key route: 2 services, 2 methods routed, 1 skipped
+    skipped testdata.complex.v1.OrderService.Watch: streaming method
+  key bot: 1 services, 1 methods routed, 0 skipped
+  render time per file:
+    complex.proto: <time>
+`
+	if got := renderTimePattern.ReplaceAllString(buf.String(), "$1: <time>"); got != want {
+		t.Errorf("stats =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestRunStats_TagsOut(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/tags.pb")
+	t.Cleanup(func() { _ = UseBuiltinTemplate("") })
+
+	plugin := testutil.MustCreatePlugin(t, set, "tags.proto")
+	conf := DefaultConfig()
+	conf.ExcludeTags = []string{"experimental"}
+	conf.IncludeTags = []string{"payments", "experimental"}
+	conf.StatsOut = filepath.Join(t.TempDir(), "stats.txt")
+	if err := Run(plugin, conf); err != nil {
+		t.Fatalf("Run failed: %v", err)
+	}
+	content, err := os.ReadFile(conf.StatsOut)
+	if err != nil {
+		t.Fatalf("stats_out not written: %v", err)
+	}
+	want := `protoc-gen-route: stats: 1 files processed, 1 with routes
+  key route: 1 services, 1 methods routed, 2 skipped
+    skipped testdata.tags.v1.ShopService.Start: no tag is included
+    skipped testdata.tags.v1.ShopService.Preview: tag "experimental" is excluded
+  render time per file:
+    tags.proto: <time>
+`
+	if got := renderTimePattern.ReplaceAllString(string(content), "$1: <time>"); got != want {
+		t.Errorf("stats =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,144 @@
+package route
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// TelegramScopeDefault is the scope of commands without a scope extra.
+const TelegramScopeDefault = "default"
+
+// telegramScopes are the BotCommandScope types a scope extra may name. The
+// chat-specific scopes need a chat id, which is only known at run time.
+var telegramScopes = map[string]bool{
+	TelegramScopeDefault:      true,
+	"all_private_chats":       true,
+	"all_group_chats":         true,
+	"all_chat_administrators": true,
+}
+
+// telegramCommandPattern is the command syntax accepted by setMyCommands.
+var telegramCommandPattern = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
+// telegramMaxDescription is the longest command description setMyCommands
+// accepts, in characters.
+const telegramMaxDescription = 256
+
+// telegramCommands is one setMyCommands request body.
+type telegramCommands struct {
+	Commands []telegramCommand `json:"commands"`
+	Scope    telegramScope     `json:"scope"`
+}
+
+type telegramCommand struct {
+	Command     string `json:"command"`
+	Description string `json:"description"`
+}
+
+type telegramScope struct {
+	Type string `json:"type"`
+}
+
+// generateTelegramCommands emits the .<key>.commands.json sidecar: a JSON array
+// holding one setMyCommands payload per scope used by the services rendered
+// into the Go file.
+func generateTelegramCommands(gen *protogen.Plugin, file *protogen.File, conf *Config, services []*template.ServiceDesc) error {
+	payloads, err := buildTelegramCommands(file, services)
+	if err != nil {
+		return err
+	}
+	content, err := json.MarshalIndent(payloads, "", "  ")
+	if err != nil {
+		return err
+	}
+	filename := file.GeneratedFilenamePrefix + fmt.Sprintf(".%s.commands.json", strings.ToLower(conf.OptionsKey))
+	g := gen.NewGeneratedFile(filename, "")
+	_, err = g.Write(append(content, '\n'))
+	return err
+}
+
+// buildTelegramCommands groups the methods with a command extra by their scope
+// extra. Only the first command of a method is listed, since aliases would
+// crowd the command menu, and its description is the method's summary, or its
+// name when it has no comment. The default scope comes first, then the others
+// by name.
+func buildTelegramCommands(file *protogen.File, services []*template.ServiceDesc) ([]telegramCommands, error) {
+	byScope := make(map[string]*telegramCommands)
+	seen := make(map[string]string) // scope and command -> method
+	var errs []error
+	for _, sd := range services {
+		for _, md := range sd.Methods {
+			if len(md.Commands) == 0 {
+				continue
+			}
+			desc := findMethod(file, md.OperationPath)
+			command := md.Commands[0]
+			description := md.CommandDescription()
+			scope := md.ExtraValue(ExtraScope)
+			if scope == "" {
+				scope = TelegramScopeDefault
+			}
+			switch {
+			case !telegramCommandPattern.MatchString(command):
+				errs = append(errs, descriptorErrorf(desc.Desc, "telegram command %q must be 1-32 lowercase letters, digits or underscores", command))
+				continue
+			case utf8.RuneCountInString(description) > telegramMaxDescription:
+				errs = append(errs, descriptorErrorf(desc.Desc, "telegram command description is longer than %d characters", telegramMaxDescription))
+				continue
+			case !telegramScopes[scope]:
+				errs = append(errs, descriptorErrorf(desc.Desc, "invalid telegram scope %q, expected one of %s", scope, strings.Join(sortedKeys(telegramScopes), ", ")))
+				continue
+			}
+			if other, ok := seen[scope+"/"+command]; ok {
+				errs = append(errs, descriptorErrorf(desc.Desc, "telegram command %q is already declared by %s in scope %s", command, other, scope))
+				continue
+			}
+			seen[scope+"/"+command] = string(desc.Desc.FullName())
+			payload := byScope[scope]
+			if payload == nil {
+				payload = &telegramCommands{Scope: telegramScope{Type: scope}}
+				byScope[scope] = payload
+			}
+			payload.Commands = append(payload.Commands, telegramCommand{Command: command, Description: description})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, joinErrors(errs)
+	}
+	scopes := sortedKeys(byScope)
+	sort.SliceStable(scopes, func(i, j int) bool { return scopes[i] == TelegramScopeDefault && scopes[j] != TelegramScopeDefault })
+	payloads := make([]telegramCommands, 0, len(scopes))
+	for _, scope := range scopes {
+		payloads = append(payloads, *byScope[scope])
+	}
+	return payloads, nil
+}
+
+// findMethod returns the method of file with the given operation path, e.g.
+// "/bot.v1.MenuService/UpdateCount".
+func findMethod(file *protogen.File, operation string) *protogen.Method {
+	for _, service := range file.Services {
+		for _, method := range service.Methods {
+			if operationPath(string(service.Desc.FullName()), string(method.Desc.Name())) == operation {
+				return method
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,47 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestGoldenTelegramCommands(t *testing.T) {
+	conf := DefaultConfig()
+	conf.TelegramCommands = true
+	content := generateSidecar(t, "testdata/pb/telegram.pb", "telegram.proto", conf, ".route.commands.json")
+	compareGolden(t, "testdata/golden/telegram.route.commands.json", content)
+}
+
+func TestBuildTelegramCommands_Errors(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/basic.pb")
+	file := testutil.FileToGenerate(t, testutil.MustCreatePlugin(t, set, "basic.proto"))
+	method := func(name, command, scope string) *template.MethodDesc {
+		return &template.MethodDesc{
+			OriginalName:  name,
+			OperationPath: operationPath("testdata.basic.v1.MenuService", name),
+			Commands:      []string{command},
+			Extra:         map[string]string{ExtraScope: scope},
+		}
+	}
+	tests := []struct {
+		name    string
+		methods []*template.MethodDesc
+		want    string
+	}{
+		{"invalid command", []*template.MethodDesc{method("GetMenu", "Menu", "")}, `telegram command "Menu" must be`},
+		{"invalid scope", []*template.MethodDesc{method("GetMenu", "menu", "chat")}, `invalid telegram scope "chat"`},
+		{"duplicate", []*template.MethodDesc{method("GetMenu", "menu", ""), method("UpdateCount", "menu", "default")}, `telegram command "menu" is already declared by testdata.basic.v1.MenuService.GetMenu`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			services := []*template.ServiceDesc{{ServiceName: "testdata.basic.v1.MenuService", Methods: tt.methods}}
+			_, err := buildTelegramCommands(file, services)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
@@ -7,14 +7,18 @@ package basicv1
 
 import (
 	context "context"
+	json "encoding/json"
+	errors "errors"
 	telegram "github.com/go-sphere/sphere/social/telegram"
 )
 
 var _ = new(context.Context)
 var _ = new(telegram.Update)
 
-const OperationRouteMenuServiceGetMenu = "/testdata.basic.v1.MenuService/GetMenu"
-const OperationRouteMenuServiceUpdateCount = "/testdata.basic.v1.MenuService/UpdateCount"
+const (
+	OperationRouteMenuServiceGetMenu     = "/testdata.basic.v1.MenuService/GetMenu"
+	OperationRouteMenuServiceUpdateCount = "/testdata.basic.v1.MenuService/UpdateCount"
+)
 
 func GetAllRouteMenuServiceOperations() []string {
 	return []string{
@@ -23,6 +27,62 @@ func GetAllRouteMenuServiceOperations() []string {
 	}
 }
 
+// MenuServiceRouteRoute describes a route of testdata.basic.v1.MenuService: its
+// operation, the proto method and message names, its commands and callback
+// query pattern, and its extras. It marshals to JSON for introspection.
+type MenuServiceRouteRoute struct {
+	Operation            string            `json:"operation"`
+	Method               string            `json:"method"`
+	Request              string            `json:"request"`
+	Reply                string            `json:"reply"`
+	Commands             []string          `json:"commands,omitempty"`
+	CallbackQueryPattern string            `json:"callback_query_pattern,omitempty"`
+	Extra                map[string]string `json:"extra,omitempty"`
+}
+
+// MenuServiceRouteRoutes lists the routes of
+// testdata.basic.v1.MenuService, highest priority first.
+var MenuServiceRouteRoutes = []MenuServiceRouteRoute{
+	{
+		Operation: OperationRouteMenuServiceGetMenu,
+		Method:    "GetMenu",
+		Request:   "testdata.basic.v1.GetMenuRequest",
+		Reply:     "testdata.basic.v1.GetMenuResponse",
+	},
+	{
+		Operation: OperationRouteMenuServiceUpdateCount,
+		Method:    "UpdateCount",
+		Request:   "testdata.basic.v1.UpdateCountRequest",
+		Reply:     "testdata.basic.v1.UpdateCountResponse",
+		Commands:  []string{"start"},
+		Extra: map[string]string{
+			"callback_query": "start",
+			"command":        "start",
+		},
+	},
+}
+
+// MarshalMenuServiceRouteRoutes returns MenuServiceRouteRoutes
+// as JSON, e.g. for an endpoint serving the live route table.
+func MarshalMenuServiceRouteRoutes() ([]byte, error) {
+	return json.Marshal(MenuServiceRouteRoutes)
+}
+
+// MenuServiceRouteCommandDescriptions returns every command, without
+// aliases, mapped to the first line of its method's comment, e.g. to build a
+// /help reply.
+func MenuServiceRouteCommandDescriptions() map[string]string {
+	return map[string]string{
+		"start": "UpdateCount updates the menu counter.",
+	}
+}
+
+// MenuServiceRouteCommands maps every command and command alias to
+// the operation handling it.
+var MenuServiceRouteCommands = map[string]string{
+	"start": OperationRouteMenuServiceUpdateCount,
+}
+
 type MenuServiceRouteServer interface {
 	// GetMenu GetMenu returns the menu and carries a route rule without extra data.
 	GetMenu(context.Context, *GetMenuRequest) (*GetMenuResponse, error)
@@ -31,6 +91,18 @@ type MenuServiceRouteServer interface {
 	UpdateCount(context.Context, *UpdateCountRequest) (*UpdateCountResponse, error)
 }
 
+// UnimplementedMenuServiceRouteServer can be embedded to have forward compatible
+// implementations: every method returns a not implemented error.
+type UnimplementedMenuServiceRouteServer struct{}
+
+func (UnimplementedMenuServiceRouteServer) GetMenu(context.Context, *GetMenuRequest) (*GetMenuResponse, error) {
+	return nil, errors.New("method GetMenu not implemented")
+}
+
+func (UnimplementedMenuServiceRouteServer) UpdateCount(context.Context, *UpdateCountRequest) (*UpdateCountResponse, error) {
+	return nil, errors.New("method UpdateCount not implemented")
+}
+
 type MenuServiceRouteCodec interface {
 	DecodeGetMenuRequest(ctx context.Context, request *telegram.Update) (*GetMenuRequest, error)
 	EncodeGetMenuResponse(ctx context.Context, response *GetMenuResponse) (*telegram.Message, error)
@@ -38,17 +110,17 @@ type MenuServiceRouteCodec interface {
 	EncodeUpdateCountResponse(ctx context.Context, response *UpdateCountResponse) (*telegram.Message, error)
 }
 
-func _MenuService_UpdateCount0_Route_Handler(srv MenuServiceRouteServer, codec MenuServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) func(ctx context.Context, request *telegram.Update) error {
+func _MenuService_GetMenu_Route_Handler(srv MenuServiceRouteServer, codec MenuServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) func(ctx context.Context, request *telegram.Update) error {
 	return func(ctx context.Context, request *telegram.Update) error {
-		req, err := codec.DecodeUpdateCountRequest(ctx, request)
+		req, err := codec.DecodeGetMenuRequest(ctx, request)
 		if err != nil {
 			return err
 		}
-		resp, err := srv.UpdateCount(ctx, req)
+		resp, err := srv.GetMenu(ctx, req)
 		if err != nil {
 			return err
 		}
-		msg, err := codec.EncodeUpdateCountResponse(ctx, resp)
+		msg, err := codec.EncodeGetMenuResponse(ctx, resp)
 		if err != nil {
 			return err
 		}
@@ -56,17 +128,17 @@ func _MenuService_UpdateCount0_Route_Handler(srv MenuServiceRouteServer, codec M
 	}
 }
 
-func _MenuService_GetMenu0_Route_Handler(srv MenuServiceRouteServer, codec MenuServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) func(ctx context.Context, request *telegram.Update) error {
+func _MenuService_UpdateCount_Route_Handler(srv MenuServiceRouteServer, codec MenuServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) func(ctx context.Context, request *telegram.Update) error {
 	return func(ctx context.Context, request *telegram.Update) error {
-		req, err := codec.DecodeGetMenuRequest(ctx, request)
+		req, err := codec.DecodeUpdateCountRequest(ctx, request)
 		if err != nil {
 			return err
 		}
-		resp, err := srv.GetMenu(ctx, req)
+		resp, err := srv.UpdateCount(ctx, req)
 		if err != nil {
 			return err
 		}
-		msg, err := codec.EncodeGetMenuResponse(ctx, resp)
+		msg, err := codec.EncodeUpdateCountResponse(ctx, resp)
 		if err != nil {
 			return err
 		}
@@ -74,9 +146,64 @@ func _MenuService_GetMenu0_Route_Handler(srv MenuServiceRouteServer, codec MenuS
 	}
 }
 
-func RegisterMenuServiceRouteServer(srv MenuServiceRouteServer, codec MenuServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) map[string]func(ctx context.Context, request *telegram.Update) error {
+// MenuServiceRouteOption customizes the handlers returned by RegisterMenuServiceRouteServer.
+type MenuServiceRouteOption func(*menuServiceRouteOptions)
+
+type menuServiceRouteOptions struct {
+	codec        MenuServiceRouteCodec
+	errorHandler func(ctx context.Context, operation string, request *telegram.Update, err error) error
+	middleware   []func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error
+}
+
+// WithMenuServiceRouteCodec replaces the codec passed to RegisterMenuServiceRouteServer.
+func WithMenuServiceRouteCodec(codec MenuServiceRouteCodec) MenuServiceRouteOption {
+	return func(o *menuServiceRouteOptions) {
+		o.codec = codec
+	}
+}
+
+// WithMenuServiceRouteErrorHandler passes every error returned by a handler to
+// handler, which returns the error to report instead, or nil to drop it.
+func WithMenuServiceRouteErrorHandler(handler func(ctx context.Context, operation string, request *telegram.Update, err error) error) MenuServiceRouteOption {
+	return func(o *menuServiceRouteOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// WithMenuServiceRouteMiddleware wraps every handler in middleware, the first
+// outermost. Repeated options append.
+func WithMenuServiceRouteMiddleware(middleware ...func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error) MenuServiceRouteOption {
+	return func(o *menuServiceRouteOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// wrap applies the error handler and middleware of o to the handler of operation.
+func (o *menuServiceRouteOptions) wrap(operation string, handler func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error {
+	if o.errorHandler != nil {
+		next := handler
+		handler = func(ctx context.Context, request *telegram.Update) error {
+			if err := next(ctx, request); err != nil {
+				return o.errorHandler(ctx, operation, request, err)
+			}
+			return nil
+		}
+	}
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		handler = o.middleware[i](handler)
+	}
+	return handler
+}
+
+// RegisterMenuServiceRouteServer returns the handler of every operation of srv, keyed
+// by operation, customized by opts.
+func RegisterMenuServiceRouteServer(srv MenuServiceRouteServer, codec MenuServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error, opts ...MenuServiceRouteOption) map[string]func(ctx context.Context, request *telegram.Update) error {
+	options := &menuServiceRouteOptions{codec: codec}
+	for _, opt := range opts {
+		opt(options)
+	}
 	handlers := make(map[string]func(ctx context.Context, request *telegram.Update) error)
-	handlers[OperationRouteMenuServiceUpdateCount] = _MenuService_UpdateCount0_Route_Handler(srv, codec, render)
-	handlers[OperationRouteMenuServiceGetMenu] = _MenuService_GetMenu0_Route_Handler(srv, codec, render)
+	handlers[OperationRouteMenuServiceGetMenu] = options.wrap(OperationRouteMenuServiceGetMenu, _MenuService_GetMenu_Route_Handler(srv, options.codec, render))
+	handlers[OperationRouteMenuServiceUpdateCount] = options.wrap(OperationRouteMenuServiceUpdateCount, _MenuService_UpdateCount_Route_Handler(srv, options.codec, render))
 	return handlers
 }
@@ -7,13 +7,17 @@ package complexv1
 
 import (
 	context "context"
+	json "encoding/json"
+	errors "errors"
 	telegram "github.com/go-sphere/sphere/social/telegram"
 )
 
 var _ = new(context.Context)
 var _ = new(telegram.Update)
 
-const OperationRouteOrderServiceCreate = "/testdata.complex.v1.OrderService/Create"
+const (
+	OperationRouteOrderServiceCreate = "/testdata.complex.v1.OrderService/Create"
+)
 
 func GetExtraRouteDataByOrderServiceOperation(operation string) *telegram.MethodExtraData {
 	switch operation {
@@ -28,11 +32,49 @@ func GetAllRouteOrderServiceOperations() []string {
 	}
 }
 
+// OrderServiceRouteRoute describes a route of testdata.complex.v1.OrderService: its
+// operation, the proto method and message names, its commands and callback
+// query pattern, and its extras. It marshals to JSON for introspection.
+type OrderServiceRouteRoute struct {
+	Operation            string            `json:"operation"`
+	Method               string            `json:"method"`
+	Request              string            `json:"request"`
+	Reply                string            `json:"reply"`
+	Commands             []string          `json:"commands,omitempty"`
+	CallbackQueryPattern string            `json:"callback_query_pattern,omitempty"`
+	Extra                map[string]string `json:"extra,omitempty"`
+}
+
+// OrderServiceRouteRoutes lists the routes of
+// testdata.complex.v1.OrderService, highest priority first.
+var OrderServiceRouteRoutes = []OrderServiceRouteRoute{
+	{
+		Operation: OperationRouteOrderServiceCreate,
+		Method:    "Create",
+		Request:   "testdata.complex.v1.CreateOrderRequest",
+		Reply:     "testdata.complex.v1.CreateOrderResponse",
+	},
+}
+
+// MarshalOrderServiceRouteRoutes returns OrderServiceRouteRoutes
+// as JSON, e.g. for an endpoint serving the live route table.
+func MarshalOrderServiceRouteRoutes() ([]byte, error) {
+	return json.Marshal(OrderServiceRouteRoutes)
+}
+
 type OrderServiceRouteServer interface {
 	// Create Create is a unary method with a route rule.
 	Create(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error)
 }
 
+// UnimplementedOrderServiceRouteServer can be embedded to have forward compatible
+// implementations: every method returns a not implemented error.
+type UnimplementedOrderServiceRouteServer struct{}
+
+func (UnimplementedOrderServiceRouteServer) Create(context.Context, *CreateOrderRequest) (*CreateOrderResponse, error) {
+	return nil, errors.New("method Create not implemented")
+}
+
 type OrderServiceRouteCodec interface {
 	DecodeCreateRequest(ctx context.Context, request *telegram.Update) (*CreateOrderRequest, error)
 	EncodeCreateResponse(ctx context.Context, response *CreateOrderResponse) (*telegram.Message, error)
@@ -56,13 +98,70 @@ func _OrderService_Create0_Route_Handler(srv OrderServiceRouteServer, codec Orde
 	}
 }
 
-func RegisterOrderServiceRouteServer(srv OrderServiceRouteServer, codec OrderServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) map[string]func(ctx context.Context, request *telegram.Update) error {
+// OrderServiceRouteOption customizes the handlers returned by RegisterOrderServiceRouteServer.
+type OrderServiceRouteOption func(*orderServiceRouteOptions)
+
+type orderServiceRouteOptions struct {
+	codec        OrderServiceRouteCodec
+	errorHandler func(ctx context.Context, operation string, request *telegram.Update, err error) error
+	middleware   []func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error
+}
+
+// WithOrderServiceRouteCodec replaces the codec passed to RegisterOrderServiceRouteServer.
+func WithOrderServiceRouteCodec(codec OrderServiceRouteCodec) OrderServiceRouteOption {
+	return func(o *orderServiceRouteOptions) {
+		o.codec = codec
+	}
+}
+
+// WithOrderServiceRouteErrorHandler passes every error returned by a handler to
+// handler, which returns the error to report instead, or nil to drop it.
+func WithOrderServiceRouteErrorHandler(handler func(ctx context.Context, operation string, request *telegram.Update, err error) error) OrderServiceRouteOption {
+	return func(o *orderServiceRouteOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// WithOrderServiceRouteMiddleware wraps every handler in middleware, the first
+// outermost. Repeated options append.
+func WithOrderServiceRouteMiddleware(middleware ...func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error) OrderServiceRouteOption {
+	return func(o *orderServiceRouteOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// wrap applies the error handler and middleware of o to the handler of operation.
+func (o *orderServiceRouteOptions) wrap(operation string, handler func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error {
+	if o.errorHandler != nil {
+		next := handler
+		handler = func(ctx context.Context, request *telegram.Update) error {
+			if err := next(ctx, request); err != nil {
+				return o.errorHandler(ctx, operation, request, err)
+			}
+			return nil
+		}
+	}
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		handler = o.middleware[i](handler)
+	}
+	return handler
+}
+
+// RegisterOrderServiceRouteServer returns the handler of every operation of srv, keyed
+// by operation, customized by opts.
+func RegisterOrderServiceRouteServer(srv OrderServiceRouteServer, codec OrderServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error, opts ...OrderServiceRouteOption) map[string]func(ctx context.Context, request *telegram.Update) error {
+	options := &orderServiceRouteOptions{codec: codec}
+	for _, opt := range opts {
+		opt(options)
+	}
 	handlers := make(map[string]func(ctx context.Context, request *telegram.Update) error)
-	handlers[OperationRouteOrderServiceCreate] = _OrderService_Create0_Route_Handler(srv, codec, render)
+	handlers[OperationRouteOrderServiceCreate] = options.wrap(OperationRouteOrderServiceCreate, _OrderService_Create0_Route_Handler(srv, options.codec, render))
 	return handlers
 }
 
-const OperationRouteUserServiceCreate = "/testdata.complex.v1.UserService/Create"
+const (
+	OperationRouteUserServiceCreate = "/testdata.complex.v1.UserService/Create"
+)
 
 var ExtraRouteDataUserServiceCreate = telegram.NewMethodExtraData(map[string]string{
 	"scope": "user",
@@ -83,11 +182,52 @@ func GetAllRouteUserServiceOperations() []string {
 	}
 }
 
+// UserServiceRouteRoute describes a route of testdata.complex.v1.UserService: its
+// operation, the proto method and message names, its commands and callback
+// query pattern, and its extras. It marshals to JSON for introspection.
+type UserServiceRouteRoute struct {
+	Operation            string            `json:"operation"`
+	Method               string            `json:"method"`
+	Request              string            `json:"request"`
+	Reply                string            `json:"reply"`
+	Commands             []string          `json:"commands,omitempty"`
+	CallbackQueryPattern string            `json:"callback_query_pattern,omitempty"`
+	Extra                map[string]string `json:"extra,omitempty"`
+}
+
+// UserServiceRouteRoutes lists the routes of
+// testdata.complex.v1.UserService, highest priority first.
+var UserServiceRouteRoutes = []UserServiceRouteRoute{
+	{
+		Operation: OperationRouteUserServiceCreate,
+		Method:    "Create",
+		Request:   "testdata.complex.v1.CreateUserRequest",
+		Reply:     "testdata.complex.v1.CreateUserResponse",
+		Extra: map[string]string{
+			"scope": "user",
+		},
+	},
+}
+
+// MarshalUserServiceRouteRoutes returns UserServiceRouteRoutes
+// as JSON, e.g. for an endpoint serving the live route table.
+func MarshalUserServiceRouteRoutes() ([]byte, error) {
+	return json.Marshal(UserServiceRouteRoutes)
+}
+
 type UserServiceRouteServer interface {
 	// Create Create is a second method with the same GoName as OrderService.Create.
 	Create(context.Context, *CreateUserRequest) (*CreateUserResponse, error)
 }
 
+// UnimplementedUserServiceRouteServer can be embedded to have forward compatible
+// implementations: every method returns a not implemented error.
+type UnimplementedUserServiceRouteServer struct{}
+
+func (UnimplementedUserServiceRouteServer) Create(context.Context, *CreateUserRequest) (*CreateUserResponse, error) {
+	return nil, errors.New("method Create not implemented")
+}
+
 type UserServiceRouteCodec interface {
 	DecodeCreateRequest(ctx context.Context, request *telegram.Update) (*CreateUserRequest, error)
 	EncodeCreateResponse(ctx context.Context, response *CreateUserResponse) (*telegram.Message, error)
@@ -111,8 +251,63 @@ func _UserService_Create1_Route_Handler(srv UserServiceRouteServer, codec UserSe
 	}
 }
 
-func RegisterUserServiceRouteServer(srv UserServiceRouteServer, codec UserServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) map[string]func(ctx context.Context, request *telegram.Update) error {
+// UserServiceRouteOption customizes the handlers returned by RegisterUserServiceRouteServer.
+type UserServiceRouteOption func(*userServiceRouteOptions)
+
+type userServiceRouteOptions struct {
+	codec        UserServiceRouteCodec
+	errorHandler func(ctx context.Context, operation string, request *telegram.Update, err error) error
+	middleware   []func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error
+}
+
+// WithUserServiceRouteCodec replaces the codec passed to RegisterUserServiceRouteServer.
+func WithUserServiceRouteCodec(codec UserServiceRouteCodec) UserServiceRouteOption {
+	return func(o *userServiceRouteOptions) {
+		o.codec = codec
+	}
+}
+
+// WithUserServiceRouteErrorHandler passes every error returned by a handler to
+// handler, which returns the error to report instead, or nil to drop it.
+func WithUserServiceRouteErrorHandler(handler func(ctx context.Context, operation string, request *telegram.Update, err error) error) UserServiceRouteOption {
+	return func(o *userServiceRouteOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// WithUserServiceRouteMiddleware wraps every handler in middleware, the first
+// outermost. Repeated options append.
+func WithUserServiceRouteMiddleware(middleware ...func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error) UserServiceRouteOption {
+	return func(o *userServiceRouteOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// wrap applies the error handler and middleware of o to the handler of operation.
+func (o *userServiceRouteOptions) wrap(operation string, handler func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error {
+	if o.errorHandler != nil {
+		next := handler
+		handler = func(ctx context.Context, request *telegram.Update) error {
+			if err := next(ctx, request); err != nil {
+				return o.errorHandler(ctx, operation, request, err)
+			}
+			return nil
+		}
+	}
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		handler = o.middleware[i](handler)
+	}
+	return handler
+}
+
+// RegisterUserServiceRouteServer returns the handler of every operation of srv, keyed
+// by operation, customized by opts.
+func RegisterUserServiceRouteServer(srv UserServiceRouteServer, codec UserServiceRouteCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error, opts ...UserServiceRouteOption) map[string]func(ctx context.Context, request *telegram.Update) error {
+	options := &userServiceRouteOptions{codec: codec}
+	for _, opt := range opts {
+		opt(options)
+	}
 	handlers := make(map[string]func(ctx context.Context, request *telegram.Update) error)
-	handlers[OperationRouteUserServiceCreate] = _UserService_Create1_Route_Handler(srv, codec, render)
+	handlers[OperationRouteUserServiceCreate] = options.wrap(OperationRouteUserServiceCreate, _UserService_Create1_Route_Handler(srv, options.codec, render))
 	return handlers
 }
@@ -7,13 +7,17 @@ package complexv1
 
 import (
 	context "context"
+	json "encoding/json"
+	errors "errors"
 	telegram "github.com/go-sphere/sphere/social/telegram"
 )
 
 var _ = new(context.Context)
 var _ = new(telegram.Update)
 
-const OperationBotUserServiceDelete = "/testdata.complex.v1.UserService/Delete"
+const (
+	OperationBotUserServiceDelete = "/testdata.complex.v1.UserService/Delete"
+)
 
 func GetExtraBotDataByUserServiceOperation(operation string) *telegram.MethodExtraData {
 	switch operation {
@@ -28,18 +32,56 @@ func GetAllBotUserServiceOperations() []string {
 	}
 }
 
+// UserServiceBotRoute describes a route of testdata.complex.v1.UserService: its
+// operation, the proto method and message names, its commands and callback
+// query pattern, and its extras. It marshals to JSON for introspection.
+type UserServiceBotRoute struct {
+	Operation            string            `json:"operation"`
+	Method               string            `json:"method"`
+	Request              string            `json:"request"`
+	Reply                string            `json:"reply"`
+	Commands             []string          `json:"commands,omitempty"`
+	CallbackQueryPattern string            `json:"callback_query_pattern,omitempty"`
+	Extra                map[string]string `json:"extra,omitempty"`
+}
+
+// UserServiceBotRoutes lists the routes of
+// testdata.complex.v1.UserService, highest priority first.
+var UserServiceBotRoutes = []UserServiceBotRoute{
+	{
+		Operation: OperationBotUserServiceDelete,
+		Method:    "Delete",
+		Request:   "testdata.complex.v1.DeleteUserRequest",
+		Reply:     "testdata.complex.v1.DeleteUserResponse",
+	},
+}
+
+// MarshalUserServiceBotRoutes returns UserServiceBotRoutes
+// as JSON, e.g. for an endpoint serving the live route table.
+func MarshalUserServiceBotRoutes() ([]byte, error) {
+	return json.Marshal(UserServiceBotRoutes)
+}
+
 type UserServiceBotServer interface {
 	// Delete Delete only carries a rule under the "bot" key; it is skipped when
 	// generating the default "route" key but generated for a "bot" run.
 	Delete(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error)
 }
 
+// UnimplementedUserServiceBotServer can be embedded to have forward compatible
+// implementations: every method returns a not implemented error.
+type UnimplementedUserServiceBotServer struct{}
+
+func (UnimplementedUserServiceBotServer) Delete(context.Context, *DeleteUserRequest) (*DeleteUserResponse, error) {
+	return nil, errors.New("method Delete not implemented")
+}
+
 type UserServiceBotCodec interface {
 	DecodeDeleteRequest(ctx context.Context, request *telegram.Update) (*DeleteUserRequest, error)
 	EncodeDeleteResponse(ctx context.Context, response *DeleteUserResponse) (*telegram.Message, error)
 }
 
-func _UserService_Delete0_Bot_Handler(srv UserServiceBotServer, codec UserServiceBotCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) func(ctx context.Context, request *telegram.Update) error {
+func _UserService_Delete_Bot_Handler(srv UserServiceBotServer, codec UserServiceBotCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) func(ctx context.Context, request *telegram.Update) error {
 	return func(ctx context.Context, request *telegram.Update) error {
 		req, err := codec.DecodeDeleteRequest(ctx, request)
 		if err != nil {
@@ -57,8 +99,63 @@ func _UserService_Delete0_Bot_Handler(srv UserServiceBotServer, codec UserServic
 	}
 }
 
-func RegisterUserServiceBotServer(srv UserServiceBotServer, codec UserServiceBotCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error) map[string]func(ctx context.Context, request *telegram.Update) error {
+// UserServiceBotOption customizes the handlers returned by RegisterUserServiceBotServer.
+type UserServiceBotOption func(*userServiceBotOptions)
+
+type userServiceBotOptions struct {
+	codec        UserServiceBotCodec
+	errorHandler func(ctx context.Context, operation string, request *telegram.Update, err error) error
+	middleware   []func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error
+}
+
+// WithUserServiceBotCodec replaces the codec passed to RegisterUserServiceBotServer.
+func WithUserServiceBotCodec(codec UserServiceBotCodec) UserServiceBotOption {
+	return func(o *userServiceBotOptions) {
+		o.codec = codec
+	}
+}
+
+// WithUserServiceBotErrorHandler passes every error returned by a handler to
+// handler, which returns the error to report instead, or nil to drop it.
+func WithUserServiceBotErrorHandler(handler func(ctx context.Context, operation string, request *telegram.Update, err error) error) UserServiceBotOption {
+	return func(o *userServiceBotOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// WithUserServiceBotMiddleware wraps every handler in middleware, the first
+// outermost. Repeated options append.
+func WithUserServiceBotMiddleware(middleware ...func(func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error) UserServiceBotOption {
+	return func(o *userServiceBotOptions) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// wrap applies the error handler and middleware of o to the handler of operation.
+func (o *userServiceBotOptions) wrap(operation string, handler func(ctx context.Context, request *telegram.Update) error) func(ctx context.Context, request *telegram.Update) error {
+	if o.errorHandler != nil {
+		next := handler
+		handler = func(ctx context.Context, request *telegram.Update) error {
+			if err := next(ctx, request); err != nil {
+				return o.errorHandler(ctx, operation, request, err)
+			}
+			return nil
+		}
+	}
+	for i := len(o.middleware) - 1; i >= 0; i-- {
+		handler = o.middleware[i](handler)
+	}
+	return handler
+}
+
+// RegisterUserServiceBotServer returns the handler of every operation of srv, keyed
+// by operation, customized by opts.
+func RegisterUserServiceBotServer(srv UserServiceBotServer, codec UserServiceBotCodec, render func(ctx context.Context, request *telegram.Update, msg *telegram.Message) error, opts ...UserServiceBotOption) map[string]func(ctx context.Context, request *telegram.Update) error {
+	options := &userServiceBotOptions{codec: codec}
+	for _, opt := range opts {
+		opt(options)
+	}
 	handlers := make(map[string]func(ctx context.Context, request *telegram.Update) error)
-	handlers[OperationBotUserServiceDelete] = _UserService_Delete0_Bot_Handler(srv, codec, render)
+	handlers[OperationBotUserServiceDelete] = options.wrap(OperationBotUserServiceDelete, _UserService_Delete_Bot_Handler(srv, options.codec, render))
 	return handlers
 }
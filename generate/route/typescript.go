@@ -0,0 +1,37 @@
+package route
+
+import (
+	"slices"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/template"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// typeScriptRoutes collects the services generated for every options key and
+// proto file, in generation order, for the ts_out_file TypeScript file.
+type typeScriptRoutes template.TypeScriptDesc
+
+// add records the services generated for file; files without any are ignored.
+func (r *typeScriptRoutes) add(file *protogen.File, services []*template.ServiceDesc) {
+	if len(services) == 0 {
+		return
+	}
+	if source := file.Desc.Path(); !slices.Contains(r.Sources, source) {
+		r.Sources = append(r.Sources, source)
+	}
+	r.Services = append(r.Services, services...)
+}
+
+// generateTypeScript emits the ts_out_file TypeScript file of the services of
+// routes. Nothing is emitted when no service was generated.
+func generateTypeScript(gen *protogen.Plugin, conf *Config, routes *typeScriptRoutes) error {
+	if len(routes.Services) == 0 {
+		return nil
+	}
+	content, err := template.ExecuteTypeScript((*template.TypeScriptDesc)(routes))
+	if err != nil {
+		return err
+	}
+	_, err = gen.NewGeneratedFile(conf.TSOutFile, "").Write([]byte(content))
+	return err
+}
@@ -0,0 +1,212 @@
+package route
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// CheckUniqueExtras validates, across every file marked for generation, that no
+// two methods routed under conf.OptionsKey share a value for any of the extra
+// keys in conf.UniqueExtras. Such duplicates would silently overwrite each
+// other in a router keyed by that extra. Every alias of a command extra is
+// checked on its own, and for the chat_type extra of its method, as
+// checkCommands does: a command may be used once without a chat type and once
+// per chat type. All conflicts, and rules that cannot be read, are reported
+// together, each with the proto positions of both methods.
+func CheckUniqueExtras(gen *protogen.Plugin, conf *Config) error {
+	if len(conf.UniqueExtras) == 0 {
+		return nil
+	}
+	type seenAt struct {
+		method   string
+		position string
+	}
+	seen := make(map[string]map[string]seenAt, len(conf.UniqueExtras))
+	for _, key := range conf.UniqueExtras {
+		seen[key] = make(map[string]seenAt)
+	}
+	var errs []error
+	for _, file := range gen.Files {
+		if !file.Generate {
+			continue
+		}
+		for _, service := range file.Services {
+			for _, method := range service.Methods {
+				extra, ok, err := extractMethodExtras(service, method, conf.OptionsKey, conf.ruleExtension(), conf.Streaming == StreamingInclude, conf.ExtrasOverrides, conf.Vars, conf.tagFilter())
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				if !ok {
+					continue
+				}
+				for _, key := range conf.UniqueExtras {
+					value, has := extra[key]
+					if !has {
+						continue
+					}
+					values := []string{value}
+					if key == ExtraCommand {
+						values = splitExtraList(value)
+					}
+					current := seenAt{method: string(method.Desc.FullName()), position: descriptorPosition(method.Desc)}
+					for _, value := range values {
+						seenKey, chats := value, ""
+						if key == ExtraCommand {
+							seenKey = commandKey(value, extra[ExtraChatType])
+							if chatType := extra[ExtraChatType]; chatType != "" {
+								chats = fmt.Sprintf(" in %s chats", chatType)
+							}
+						}
+						if first, dup := seen[key][seenKey]; dup {
+							errs = append(errs, descriptorErrorf(method.Desc, "extra %q value %q under options key %q is already used by %s (%s)%s",
+								key, value, conf.OptionsKey, first.method, first.position, chats))
+							continue
+						}
+						seen[key][seenKey] = current
+					}
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validateMethodExtras checks the extras the generator interprets itself, so
+// that a malformed value fails generation at the method's position instead of
+// panicking in the generated code.
+func validateMethodExtras(method *protogen.Method, extra map[string]string) error {
+	if pattern, ok := extra[ExtraCallbackQueryPattern]; ok {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return descriptorErrorf(method.Desc, "invalid %s %q: %v", ExtraCallbackQueryPattern, pattern, err)
+		}
+	}
+	if spec, ok := extra[ExtraCron]; ok {
+		if err := validateCron(spec); err != nil {
+			return descriptorErrorf(method.Desc, "invalid %s %q: %v", ExtraCron, spec, err)
+		}
+	}
+	if name, ok := extra[ExtraName]; ok && !token.IsIdentifier(name) {
+		return descriptorErrorf(method.Desc, "invalid %s %q: must be a Go identifier", ExtraName, name)
+	}
+	if group, ok := extra[ExtraGroup]; ok && !groupPattern.MatchString(group) {
+		return descriptorErrorf(method.Desc, "invalid %s %q: must start with a letter and hold only letters, digits, '_' or '-'", ExtraGroup, group)
+	}
+	if chatType, ok := extra[ExtraChatType]; ok && !slices.Contains(chatTypes, chatType) {
+		return descriptorErrorf(method.Desc, "invalid %s %q: must be one of %s", ExtraChatType, chatType, strings.Join(chatTypes, ", "))
+	}
+	if roles, ok := extra[ExtraRoles]; ok && len(splitExtraList(roles)) == 0 {
+		return descriptorErrorf(method.Desc, "invalid %s %q: must list a role", ExtraRoles, roles)
+	}
+	if permission, ok := extra[ExtraPermission]; ok && strings.TrimSpace(permission) == "" {
+		return descriptorErrorf(method.Desc, "invalid %s %q: must name a permission", ExtraPermission, permission)
+	}
+	if envs, ok := extra[ExtraEnv]; ok {
+		list := splitExtraList(envs)
+		if len(list) == 0 {
+			return descriptorErrorf(method.Desc, "invalid %s %q: must list an environment", ExtraEnv, envs)
+		}
+		for _, env := range list {
+			if !groupPattern.MatchString(env) {
+				return descriptorErrorf(method.Desc, "invalid %s %q: environment %q must start with a letter and hold only letters, digits, '_' or '-'", ExtraEnv, envs, env)
+			}
+		}
+	}
+	if notice, ok := extra[ExtraDeprecated]; ok && strings.TrimSpace(notice) == "" {
+		return descriptorErrorf(method.Desc, "invalid %s %q: must be true, false or a migration notice", ExtraDeprecated, notice)
+	}
+	if flag, ok := extra[ExtraFeatureFlag]; ok && strings.TrimSpace(flag) == "" {
+		return descriptorErrorf(method.Desc, "invalid %s %q: must name a flag", ExtraFeatureFlag, flag)
+	}
+	return nil
+}
+
+// chatTypes are the values of a chat_type extra.
+var chatTypes = []string{"private", "group", "channel"}
+
+// groupPattern is the syntax of a group extra, which becomes part of the
+// generated registration function names.
+var groupPattern = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*$`)
+
+// checkRequiredExtras reports the first extra of required that method lacks.
+func checkRequiredExtras(method *protogen.Method, extra map[string]string, required []string) error {
+	for _, key := range required {
+		if extra[key] == "" {
+			return descriptorErrorf(method.Desc, "extra %q is required by the selected template", key)
+		}
+	}
+	return nil
+}
+
+// checkUnsupportedExtras reports every extra of method listed in unsupported,
+// which the selected template would silently drop.
+func checkUnsupportedExtras(method *protogen.Method, extra map[string]string, unsupported map[string]string) error {
+	var errs []error
+	for _, key := range sortedKeys(unsupported) {
+		if _, ok := extra[key]; ok {
+			errs = append(errs, descriptorErrorf(method.Desc, "extra %q is not supported with dispatch=%s, which generates no %s; use dispatch=%s", key, DispatchSwitch, unsupported[key], DispatchMap))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// checkCommands reports a command or alias of method that is listed twice or
+// already routed to another method of the service for the same chat type,
+// recorded in seen, which would make the aliases ambiguous. A command may be
+// routed once without a chat type and once per chat type. The commands of
+// method are added to seen.
+func checkCommands(method *protogen.Method, commands []string, chatType string, seen map[string]*protogen.Method) error {
+	for i, command := range commands {
+		if slices.Contains(commands[:i], command) {
+			return descriptorErrorf(method.Desc, "%s alias %q is listed twice", ExtraCommand, command)
+		}
+		if other, ok := seen[commandKey(command, chatType)]; ok {
+			if chatType != "" {
+				return descriptorErrorf(method.Desc, "%s alias %q is already routed to %s in %s chats", ExtraCommand, command, other.Desc.FullName(), chatType)
+			}
+			return descriptorErrorf(method.Desc, "%s alias %q is already routed to %s", ExtraCommand, command, other.Desc.FullName())
+		}
+	}
+	for _, command := range commands {
+		seen[commandKey(command, chatType)] = method
+	}
+	return nil
+}
+
+// checkMethodName reports a method whose name, its proto name or name extra,
+// is already the name of another method in seen, which would declare its
+// operation constant twice, and records it otherwise.
+func checkMethodName(method *protogen.Method, name string, seen map[string]*protogen.Method) error {
+	if other, ok := seen[name]; ok {
+		return descriptorErrorf(method.Desc, "method name %q is already used by %s", name, other.Desc.FullName())
+	}
+	seen[name] = method
+	return nil
+}
+
+// commandKey is the key of a command routed for chatType in the seen map of
+// checkCommands; a command without a chat type is its own key.
+func commandKey(command, chatType string) string {
+	if chatType == "" {
+		return command
+	}
+	return command + "\x00" + chatType
+}
+
+// descriptorPosition formats the source position of desc as "path:line:column"
+// (1-based). Files compiled without source info yield just the path.
+func descriptorPosition(desc protoreflect.Descriptor) string {
+	file := desc.ParentFile()
+	loc := file.SourceLocations().ByDescriptor(desc)
+	if loc.Path == nil {
+		return file.Path()
+	}
+	return fmt.Sprintf("%s:%d:%d", file.Path(), loc.StartLine+1, loc.StartColumn+1)
+}
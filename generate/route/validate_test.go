@@ -0,0 +1,237 @@
+package route
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+func TestCheckUniqueExtras(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/duplicate.pb")
+
+	t.Run("disabled", func(t *testing.T) {
+		plugin := testutil.MustCreatePluginForFiles(t, set, "basic.proto", "duplicate.proto")
+		if err := CheckUniqueExtras(plugin, DefaultConfig()); err != nil {
+			t.Errorf("CheckUniqueExtras() without unique extras = %v, want nil", err)
+		}
+	})
+
+	t.Run("conflicts across and within files", func(t *testing.T) {
+		plugin := testutil.MustCreatePluginForFiles(t, set, "basic.proto", "duplicate.proto")
+		conf := DefaultConfig()
+		conf.UniqueExtras = ParseList("command;callback_query")
+		err := CheckUniqueExtras(plugin, conf)
+		if err == nil {
+			t.Fatal("CheckUniqueExtras() = nil, want conflicts")
+		}
+		msg := err.Error()
+		for _, want := range []string{
+			`duplicate.proto:14:3: testdata.duplicate.v1.AdminService.Restart: extra "command" value "start"`,
+			`already used by testdata.basic.v1.MenuService.UpdateCount (basic.proto:`,
+			`testdata.duplicate.v1.AdminService.Close: extra "callback_query" value "panel"`,
+		} {
+			if !strings.Contains(msg, want) {
+				t.Errorf("error %q does not contain %q", msg, want)
+			}
+		}
+		if n := strings.Count(msg, "\n") + 1; n != 2 {
+			t.Errorf("got %d conflicts, want 2:\n%s", n, msg)
+		}
+	})
+
+	t.Run("only generated files are checked", func(t *testing.T) {
+		plugin := testutil.MustCreatePlugin(t, set, "basic.proto")
+		conf := DefaultConfig()
+		conf.UniqueExtras = []string{"command", "callback_query"}
+		if err := CheckUniqueExtras(plugin, conf); err != nil {
+			t.Errorf("CheckUniqueExtras() = %v, want nil", err)
+		}
+	})
+
+	t.Run("commands of two chat types", func(t *testing.T) {
+		chatSet := testutil.LoadDescriptorSet(t, "testdata/pb/chat_type.pb")
+		plugin := testutil.MustCreatePlugin(t, chatSet, "chat_type.proto")
+		conf := DefaultConfig()
+		conf.UniqueExtras = []string{"command"}
+		if err := CheckUniqueExtras(plugin, conf); err != nil {
+			t.Errorf("CheckUniqueExtras() = %v, want nil for /start in private and group chats", err)
+		}
+
+		conf.ExtrasOverrides = ExtrasOverrides{"testdata.chattype.v1.StartService.Help": {"route": {"chat_type": "group", "command": "start"}}}
+		err := CheckUniqueExtras(plugin, conf)
+		if err == nil || !strings.Contains(err.Error(), `extra "command" value "start" under options key "route" is already used by testdata.chattype.v1.StartService.Introduce`) ||
+			!strings.Contains(err.Error(), "in group chats") {
+			t.Errorf("CheckUniqueExtras() = %v, want /start routed twice in group chats", err)
+		}
+	})
+
+	t.Run("skipped methods are ignored", func(t *testing.T) {
+		skipSet := testutil.LoadDescriptorSet(t, "testdata/pb/skip.pb")
+		plugin := testutil.MustCreatePlugin(t, skipSet, "skip.proto")
+		conf := DefaultConfig()
+		conf.UniqueExtras = []string{"command"}
+		if err := CheckUniqueExtras(plugin, conf); err != nil {
+			t.Errorf("CheckUniqueExtras() = %v, want nil", err)
+		}
+	})
+}
+
+func TestCheckCommands(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/aliases.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "aliases.proto")
+	methods := testutil.FileToGenerate(t, plugin).Services[0].Methods
+	start, help := methods[0], methods[1]
+
+	seen := make(map[string]*protogen.Method)
+	if err := checkCommands(help, []string{"help"}, "", seen); err != nil {
+		t.Fatalf("checkCommands(help) = %v", err)
+	}
+	if err := checkCommands(start, []string{"start", "begin", "start"}, "", seen); err == nil || !strings.Contains(err.Error(), `alias "start" is listed twice`) {
+		t.Errorf("checkCommands() with a repeated alias = %v", err)
+	}
+	err := checkCommands(start, []string{"start", "help"}, "", seen)
+	if err == nil || !strings.Contains(err.Error(), `alias "help" is already routed to testdata.aliases.v1.StartService.Help`) {
+		t.Errorf("checkCommands() with another method's alias = %v", err)
+	}
+	if _, ok := seen["start"]; ok {
+		t.Error("a rejected method's commands were recorded")
+	}
+}
+
+func TestCheckCommands_ChatType(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/aliases.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "aliases.proto")
+	methods := testutil.FileToGenerate(t, plugin).Services[0].Methods
+	start, help := methods[0], methods[1]
+
+	seen := make(map[string]*protogen.Method)
+	if err := checkCommands(help, []string{"help"}, "", seen); err != nil {
+		t.Fatalf("checkCommands(help) = %v", err)
+	}
+	if err := checkCommands(start, []string{"help"}, "group", seen); err != nil {
+		t.Errorf("checkCommands() with a group-only alias of another method = %v", err)
+	}
+	err := checkCommands(help, []string{"help"}, "group", seen)
+	if err == nil || !strings.Contains(err.Error(), `alias "help" is already routed to testdata.aliases.v1.StartService.Start in group chats`) {
+		t.Errorf("checkCommands() with a duplicate group alias = %v", err)
+	}
+}
+
+func TestValidateMethodExtras_ChatType(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/groups.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "groups.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	for chatType, valid := range map[string]bool{"private": true, "group": true, "channel": true, "": false, "supergroup": false, "Private": false} {
+		err := validateMethodExtras(method, map[string]string{ExtraChatType: chatType})
+		if (err == nil) != valid {
+			t.Errorf("validateMethodExtras(chat_type %q) = %v, want valid %v", chatType, err, valid)
+		}
+	}
+}
+
+func TestValidateMethodExtras_Group(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/groups.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "groups.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	for group, valid := range map[string]bool{"admin": true, "read-only": true, "v2_beta": true, "": false, "2fa": false, "admin,public": false} {
+		err := validateMethodExtras(method, map[string]string{ExtraGroup: group})
+		if (err == nil) != valid {
+			t.Errorf("validateMethodExtras(group %q) = %v, want valid %v", group, err, valid)
+		}
+	}
+}
+
+func TestValidateMethodExtras_FeatureFlag(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/groups.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "groups.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	for flag, valid := range map[string]bool{"new_checkout": true, "checkout.v2": true, "": false, " ": false} {
+		err := validateMethodExtras(method, map[string]string{ExtraFeatureFlag: flag})
+		if (err == nil) != valid {
+			t.Errorf("validateMethodExtras(feature_flag %q) = %v, want valid %v", flag, err, valid)
+		}
+	}
+}
+
+func TestValidateMethodExtras_Authorization(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/groups.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "groups.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	tests := []struct {
+		extra map[string]string
+		valid bool
+	}{
+		{map[string]string{ExtraRoles: "admin"}, true},
+		{map[string]string{ExtraRoles: "admin, support"}, true},
+		{map[string]string{ExtraRoles: ""}, false},
+		{map[string]string{ExtraRoles: " , "}, false},
+		{map[string]string{ExtraPermission: "orders.refund"}, true},
+		{map[string]string{ExtraPermission: ""}, false},
+	}
+	for _, tt := range tests {
+		if err := validateMethodExtras(method, tt.extra); (err == nil) != tt.valid {
+			t.Errorf("validateMethodExtras(%v) = %v, want valid %v", tt.extra, err, tt.valid)
+		}
+	}
+}
+
+func TestValidateMethodExtras_Env(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/groups.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "groups.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	for envs, valid := range map[string]bool{"dev": true, "dev, staging": true, "": false, " , ": false, "dev,pre prod": false} {
+		err := validateMethodExtras(method, map[string]string{ExtraEnv: envs})
+		if (err == nil) != valid {
+			t.Errorf("validateMethodExtras(env %q) = %v, want valid %v", envs, err, valid)
+		}
+	}
+}
+
+func TestValidateMethodExtras_Deprecated(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/groups.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "groups.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	for notice, valid := range map[string]bool{"true": true, "false": true, "use /orders instead": true, "": false, " ": false} {
+		err := validateMethodExtras(method, map[string]string{ExtraDeprecated: notice})
+		if (err == nil) != valid {
+			t.Errorf("validateMethodExtras(deprecated %q) = %v, want valid %v", notice, err, valid)
+		}
+	}
+}
+
+func TestValidateMethodExtras_Name(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/aliases.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "aliases.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+
+	for name, valid := range map[string]bool{"StartMenu": true, "start_menu": true, "": false, "2Start": false, "Start-Menu": false, "func": false} {
+		err := validateMethodExtras(method, map[string]string{ExtraName: name})
+		if (err == nil) != valid {
+			t.Errorf("validateMethodExtras(name %q) = %v, want valid %v", name, err, valid)
+		}
+	}
+}
+
+func TestCheckMethodName(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/aliases.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "aliases.proto")
+	methods := testutil.FileToGenerate(t, plugin).Services[0].Methods
+	start, help := methods[0], methods[1]
+
+	seen := make(map[string]*protogen.Method)
+	if err := checkMethodName(start, "Start", seen); err != nil {
+		t.Fatalf("checkMethodName(start) = %v", err)
+	}
+	err := checkMethodName(help, "Start", seen)
+	if err == nil || !strings.Contains(err.Error(), `method name "Start" is already used by testdata.aliases.v1.StartService.Start`) {
+		t.Errorf("checkMethodName() with another method's name = %v", err)
+	}
+}
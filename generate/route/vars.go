@@ -0,0 +1,88 @@
+package route
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"sort"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// placeholderPattern matches a ${name} placeholder of an extra value, or the
+// escaped $${ of a literal ${.
+var placeholderPattern = regexp.MustCompile(`\$(\$?)\{([^}]*)\}`)
+
+// varNamePattern matches the name of a variable of Config.Vars.
+var varNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// interpolateExtras returns extra with the ${name} placeholders of its values
+// replaced by the values of vars; $${name} stands for a literal ${name}. A
+// placeholder without a variable fails at the position of desc. extra is
+// returned as is when no value holds a placeholder, and is never modified.
+func interpolateExtras(desc protoreflect.Descriptor, extra map[string]string, vars map[string]string) (map[string]string, error) {
+	keys := make([]string, 0, len(extra))
+	for key, value := range extra {
+		if strings.Contains(value, "${") {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return extra, nil
+	}
+	sort.Strings(keys)
+	resolved := maps.Clone(extra)
+	for _, key := range keys {
+		var err error
+		resolved[key] = placeholderPattern.ReplaceAllStringFunc(extra[key], func(placeholder string) string {
+			match := placeholderPattern.FindStringSubmatch(placeholder)
+			if match[1] != "" {
+				return placeholder[1:]
+			}
+			value, ok := vars[match[2]]
+			if !ok && err == nil {
+				if !varNamePattern.MatchString(match[2]) {
+					err = descriptorErrorf(desc, "extra %q: invalid placeholder %s: a variable name holds only letters, digits and '_'", key, placeholder)
+				} else {
+					err = descriptorErrorf(desc, "extra %q: unresolved placeholder %s; set it with var=%s=<value>", key, placeholder, match[2])
+				}
+			}
+			return value
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return resolved, nil
+}
+
+// varFlag collects the values of the repeatable var parameter, name=value.
+type varFlag map[string]string
+
+func (f *varFlag) String() string {
+	if f == nil {
+		return ""
+	}
+	values := make([]string, 0, len(*f))
+	for name, value := range *f {
+		values = append(values, name+"="+value)
+	}
+	sort.Strings(values)
+	return strings.Join(values, ",")
+}
+
+func (f *varFlag) Set(value string) error {
+	name, val, ok := strings.Cut(value, "=")
+	if !ok || !varNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid var %q, expected 'name=value' with a name of letters, digits and '_'", value)
+	}
+	if _, dup := (*f)[name]; dup {
+		return fmt.Errorf("var %q is set twice", name)
+	}
+	if *f == nil {
+		*f = make(varFlag)
+	}
+	(*f)[name] = val
+	return nil
+}
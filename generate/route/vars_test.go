@@ -0,0 +1,47 @@
+package route
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-sphere/protoc-gen-route/generate/internal/testutil"
+)
+
+func TestInterpolateExtras(t *testing.T) {
+	set := testutil.LoadDescriptorSet(t, "testdata/pb/vars.pb")
+	plugin := testutil.MustCreatePlugin(t, set, "vars.proto")
+	method := testutil.FileToGenerate(t, plugin).Services[0].Methods[0]
+	vars := map[string]string{"bot_prefix": "acme_", "empty": ""}
+
+	tests := []struct {
+		extra   map[string]string
+		want    map[string]string
+		wantErr string
+	}{
+		{nil, nil, ""},
+		{map[string]string{"command": "start"}, map[string]string{"command": "start"}, ""},
+		{map[string]string{"command": "${bot_prefix}start,${bot_prefix}begin", "group": "admin"}, map[string]string{"command": "acme_start,acme_begin", "group": "admin"}, ""},
+		{map[string]string{"command": "${empty}start"}, map[string]string{"command": "start"}, ""},
+		{map[string]string{"text": "$${bot_prefix} and $5 {x}"}, map[string]string{"text": "${bot_prefix} and $5 {x}"}, ""},
+		{map[string]string{"command": "${tenant}start"}, nil, `extra "command": unresolved placeholder ${tenant}; set it with var=tenant=<value>`},
+		{map[string]string{"command": "${bot prefix}start"}, nil, `extra "command": invalid placeholder ${bot prefix}`},
+	}
+	for _, tt := range tests {
+		before := maps.Clone(tt.extra)
+		got, err := interpolateExtras(method.Desc, tt.extra, vars)
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("interpolateExtras(%v) error = %v, want %q", tt.extra, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("interpolateExtras(%v) = %v, %v; want %v", tt.extra, got, err, tt.want)
+		}
+		if !reflect.DeepEqual(tt.extra, before) {
+			t.Errorf("interpolateExtras modified its input: %v, was %v", tt.extra, before)
+		}
+	}
+}
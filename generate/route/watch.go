@@ -0,0 +1,73 @@
+package route
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// fileState is what WatchFiles compares to detect a change: a missing file
+// has a zero state.
+type fileState struct {
+	size    int64
+	modTime time.Time
+}
+
+// WatchFiles polls paths every interval and calls onChange once they changed
+// and then stayed unchanged for a whole interval, so a burst of writes, such as
+// an editor saving a file in several steps or buf rewriting a descriptor set,
+// triggers a single rebuild. A directory is watched through every file below
+// it. A file that disappears or appears counts as a change. It returns when
+// ctx is done.
+func WatchFiles(ctx context.Context, paths []string, interval time.Duration, onChange func()) {
+	last := statFiles(paths)
+	changed := false
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		current := statFiles(paths)
+		switch {
+		case !sameStates(current, last):
+			last, changed = current, true
+		case changed:
+			changed = false
+			onChange()
+		}
+	}
+}
+
+// statFiles returns the state of every file of paths, expanding directories.
+func statFiles(paths []string) map[string]fileState {
+	states := make(map[string]fileState)
+	for _, path := range paths {
+		_ = filepath.WalkDir(path, func(name string, entry os.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return nil
+			}
+			if info, err := entry.Info(); err == nil {
+				states[name] = fileState{size: info.Size(), modTime: info.ModTime()}
+			}
+			return nil
+		})
+	}
+	return states
+}
+
+// sameStates reports whether a and b hold the same files in the same states.
+func sameStates(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	keys := sortedKeys(a)
+	return slices.IndexFunc(keys, func(name string) bool {
+		state, ok := b[name]
+		return !ok || state.size != a[name].size || !state.modTime.Equal(a[name].modTime)
+	}) < 0
+}
@@ -0,0 +1,66 @@
+package route
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatchFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "api.binpb")
+	if err := os.WriteFile(path, []byte("v1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	changes := make(chan struct{}, 10)
+	done := make(chan struct{})
+	go func() {
+		WatchFiles(ctx, []string{dir}, 20*time.Millisecond, func() { changes <- struct{}{} })
+		close(done)
+	}()
+
+	select {
+	case <-changes:
+		t.Fatal("change reported before any write")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A burst of writes is one change.
+	for _, content := range []string{"v22", "v333", "v4444"} {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported after a write")
+	}
+	select {
+	case <-changes:
+		t.Fatal("a burst of writes reported more than once")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// A new file under a watched directory is a change too.
+	if err := os.WriteFile(filepath.Join(dir, "new.tmpl"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-changes:
+	case <-time.After(2 * time.Second):
+		t.Fatal("no change reported after adding a file")
+	}
+
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("WatchFiles did not return after the context was canceled")
+	}
+}
@@ -5,4 +5,7 @@ go 1.23.0
 require (
 	github.com/go-sphere/options v0.0.1
 	google.golang.org/protobuf v1.36.11
+	sigs.k8s.io/yaml v1.6.0
 )
+
+require go.yaml.in/yaml/v2 v2.4.2 // indirect
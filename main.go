@@ -1,90 +1,262 @@
 package main
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"time"
 
 	"github.com/go-sphere/protoc-gen-route/generate/route"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
+// version is the protoc-gen-route version printed by -version and, with the
+// header_version parameter, written into generated files.
+const version = "0.0.1"
+
 var (
 	showVersion = flag.Bool("version", false, "print the version and exit")
 
-	optionsKey   = flag.String("options_key", "route", "options key in proto")
-	templateFile = flag.String("template_file", "", "template file, if not set, use default template")
+	descriptorSetIn = flag.String("descriptor_set_in", "", "generate from this FileDescriptorSet without protoc, writing to -out")
+	outDir          = flag.String("out", ".", "output directory when -descriptor_set_in is set")
+	files           = flag.String("files", "", "proto files of -descriptor_set_in to generate, separated by ';', default every file with a service")
+	paths           = flag.String("paths", "", "output paths mode when -descriptor_set_in is set: import or source_relative")
+	module          = flag.String("module", "", "with -descriptor_set_in, strip this import path prefix from the output paths, like protoc-gen-go's module=")
+
+	watch         = flag.Bool("watch", false, "with -descriptor_set_in, regenerate whenever the set, the template or the extras files change, printing a diff of the output")
+	watchPaths    = flag.String("watch_paths", "", "more files or directories -watch monitors, separated by ';', e.g. the proto sources")
+	watchBuild    = flag.String("watch_build", "", "shell command -watch runs before each rebuild, e.g. buf build -o api.binpb")
+	watchInterval = flag.Duration("watch_interval", 300*time.Millisecond, "how often -watch polls the files, and how long they must stay unchanged before a rebuild")
 
-	requestModel   = flag.String("request_model", "", "request model")
-	responseModel  = flag.String("response_model", "", "response model")
-	extraDataModel = flag.String("extra_data_model", "", "extra data model")
+	lintTemplate = flag.String("lint-template", "", "check this template file against fixture data without protoc and exit")
 
-	extraDataConstructor = flag.String("extra_data_constructor", "", "extra data constructor, and return a pointer of extra data")
+	flags = route.BindFlags(flag.CommandLine)
 )
 
 func main() {
-	flag.Parse()
+	if err := parseArgs(os.Args[1:]); err != nil {
+		fmt.Fprintf(os.Stderr, "protoc-gen-route: %v\n", err)
+		os.Exit(2)
+	}
 	if *showVersion {
-		fmt.Printf("protoc-gen-route %v\n", "0.0.1")
+		fmt.Printf("protoc-gen-route %v\n", version)
 		return
 	}
-	protogen.Options{
-		ParamFunc: flag.CommandLine.Set,
-	}.Run(func(gen *protogen.Plugin) error {
-		conf, err := extractConfig()
-		if err != nil {
-			return err
+	if flag.Arg(0) == "check" {
+		// Flags may also follow the subcommand.
+		if err := parseArgs(flag.Args()[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "protoc-gen-route: %v\n", err)
+			os.Exit(2)
 		}
-		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
-		err = route.ReplaceTemplateIfNeed(conf.TemplateFile)
-		if err != nil {
-			return err
+		if err := runCheck(); err != nil {
+			fmt.Fprintf(os.Stderr, "protoc-gen-route: %v\n", err)
+			os.Exit(1)
 		}
-		for _, f := range gen.Files {
-			if !f.Generate {
-				continue
-			}
-			_, gErr := route.GenerateFile(gen, f, conf)
-			if gErr != nil {
-				return gErr
-			}
+		return
+	}
+	if *lintTemplate != "" {
+		if err := runLint(); err != nil {
+			fmt.Fprintf(os.Stderr, "protoc-gen-route: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	if *watch {
+		if err := runWatch(); err != nil {
+			fmt.Fprintf(os.Stderr, "protoc-gen-route: %v\n", err)
+			os.Exit(1)
 		}
-		return nil
-	})
+		return
+	}
+	if *descriptorSetIn != "" {
+		if err := runDescriptorSet(); err != nil {
+			fmt.Fprintf(os.Stderr, "protoc-gen-route: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+	protogen.Options{
+		ParamFunc: flags.Set,
+	}.Run(run)
 }
 
-func extractConfig() (*route.Config, error) {
-	_requestModel, err := route.ParseGoIdent(*requestModel)
+// parseArgs parses the command-line args, setting the per-key parameters such
+// as -bot.request_model=... through flags, which the flag set does not define.
+func parseArgs(args []string) error {
+	rest, err := flags.SetKeyArgs(args)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	_responseModel, err := route.ParseGoIdent(*responseModel)
+	return flag.CommandLine.Parse(rest)
+}
+
+func run(gen *protogen.Plugin) error {
+	conf, err := flags.Config()
 	if err != nil {
-		return nil, err
+		return err
 	}
+	conf.Version = version
+	route.DeclareSupport(gen)
+	return route.Run(gen, conf)
+}
 
-	conf := &route.Config{
-		OptionsKey:   *optionsKey,
-		TemplateFile: *templateFile,
-
-		RequestType:  _requestModel,
-		ResponseType: _responseModel,
+// runDescriptorSet is the standalone mode: the generator parameters come from
+// the command line and the files from -descriptor_set_in instead of protoc.
+func runDescriptorSet() error {
+	resp, err := generateDescriptorSet()
+	if err != nil {
+		return err
 	}
+	return route.WriteResponse(resp, *outDir)
+}
 
-	if *extraDataModel == "" {
-		return conf, nil
+// generateDescriptorSet generates the files of -descriptor_set_in.
+func generateDescriptorSet() (*pluginpb.CodeGeneratorResponse, error) {
+	gen, err := descriptorSetPlugin()
+	if err != nil {
+		return nil, err
+	}
+	if err := run(gen); err != nil {
+		return nil, err
 	}
+	return gen.Response(), nil
+}
 
-	_extraDataModel, err := route.ParseGoIdent(*extraDataModel)
+// descriptorSetPlugin returns the plugin of a request for the files of
+// -descriptor_set_in.
+func descriptorSetPlugin() (*protogen.Plugin, error) {
+	set, err := route.ReadDescriptorSet(*descriptorSetIn)
 	if err != nil {
 		return nil, err
 	}
-	_extraDataConstructor, err := route.ParseGoIdent(*extraDataConstructor)
+	req, err := route.NewDescriptorSetRequest(set, route.ParseList(*files))
 	if err != nil {
 		return nil, err
 	}
-	conf.ExtraType = _extraDataModel
-	conf.ExtraConstructor = _extraDataConstructor
+	var params []string
+	if *paths != "" {
+		params = append(params, "paths="+*paths)
+	}
+	if *module != "" {
+		params = append(params, "module="+*module)
+	}
+	if len(params) > 0 {
+		req.Parameter = proto.String(strings.Join(params, ","))
+	}
+	return protogen.Options{}.New(req)
+}
+
+// runCheck is the check subcommand: the files of -descriptor_set_in are
+// checked for route conflicts with the generator parameters from the command
+// line, without writing anything.
+func runCheck() error {
+	if *descriptorSetIn == "" {
+		return fmt.Errorf("check needs -descriptor_set_in")
+	}
+	gen, err := descriptorSetPlugin()
+	if err != nil {
+		return err
+	}
+	conf, err := flags.CheckConfig()
+	if err != nil {
+		return err
+	}
+	route.DeclareSupport(gen)
+	if err := route.Check(gen, conf); err != nil {
+		return err
+	}
+	var checked int
+	for _, file := range gen.Files {
+		if file.Generate {
+			checked++
+		}
+	}
+	fmt.Printf("protoc-gen-route: no route conflicts in %d files\n", checked)
+	return nil
+}
+
+// runWatch is the watch mode: the standalone mode reruns whenever its inputs
+// change, until interrupted, printing what changed in the output. A failed
+// rebuild is reported and the previous output kept.
+func runWatch() error {
+	if *descriptorSetIn == "" {
+		return fmt.Errorf("-watch needs -descriptor_set_in")
+	}
+	watched := []string{*descriptorSetIn}
+	for _, name := range []string{"template_file", "template_dir", "extras_schema", "extras_overrides", "license_file"} {
+		if value := flag.Lookup(name).Value.String(); value != "" {
+			watched = append(watched, value)
+		}
+	}
+	for _, r := range flags.Renders() {
+		watched = append(watched, r.TemplateFile)
+	}
+	watched = append(watched, route.ParseList(*watchPaths)...)
+	color := useColor()
 
-	return conf, nil
+	var prev map[string]string
+	rebuild := func() {
+		if *watchBuild != "" {
+			cmd := exec.Command("sh", "-c", *watchBuild)
+			cmd.Stdout, cmd.Stderr = os.Stderr, os.Stderr
+			if err := cmd.Run(); err != nil {
+				fmt.Fprintf(os.Stderr, "protoc-gen-route: %s: %v\n", *watchBuild, err)
+				return
+			}
+		}
+		resp, err := generateDescriptorSet()
+		if err == nil {
+			err = route.WriteResponse(resp, *outDir)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "protoc-gen-route: %v\n", err)
+			return
+		}
+		next := route.ResponseFiles(resp)
+		if prev == nil {
+			fmt.Printf("protoc-gen-route: generated %d files\n", len(next))
+		} else if diff := route.DiffOutputs(prev, next, color); diff != "" {
+			fmt.Print(diff)
+		} else {
+			fmt.Println("protoc-gen-route: output unchanged")
+		}
+		prev = next
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	rebuild()
+	fmt.Fprintf(os.Stderr, "protoc-gen-route: watching %d paths, press Ctrl+C to stop\n", len(watched))
+	route.WatchFiles(ctx, watched, *watchInterval, rebuild)
+	return nil
+}
+
+// useColor reports whether the diffs of -watch are colored: when stdout is a
+// terminal and NO_COLOR is not set.
+func useColor() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := os.Stdout.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// runLint is the lint mode: the template of -lint-template, with the generator
+// parameters from the command line, is rendered against fixture data.
+func runLint() error {
+	if err := flag.Set("template_file", *lintTemplate); err != nil {
+		return err
+	}
+	conf, err := flags.Config()
+	if err != nil {
+		return err
+	}
+	conf.Version = version
+	return route.LintTemplate(conf)
 }
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestMain runs main instead of the tests when the test binary is started by
+// runMain, so the tests see the command line as the plugin does.
+func TestMain(m *testing.M) {
+	if os.Getenv("PROTOC_GEN_ROUTE_RUN_MAIN") == "1" {
+		main()
+		os.Exit(0)
+	}
+	os.Exit(m.Run())
+}
+
+// runMain runs protoc-gen-route with args and returns its combined output.
+func runMain(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], args...)
+	cmd.Env = append(os.Environ(), "PROTOC_GEN_ROUTE_RUN_MAIN=1")
+	out, err := cmd.CombinedOutput()
+	return string(out), err
+}
+
+func TestMain_KeyModels(t *testing.T) {
+	dir := t.TempDir()
+	out, err := runMain(t,
+		"-descriptor_set_in", "generate/route/testdata/pb/multi_key.pb",
+		"-out", dir,
+		"-options_key=job",
+		"-job.request_model=example.com/job;Event",
+		"--job.response_model", "example.com/job;Result",
+	)
+	if err != nil {
+		t.Fatalf("protoc-gen-route failed: %v\n%s", err, out)
+	}
+	var generated int
+	err = filepath.WalkDir(dir, func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		generated++
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(string(content), `"example.com/job"`) {
+			t.Errorf("%s does not import the job models:\n%s", name, content)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if generated == 0 {
+		t.Error("no files generated")
+	}
+}
+
+func TestMain_UnknownKeyParam(t *testing.T) {
+	out, err := runMain(t, "-job.request_mode=example.com/job;Event")
+	if err == nil || !strings.Contains(out, "per-key parameters are") {
+		t.Errorf("protoc-gen-route = %v\n%s\nwant the per-key parameters error", err, out)
+	}
+}
+
+func TestMain_CheckWithoutModels(t *testing.T) {
+	out, err := runMain(t, "check", "-descriptor_set_in", "generate/route/testdata/pb/basic.pb", "-options_key", "route")
+	if err != nil {
+		t.Fatalf("check failed: %v\n%s", err, out)
+	}
+	if want := "no route conflicts in 1 files"; !strings.Contains(out, want) {
+		t.Errorf("check output = %q, want %q", out, want)
+	}
+
+	out, err = runMain(t, "check", "-descriptor_set_in", "generate/route/testdata/pb/check.pb", "-options_key", "route")
+	if err == nil || !strings.Contains(out, "is already routed to") {
+		t.Errorf("check = %v\n%s\nwant the route conflicts", err, out)
+	}
+}